@@ -2,22 +2,115 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/download"
+	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 )
 
-//API provides a struct to wrap the api around
+// API provides a struct to wrap the api around
 type API struct {
-	Router *mux.Router
+	Router             *mux.Router
+	maxResolveDepth    int
+	defaultLimit       int
+	maxLimit           int
+	maxMovePages       int
+	maxRedirectRows    int
+	maxRedirectBytes   int64
+	cacheMaxAge        time.Duration
+	cacheReleaseMaxAge time.Duration
+	downloads          *download.Cache
+	contentStore       ContentStore
+	collectionStore    CollectionStore
+	auditStore         AuditStore
+	redirectStore      RedirectStore
+	scheduler          Scheduler
+	eventProducer      EventProducer
 }
 
-//Setup function sets up the api and returns an api
-func Setup(ctx context.Context, r *mux.Router) *API {
+// Setup function sets up the api and returns an api
+func Setup(ctx context.Context, cfg *config.Config, r *mux.Router, contentStore ContentStore, collectionStore CollectionStore, auditStore AuditStore, redirectStore RedirectStore, scheduler Scheduler, eventProducer EventProducer) *API {
 	api := &API{
-		Router: r,
+		Router:             r,
+		maxResolveDepth:    cfg.ResolveMaxDepth,
+		defaultLimit:       cfg.DefaultLimit,
+		maxLimit:           cfg.DefaultMaxLimit,
+		maxMovePages:       cfg.MoveMaxPages,
+		maxRedirectRows:    cfg.RedirectsMaxRows,
+		maxRedirectBytes:   cfg.RedirectsMaxBytes,
+		cacheMaxAge:        cfg.CacheMaxAge,
+		cacheReleaseMaxAge: cfg.CacheReleaseMaxAge,
+		downloads:          download.NewCache(cfg.DownloadCacheSize),
+		contentStore:       tracedContentStore{store: contentStore},
+		collectionStore:    tracedCollectionStore{store: collectionStore},
+		auditStore:         tracedAuditStore{store: auditStore},
+		redirectStore:      tracedRedirectStore{store: redirectStore},
+		scheduler:          scheduler,
+		eventProducer:      eventProducer,
 	}
 
-	// TODO: remove hello world example handler route
-	r.HandleFunc("/hello", HelloHandler(ctx)).Methods("GET")
+	// requests that match no route are answered with the same error responses as the handlers
+	r.NotFoundHandler = http.HandlerFunc(notFoundHandler)
+	r.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
+
+	// published content can be read by anyone, whereas collections and their drafts require a permission. Actions on
+	// a page are routed separately from the page itself, so that no page URI is taken to be an action.
+	r.HandleFunc("/v1/content-actions/versions/{uri:.*}", api.getVersionsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/v1/content-actions/breadcrumb/{uri:.*}", api.getBreadcrumbHandler).Methods(http.MethodGet)
+	r.HandleFunc("/v1/content-actions/children/{uri:.*}", api.getChildrenHandler).Methods(http.MethodGet)
+	r.HandleFunc("/v1/content-actions/move/{uri:.*}", authorised(auth.PermissionEdit, api.moveContentHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/content-actions/confirm/{uri:.*}", authorised(auth.PermissionEdit, api.confirmReleaseHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/content-actions/postpone/{uri:.*}", authorised(auth.PermissionEdit, api.postponeReleaseHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/content-actions/cancel/{uri:.*}", authorised(auth.PermissionEdit, api.cancelReleaseHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/content/{uri:.*}", api.getContentHandler).Methods(http.MethodGet)
+	r.HandleFunc("/v1/content/{uri:.*}", authorised(auth.PermissionEdit, api.putContentHandler)).Methods(http.MethodPut)
+	r.HandleFunc("/v1/content/{uri:.*}", authorised(auth.PermissionEdit, api.postContentHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/content/{uri:.*}", authorised(auth.PermissionEdit, api.deleteContentHandler)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/v1/collections", authorised(auth.PermissionRead, api.getCollectionsHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/collections", authorised(auth.PermissionEdit, api.postCollectionHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/collections/{id}", authorised(auth.PermissionRead, api.getCollectionHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/collections/{id}", authorised(auth.PermissionEdit, api.deleteCollectionHandler)).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/collections/{id}/content/{uri:.*}", authorised(auth.PermissionRead, api.getDraftHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/collections/{id}/content/{uri:.*}", authorised(auth.PermissionEdit, api.putDraftHandler)).Methods(http.MethodPut)
+	r.HandleFunc("/v1/collections/{id}/content/{uri:.*}", authorised(auth.PermissionEdit, api.deleteDraftHandler)).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/collections/{id}/complete/{uri:.*}", authorised(auth.PermissionEdit, api.completeItemHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/collections/{id}/review/{uri:.*}", authorised(auth.PermissionEdit, api.reviewItemHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/collections/{id}/publish", authorised(auth.PermissionEdit, api.publishCollectionHandler)).Methods(http.MethodPost)
+
+	// the legacy Zebedee data endpoint, for clients that have not yet moved to the content endpoints
+	r.HandleFunc("/data", api.getDataHandler).Methods(http.MethodGet)
+	r.HandleFunc("/data/{collection_id}", api.getDataHandler).Methods(http.MethodGet)
+
+	r.HandleFunc("/v1/releases", api.getReleasesHandler).Methods(http.MethodGet)
+
+	r.HandleFunc("/v1/timeseries/{cdid}/data", api.getTimeseriesDataHandler).Methods(http.MethodGet)
+	r.HandleFunc("/v1/timeseries/{cdid}/data", authorised(auth.PermissionEdit, api.patchTimeseriesDataHandler)).Methods(http.MethodPatch)
+
+	r.HandleFunc("/v1/translations/missing", authorised(auth.PermissionRead, api.getUntranslatedHandler)).Methods(http.MethodGet)
+
+	r.HandleFunc("/v1/redirects", authorised(auth.PermissionRead, api.getRedirectsHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/redirects", authorised(auth.PermissionEdit, api.postRedirectsHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/v1/redirects/{uri:.*}", authorised(auth.PermissionRead, api.getRedirectHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/redirects/{uri:.*}", authorised(auth.PermissionEdit, api.putRedirectHandler)).Methods(http.MethodPut)
+	r.HandleFunc("/v1/redirects/{uri:.*}", authorised(auth.PermissionEdit, api.deleteRedirectHandler)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/v1/audit", authorised(auth.PermissionRead, api.getAuditHandler)).Methods(http.MethodGet)
 	return api
 }
+
+// authorised only calls the handler if the caller has the permission
+func authorised(permission auth.Permission, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if err := auth.Check(ctx, permission); err != nil {
+			handleError(ctx, w, err, log.Data{"permission": permission})
+			return
+		}
+		handler(w, req)
+	}
+}
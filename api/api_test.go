@@ -2,9 +2,15 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -13,12 +19,134 @@ func TestSetup(t *testing.T) {
 	Convey("Given an API instance", t, func() {
 		r := mux.NewRouter()
 		ctx := context.Background()
-		api := Setup(ctx, r)
+		store := memory.New()
+		api := Setup(ctx, &config.Config{}, r, store, store, store, store, nil, nil)
 
-		// TODO: remove hello world example handler route test case
 		Convey("When created the following routes should have been added", func() {
-			// Replace the check below with any newly added api endpoints
-			So(hasRoute(api.Router, "/hello", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/content/economy/inflationandpriceindices", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/content/economy/inflationandpriceindices", "PUT"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/content/economy/inflationandpriceindices", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/content/economy/inflationandpriceindices", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/content-actions/versions/economy/inflationandpriceindices", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/content/economy/inflationandpriceindices/previous/v1", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123/content/economy", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123/content/economy", "PUT"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123/content/economy", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123/complete/economy", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123/review/economy", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/collections/123/publish", "POST"), ShouldBeTrue)
+		})
+	})
+}
+
+func TestAuthorisation(t *testing.T) {
+	Convey("Given an API with a published page and a collection", t, func() {
+		ctx := context.Background()
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Data: json.RawMessage(`{"type":"static_page"}`)}), ShouldBeNil)
+		So(store.CreateCollection(ctx, &models.Collection{ID: "123", Name: "March 2021 inflation", State: models.CollectionStateInProgress}), ShouldBeNil)
+		api := Setup(ctx, &config.Config{}, mux.NewRouter(), store, store, store, store, nil, nil)
+
+		viewer := &auth.Identity{ID: "viewer@ons.gov.uk", Role: auth.RoleViewer}
+		serve := func(identity *auth.Identity, method, target string, header ...string) int {
+			req := httptest.NewRequest(method, target, nil)
+			if identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), identity))
+			}
+			if len(header) == 2 {
+				req.Header.Set(header[0], header[1])
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		Convey("Then published content can be read without authenticating", func() {
+			So(serve(nil, http.MethodGet, "/v1/content/economy"), ShouldEqual, http.StatusOK)
+			So(serve(nil, http.MethodGet, "/v1/content-actions/versions/economy"), ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Then unauthenticated requests cannot read collections or drafts", func() {
+			So(serve(nil, http.MethodGet, "/v1/collections"), ShouldEqual, http.StatusUnauthorized)
+			So(serve(nil, http.MethodGet, "/v1/collections/123"), ShouldEqual, http.StatusUnauthorized)
+			So(serve(nil, http.MethodGet, "/v1/content/economy", "Collection-Id", "123"), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("Then unauthenticated requests cannot edit content", func() {
+			So(serve(nil, http.MethodPut, "/v1/content/economy"), ShouldEqual, http.StatusUnauthorized)
+			So(serve(nil, http.MethodDelete, "/v1/content/economy"), ShouldEqual, http.StatusUnauthorized)
+			So(serve(nil, http.MethodPost, "/v1/collections"), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("Then viewers can read collections and drafts", func() {
+			So(serve(viewer, http.MethodGet, "/v1/collections"), ShouldEqual, http.StatusOK)
+			So(serve(viewer, http.MethodGet, "/v1/collections/123"), ShouldEqual, http.StatusOK)
+			So(serve(viewer, http.MethodGet, "/v1/content/economy", "Collection-Id", "123"), ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Then viewers cannot edit content", func() {
+			So(serve(viewer, http.MethodPut, "/v1/content/economy"), ShouldEqual, http.StatusForbidden)
+			So(serve(viewer, http.MethodPost, "/v1/content/economy"), ShouldEqual, http.StatusForbidden)
+			So(serve(viewer, http.MethodDelete, "/v1/content/economy"), ShouldEqual, http.StatusForbidden)
+			So(serve(viewer, http.MethodPost, "/v1/collections"), ShouldEqual, http.StatusForbidden)
+			So(serve(viewer, http.MethodDelete, "/v1/collections/123"), ShouldEqual, http.StatusForbidden)
+			So(serve(viewer, http.MethodPut, "/v1/collections/123/content/economy"), ShouldEqual, http.StatusForbidden)
+			So(serve(viewer, http.MethodPost, "/v1/collections/123/publish"), ShouldEqual, http.StatusForbidden)
+		})
+	})
+}
+
+func TestErrorResponses(t *testing.T) {
+	Convey("Given an API with no pages", t, func() {
+		ctx := context.Background()
+		store := memory.New()
+		api := Setup(ctx, &config.Config{}, mux.NewRouter(), store, store, store, store, nil, nil)
+
+		serve := func(method, target string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+			return w
+		}
+
+		Convey("When a page that does not exist is requested", func() {
+			w := serve(http.MethodGet, "/v1/content/economy")
+
+			Convey("Then a 404 is returned with the code of the error", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"PageNotFound","description":"page not found"}]}`)
+			})
+		})
+
+		Convey("When a request is not authenticated", func() {
+			w := serve(http.MethodGet, "/v1/collections")
+
+			Convey("Then a 401 is returned with the code of the error", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"Unauthorised","description":"request could not be authenticated"}]}`)
+			})
+		})
+
+		Convey("When a request matches no route", func() {
+			w := serve(http.MethodGet, "/v2/content/economy")
+
+			Convey("Then a 404 is returned in the same form", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"NotFound","description":"resource not found"}]}`)
+			})
+		})
+
+		Convey("When a route is requested with a method it does not support", func() {
+			w := serve(http.MethodDelete, "/v1/releases")
+
+			Convey("Then a 405 is returned in the same form", func() {
+				So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"MethodNotAllowed","description":"method not allowed"}]}`)
+			})
 		})
 	})
 }
@@ -26,5 +154,5 @@ func TestSetup(t *testing.T) {
 func hasRoute(r *mux.Router, path, method string) bool {
 	req := httptest.NewRequest(method, path, nil)
 	match := &mux.RouteMatch{}
-	return r.Match(req, match)
+	return r.Match(req, match) && match.MatchErr == nil
 }
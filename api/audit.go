@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/audit"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// auditResponse is the body returned when listing a page of audit records
+type auditResponse struct {
+	Count      int                   `json:"count"`
+	Offset     int                   `json:"offset"`
+	Limit      int                   `json:"limit"`
+	TotalCount int                   `json:"total_count"`
+	Items      []*models.AuditRecord `json:"items"`
+}
+
+// getAuditHandler returns a page of the audit records matching the uri, user, from and to query parameters, most
+// recent first
+func (api *API) getAuditHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	query := req.URL.Query()
+	logData := log.Data{"query": query}
+
+	filter, err := api.readAuditFilter(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	records, total, err := api.auditStore.GetAuditRecords(ctx, filter)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, auditResponse{
+		Count:      len(records),
+		Offset:     filter.Offset,
+		Limit:      filter.Limit,
+		TotalCount: total,
+		Items:      records,
+	}, logData)
+}
+
+// readAuditFilter reads the filter for audit records from the query parameters of the request
+func (api *API) readAuditFilter(req *http.Request) (models.AuditFilter, error) {
+	query := req.URL.Query()
+	filter := models.AuditFilter{User: query.Get("user")}
+	if uri := query.Get("uri"); uri != "" {
+		filter.URI = models.CleanURI(uri)
+	}
+
+	var err error
+	if filter.From, err = readTime(query.Get("from"), apierrors.ErrInvalidAuditTime); err != nil {
+		return filter, err
+	}
+	if filter.To, err = readTime(query.Get("to"), apierrors.ErrInvalidAuditTime); err != nil {
+		return filter, err
+	}
+	filter.Offset, filter.Limit, err = api.readPagination(req)
+	return filter, err
+}
+
+// readTime parses an RFC3339 timestamp, returning the zero time if the value is empty, or the invalid error if it
+// is not a timestamp
+func readTime(value string, invalid error) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, invalid
+	}
+	return t.UTC(), nil
+}
+
+// audit records a change to the page at the URI, made by the caller of the request. It is recorded before the
+// change is made, so that a change is never made without an audit record of it, and the record is returned so that
+// it can be marked as failed if the change is not made. Before and after are the page as it was and as it will be,
+// and are nil if there was no page before the change or will be none after it.
+func (api *API) audit(ctx context.Context, action models.AuditAction, uri, collectionID string, before, after *models.Page) (*models.AuditRecord, error) {
+	return api.addAuditRecord(ctx, &models.AuditRecord{Action: action, URI: uri, CollectionID: collectionID}, before, after)
+}
+
+// auditTranslation records a change to the translation of the page at the URI into the language, as audit does
+func (api *API) auditTranslation(ctx context.Context, action models.AuditAction, uri string, lang models.Language, before, after *models.Page) (*models.AuditRecord, error) {
+	return api.addAuditRecord(ctx, &models.AuditRecord{Action: action, URI: uri, Lang: lang}, before, after)
+}
+
+// addAuditRecord completes the record of a change with the caller, the request and the hashes of the page before
+// and after the change, and stores it
+func (api *API) addAuditRecord(ctx context.Context, record *models.AuditRecord, before, after *models.Page) (*models.AuditRecord, error) {
+	if err := audit.Add(ctx, api.auditStore, record, before, after); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// auditFailed records that the audited changes were not made, as the store failed to make them
+func (api *API) auditFailed(ctx context.Context, records ...*models.AuditRecord) {
+	audit.Failed(ctx, api.auditStore, records...)
+}
@@ -0,0 +1,367 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/api/mock"
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func readAuditRecords(store api.AuditStore) []*models.AuditRecord {
+	records, _, err := store.GetAuditRecords(ctx, models.AuditFilter{})
+	So(err, ShouldBeNil)
+	return records
+}
+
+func TestAuditContent(t *testing.T) {
+	Convey("Given an empty store", t, func() {
+		store := memory.New()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		Convey("When a publisher PUTs a page", func() {
+			req := newRequest(publisher, http.MethodPut, "/v1/content/economy", testPageBody)
+			req = req.WithContext(dprequest.WithRequestId(req.Context(), "abc123"))
+			w := serve(a, req)
+			So(w.Code, ShouldEqual, http.StatusCreated)
+
+			Convey("Then its creation is audited", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 1)
+				So(records[0].ID, ShouldNotBeEmpty)
+				So(records[0].User, ShouldEqual, publisher.ID)
+				So(records[0].Service, ShouldBeFalse)
+				So(records[0].Action, ShouldEqual, models.AuditActionCreate)
+				So(records[0].URI, ShouldEqual, "/economy")
+				So(records[0].BeforeHash, ShouldBeEmpty)
+				So(records[0].AfterHash, ShouldEqual, models.HashPage(&models.Page{Data: json.RawMessage(storedPage)}))
+				So(records[0].RequestID, ShouldEqual, "abc123")
+				So(records[0].Timestamp, ShouldHappenWithin, time.Minute, time.Now())
+			})
+
+			Convey("And it is PUT again with different content", func() {
+				w := doReplace(a, "/v1/content/economy", `{"type":"static_page","description":{"title":"Economy"}}`)
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				Convey("Then the update is audited with the hashes of the page before and after it", func() {
+					records := readAuditRecords(store)
+					So(records, ShouldHaveLength, 2)
+					So(records[0].Action, ShouldEqual, models.AuditActionUpdate)
+					So(records[0].BeforeHash, ShouldEqual, records[1].AfterHash)
+					So(records[0].AfterHash, ShouldEqual, models.HashPage(&models.Page{Data: w.Body.Bytes()}))
+				})
+			})
+
+			Convey("And it is PUT again with JSON that could be written in other ways", func() {
+				body := `{"type":"static_page","weight":1.50,"size":1e3,"description":{"title":"Caf\u00e9"}}`
+				So(doReplace(a, "/v1/content/economy", body).Code, ShouldEqual, http.StatusOK)
+
+				Convey("Then the update is audited with the hash of the page as it is read back", func() {
+					w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/economy", ""))
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(readAuditRecords(store)[0].AfterHash, ShouldEqual, models.HashPage(&models.Page{Data: w.Body.Bytes()}))
+				})
+			})
+
+			Convey("And it is deleted", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/content/economy", "")
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+
+				Convey("Then the deletion is audited", func() {
+					records := readAuditRecords(store)
+					So(records, ShouldHaveLength, 2)
+					So(records[0].Action, ShouldEqual, models.AuditActionDelete)
+					So(records[0].BeforeHash, ShouldEqual, records[1].AfterHash)
+					So(records[0].AfterHash, ShouldBeEmpty)
+				})
+			})
+
+			Convey("And it is POSTed again", func() {
+				w := doRequest(a, http.MethodPost, "/v1/content/economy", testPageBody)
+
+				Convey("Then the conflict is not audited", func() {
+					So(w.Code, ShouldEqual, http.StatusConflict)
+					So(readAuditRecords(store), ShouldHaveLength, 1)
+				})
+			})
+		})
+
+		Convey("When a page fails validation", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", `{"type":"static_page"}`)
+
+			Convey("Then nothing is audited", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(readAuditRecords(store), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given an audit store that returns an error", t, func() {
+		store := memory.New()
+		auditStore := &mock.AuditStoreMock{
+			AddAuditRecordFunc: func(ctx context.Context, record *models.AuditRecord) error { return errStore },
+		}
+		events := newEventProducerMock()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, auditStore, store, newSchedulerMock(), events)
+
+		Convey("When a page is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", testPageBody)
+
+			Convey("Then the request fails without storing the page", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(auditStore.AddAuditRecordCalls(), ShouldHaveLength, 1)
+				_, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldNotBeNil)
+				So(events.ContentPublishedCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestAuditFailedChanges(t *testing.T) {
+	Convey("Given a content store that fails to store pages", t, func() {
+		contentStore := &mock.ContentStoreMock{
+			GetPageFunc:    func(ctx context.Context, uri string) (*models.Page, error) { return nil, apierrors.ErrPageNotFound },
+			CreatePageFunc: func(ctx context.Context, page *models.Page) error { return errStore },
+		}
+		store := memory.New()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), contentStore, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		Convey("When a page is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", testPageBody)
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+
+			Convey("Then the change is audited, and then recorded as failed", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[1].Failed, ShouldBeFalse)
+				So(records[0].Failed, ShouldBeTrue)
+				So(records[0].ID, ShouldNotEqual, records[1].ID)
+				So(records[0].Action, ShouldEqual, models.AuditActionCreate)
+				So(records[0].URI, ShouldEqual, "/economy")
+				So(records[0].AfterHash, ShouldEqual, records[1].AfterHash)
+				So(records[0].User, ShouldEqual, publisher.ID)
+			})
+		})
+	})
+}
+
+func TestAuditCollectionChanges(t *testing.T) {
+	Convey("Given an empty store", t, func() {
+		store := memory.New()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		Convey("When a collection is created", func() {
+			w := doRequest(a, http.MethodPost, "/v1/collections", `{"name":"Economy"}`)
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			var collection models.Collection
+			So(json.Unmarshal(w.Body.Bytes(), &collection), ShouldBeNil)
+
+			Convey("Then its creation is audited", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 1)
+				So(records[0].Action, ShouldEqual, models.AuditActionCreateCollection)
+				So(records[0].CollectionID, ShouldEqual, collection.ID)
+				So(records[0].URI, ShouldBeEmpty)
+			})
+
+			Convey("And a draft in it is completed and reviewed", func() {
+				So(doRequest(a, http.MethodPut, "/v1/collections/"+collection.ID+"/content/economy", testPageBody).Code, ShouldEqual, http.StatusOK)
+				So(doChange(a, http.MethodPost, "/v1/collections/"+collection.ID+"/complete/economy", "", "/v1/collections/"+collection.ID).Code, ShouldEqual, http.StatusOK)
+				So(doChange(a, http.MethodPost, "/v1/collections/"+collection.ID+"/review/economy", "", "/v1/collections/"+collection.ID).Code, ShouldEqual, http.StatusOK)
+
+				Convey("Then each change of its state is audited", func() {
+					records := readAuditRecords(store)
+					So(records, ShouldHaveLength, 4)
+					So(records[1].Action, ShouldEqual, models.AuditActionCompleteItem)
+					So(records[0].Action, ShouldEqual, models.AuditActionReviewItem)
+					So(records[0].URI, ShouldEqual, "/economy")
+					So(records[0].CollectionID, ShouldEqual, collection.ID)
+				})
+			})
+
+			Convey("And it is deleted", func() {
+				So(doChange(a, http.MethodDelete, "/v1/collections/"+collection.ID, "", "/v1/collections/"+collection.ID).Code, ShouldEqual, http.StatusNoContent)
+
+				Convey("Then its deletion is audited", func() {
+					records := readAuditRecords(store)
+					So(records, ShouldHaveLength, 2)
+					So(records[0].Action, ShouldEqual, models.AuditActionDeleteCollection)
+					So(records[0].CollectionID, ShouldEqual, collection.ID)
+				})
+			})
+		})
+	})
+}
+
+func TestAuditRedirects(t *testing.T) {
+	Convey("Given an empty store", t, func() {
+		store := memory.New()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		Convey("When a redirect is PUT and then deleted", func() {
+			So(doRequest(a, http.MethodPut, "/v1/redirects/economy", `{"to":"/economics"}`).Code, ShouldEqual, http.StatusCreated)
+			So(doRequest(a, http.MethodDelete, "/v1/redirects/economy", "").Code, ShouldEqual, http.StatusNoContent)
+
+			Convey("Then both changes are audited with where the redirect leads", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[1].Action, ShouldEqual, models.AuditActionUpdateRedirect)
+				So(records[0].Action, ShouldEqual, models.AuditActionDeleteRedirect)
+				for _, record := range records {
+					So(record.URI, ShouldEqual, "/economy")
+					So(record.RedirectTo, ShouldEqual, "/economics")
+				}
+			})
+		})
+
+		Convey("When redirects are uploaded", func() {
+			So(doRequest(a, http.MethodPost, "/v1/redirects", "from,to\n/economy,/economics\n/business,/businessindustryandtrade\n").Code, ShouldEqual, http.StatusOK)
+
+			Convey("Then each redirect is audited", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[0].Action, ShouldEqual, models.AuditActionUpdateRedirect)
+				So(records[0].URI, ShouldEqual, "/business")
+				So(records[1].URI, ShouldEqual, "/economy")
+			})
+		})
+	})
+}
+
+func TestAuditCollections(t *testing.T) {
+	Convey("Given a collection containing a reviewed draft of a published page", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Data: json.RawMessage(testPageBody)}), ShouldBeNil)
+		So(store.CreateCollection(ctx, &models.Collection{ID: "123", Name: "Economy", State: models.CollectionStateInProgress}), ShouldBeNil)
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		w := doRequest(a, http.MethodPut, "/v1/collections/123/content/economy", testPageBody)
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(store.UpdateItemState(ctx, "123", "/economy", models.ItemStateReviewed), ShouldBeNil)
+
+		Convey("Then the draft is audited against its collection", func() {
+			records := readAuditRecords(store)
+			So(records, ShouldHaveLength, 1)
+			So(records[0].Action, ShouldEqual, models.AuditActionUpdateDraft)
+			So(records[0].URI, ShouldEqual, "/economy")
+			So(records[0].CollectionID, ShouldEqual, "123")
+			So(records[0].BeforeHash, ShouldBeEmpty)
+		})
+
+		Convey("When the collection is published", func() {
+			w := doChange(a, http.MethodPost, "/v1/collections/123/publish", "", "/v1/collections/123")
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			Convey("Then the publication of the page is audited", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[0].Action, ShouldEqual, models.AuditActionPublish)
+				So(records[0].CollectionID, ShouldEqual, "123")
+				So(records[0].BeforeHash, ShouldEqual, models.HashPage(&models.Page{Data: json.RawMessage(testPageBody)}))
+				So(records[0].AfterHash, ShouldEqual, records[1].AfterHash)
+			})
+
+			Convey("Then a draft cannot be added to it, and nothing more is audited", func() {
+				w := doRequest(a, http.MethodPut, "/v1/collections/123/content/economy", testPageBody)
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(readAuditRecords(store), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the draft is deleted", func() {
+			w := doRequest(a, http.MethodDelete, "/v1/collections/123/content/economy", "")
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+
+			Convey("Then its deletion is audited", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[0].Action, ShouldEqual, models.AuditActionDeleteDraft)
+				So(records[0].BeforeHash, ShouldEqual, records[1].AfterHash)
+				So(records[0].AfterHash, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetAudit(t *testing.T) {
+	Convey("Given a store containing audit records", t, func() {
+		store := memory.New()
+		records := []*models.AuditRecord{
+			{ID: "1", User: "publisher@ons.gov.uk", Action: models.AuditActionCreate, URI: "/economy", Timestamp: time.Date(2021, 3, 17, 9, 0, 0, 0, time.UTC)},
+			{ID: "2", User: "another@ons.gov.uk", Action: models.AuditActionUpdate, URI: "/economy", Timestamp: time.Date(2021, 3, 18, 9, 0, 0, 0, time.UTC)},
+			{ID: "3", User: "publisher@ons.gov.uk", Action: models.AuditActionCreate, URI: "/employmentandlabourmarket", Timestamp: time.Date(2021, 3, 19, 9, 0, 0, 0, time.UTC)},
+		}
+		for _, record := range records {
+			So(store.AddAuditRecord(ctx, record), ShouldBeNil)
+		}
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		Convey("When a viewer lists the audit records", func() {
+			w := serve(a, newRequest(viewer, http.MethodGet, "/v1/audit", ""))
+
+			Convey("Then every record is returned, most recent first", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var body struct {
+					Count int                   `json:"count"`
+					Items []*models.AuditRecord `json:"items"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &body), ShouldBeNil)
+				So(body.Count, ShouldEqual, 3)
+				So(body.Items[0].ID, ShouldEqual, "3")
+				So(body.Items[2].ID, ShouldEqual, "1")
+			})
+		})
+
+		Convey("When the audit records are filtered by page, user and time", func() {
+			w := doRequest(a, http.MethodGet, "/v1/audit?uri=economy/&user=publisher@ons.gov.uk&from=2021-03-17T00:00:00Z&to=2021-03-18T00:00:00Z", "")
+
+			Convey("Then only the matching records are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"count":1,"offset":0,"limit":20,"total_count":1,"items":[{"id":"1","user":"publisher@ons.gov.uk","service":false,"action":"create","uri":"/economy","timestamp":"2021-03-17T09:00:00Z"}]}`)
+			})
+		})
+
+		Convey("When a page of the audit records is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/audit?offset=1&limit=1", "")
+
+			Convey("Then only that page is returned, with the total number of records", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldStartWith, `{"count":1,"offset":1,"limit":1,"total_count":3,"items":[{"id":"2",`)
+			})
+		})
+
+		Convey("When the audit records are requested with a limit that is out of range", func() {
+			w := doRequest(a, http.MethodGet, "/v1/audit?limit=0", "")
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the audit records are filtered by an invalid time", func() {
+			w := doRequest(a, http.MethodGet, "/v1/audit?from=yesterday", "")
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the audit records are requested without authenticating", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/audit", ""))
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// cachePolicy describes the page that a response is made from, which decides how long the response can be cached
+// and whether the client already has it
+type cachePolicy struct {
+	pageType     models.PageType
+	lastModified time.Time
+	private      bool
+}
+
+// writeCacheable writes the body with its ETag and caching headers, or a 304 without it if the conditional headers
+// of the request show that the client already has the same body
+func (api *API) writeCacheable(w http.ResponseWriter, req *http.Request, contentType string, body []byte, policy cachePolicy, logData log.Data) {
+	ctx := req.Context()
+	etag := models.ETag(body)
+
+	w.Header().Set("ETag", etag)
+	if !policy.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", policy.lastModified.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", api.cacheControl(policy))
+
+	if notModified(req, etag, policy.lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeBody(ctx, w, http.StatusOK, contentType, body, logData)
+}
+
+// cacheControl returns the Cache-Control header for the response. Drafts must be revalidated on every request, and
+// are never stored by shared caches. Published pages can be reused for a time that depends on their type, but never
+// beyond the next scheduled publish, so that releases are seen as soon as they are published.
+func (api *API) cacheControl(policy cachePolicy) string {
+	if policy.private {
+		return "private, no-cache"
+	}
+
+	maxAge := api.cacheMaxAge
+	switch policy.pageType {
+	case models.PageTypeRelease, models.PageTypeTimeseries:
+		maxAge = api.cacheReleaseMaxAge
+	}
+	if api.scheduler != nil {
+		if next, ok := api.scheduler.NextPublish(); ok {
+			if untilPublish := time.Until(next); untilPublish < maxAge {
+				maxAge = untilPublish
+			}
+		}
+	}
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	return fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+}
+
+// notModified returns true if the request is conditional on the body having changed, and it has not. If-None-Match
+// takes precedence over If-Modified-Since, which can only be compared to the second.
+func notModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return matchesETag(ifNoneMatch, etag, false)
+	}
+
+	ifModifiedSince := req.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// matchesETag returns true if the list of entity tags from a conditional header includes the ETag, or is "*". Weak
+// tags only match if the comparison is weak, as it is for If-None-Match.
+func matchesETag(header, etag string, strong bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if strong {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
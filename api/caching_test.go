@@ -0,0 +1,145 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCaching(t *testing.T) {
+	lastUpdated := time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a store containing a published page and a release", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Type: models.PageTypeStaticPage, Data: json.RawMessage(testPageBody), LastUpdated: lastUpdated}), ShouldBeNil)
+		So(store.CreatePage(ctx, &models.Page{URI: "/releases/cpi", Type: models.PageTypeRelease, Data: json.RawMessage(`{"type":"release","description":{"title":"CPI"}}`), LastUpdated: lastUpdated}), ShouldBeNil)
+		a := newTestAPI(store, store)
+
+		Convey("When the page is requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/economy", ""))
+
+			Convey("Then it has the ETag of its data, the time it was updated and can be cached", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag([]byte(testPageBody)))
+				So(w.Header().Get("Last-Modified"), ShouldEqual, "Wed, 17 Mar 2021 09:30:00 GMT")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=600")
+			})
+		})
+
+		Convey("When the page is requested with its ETag", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("If-None-Match", `"other", `+models.ETag([]byte(testPageBody)))
+			w := serve(a, req)
+
+			Convey("Then it is not modified, and is not returned again", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag([]byte(testPageBody)))
+				So(w.Body.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the page is requested with its ETag as a weak tag", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("If-None-Match", "W/"+models.ETag([]byte(testPageBody)))
+			w := serve(a, req)
+
+			Convey("Then it is not modified", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+			})
+		})
+
+		Convey("When the page is requested with a different ETag", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("If-None-Match", `"other"`)
+			req.Header.Set("If-Modified-Since", "Wed, 17 Mar 2021 09:30:00 GMT")
+			w := serve(a, req)
+
+			Convey("Then it is returned, as the ETag takes precedence over the time it was updated", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, testPageBody)
+			})
+		})
+
+		Convey("When the page is requested if it has been modified since it was updated", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("If-Modified-Since", "Wed, 17 Mar 2021 09:30:00 GMT")
+			w := serve(a, req)
+
+			Convey("Then it is not modified", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+			})
+		})
+
+		Convey("When the page is requested if it has been modified since before it was updated", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("If-Modified-Since", "Wed, 17 Mar 2021 09:29:59 GMT")
+			w := serve(a, req)
+
+			Convey("Then it is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the release is requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/releases/cpi", ""))
+
+			Convey("Then it can only be cached for a short time, as it changes with the release", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+			})
+		})
+
+		Convey("When the page is requested from a collection", func() {
+			So(store.CreateCollection(ctx, &models.Collection{ID: "123", Name: "March 2021 inflation"}), ShouldBeNil)
+			req := newRequest(viewer, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("Collection-Id", "123")
+			w := serve(a, req)
+
+			Convey("Then it must be revalidated, and not stored by shared caches", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-cache")
+			})
+		})
+
+		Convey("When a collection is due to be published shortly", func() {
+			scheduler := newSchedulerMock()
+			scheduler.NextPublishFunc = func() (time.Time, bool) { return time.Now().Add(30 * time.Second), true }
+			a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, scheduler, newEventProducerMock())
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/economy", ""))
+
+			Convey("Then the page can only be cached until the collection is published", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Cache-Control"), ShouldBeIn, "public, max-age=30", "public, max-age=29")
+			})
+		})
+	})
+
+	Convey("Given a published timeseries", t, func() {
+		store := memory.New()
+		storeTimeseries(store, "/economy/inflation/timeseries/d7g7/mm23", `{"type":"timeseries","description":{"title":"CPI annual rate","cdid":"D7G7","datasetId":"MM23"},"months":[{"date":"2021 FEB","value":"0.4"}]}`)
+		a := newTestAPI(store, store)
+
+		Convey("When its data is downloaded again with the ETag of the previous download", func() {
+			first := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=csv", ""))
+			So(first.Code, ShouldEqual, http.StatusOK)
+			So(first.Header().Get("ETag"), ShouldEqual, models.ETag(first.Body.Bytes()))
+			So(first.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+
+			req := newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=csv", "")
+			req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+			w := serve(a, req)
+
+			Convey("Then it is not modified", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+				So(w.Body.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,382 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/audit"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+)
+
+// collectionsResponse is the body returned when listing collections
+type collectionsResponse struct {
+	Count int                  `json:"count"`
+	Items []*models.Collection `json:"items"`
+}
+
+// getCollectionsHandler returns every collection
+func (api *API) getCollectionsHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logData := log.Data{}
+
+	collections, err := api.collectionStore.GetCollections(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, collectionsResponse{Count: len(collections), Items: collections}, logData)
+}
+
+// postCollectionHandler creates a new, empty collection
+func (api *API) postCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logData := log.Data{}
+
+	var newCollection models.NewCollectionRequest
+	if err := json.NewDecoder(req.Body).Decode(&newCollection); err != nil {
+		handleError(ctx, w, apierrors.ErrInvalidBody, logData)
+		return
+	}
+	if strings.TrimSpace(newCollection.Name) == "" {
+		handleError(ctx, w, apierrors.ErrCollectionNameRequired, logData)
+		return
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	collection := &models.Collection{
+		ID:          id.String(),
+		Name:        strings.TrimSpace(newCollection.Name),
+		State:       models.CollectionStateInProgress,
+		Items:       []models.CollectionItem{},
+		LastUpdated: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	// times are kept to the millisecond, as they are stored, so that the collection has the same ETag when it is read
+	if newCollection.PublishDate != nil {
+		publishDate := newCollection.PublishDate.UTC().Truncate(time.Millisecond)
+		collection.PublishDate = &publishDate
+	}
+	logData["collection_id"] = collection.ID
+
+	record, err := api.addAuditRecord(ctx, &models.AuditRecord{Action: models.AuditActionCreateCollection, CollectionID: collection.ID}, nil, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.collectionStore.CreateCollection(ctx, collection); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if collection.PublishDate != nil {
+		api.scheduler.Schedule(ctx, collection)
+	}
+
+	log.Event(ctx, "collection created", log.INFO, logData)
+	writeCollection(ctx, w, http.StatusCreated, collection, logData)
+}
+
+// getCollectionHandler returns the requested collection
+func (api *API) getCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logData := log.Data{"collection_id": id}
+
+	collection, err := api.collectionStore.GetCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeCollection(ctx, w, http.StatusOK, collection, logData)
+}
+
+// deleteCollectionHandler removes a collection and its draft pages. Published collections are kept as a record.
+func (api *API) deleteCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logData := log.Data{"collection_id": id}
+
+	collection, err := api.collectionStore.GetCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	if collection.State == models.CollectionStatePublished {
+		handleError(ctx, w, apierrors.ErrCollectionPublished, logData)
+		return
+	}
+	if err := checkCollectionIfMatch(req, collection); err != nil {
+		handleCollectionEditError(ctx, w, err, collection, logData)
+		return
+	}
+
+	// the drafts in the collection are deleted with it, and are covered by the audit record of its deletion
+	record, err := api.addAuditRecord(ctx, &models.AuditRecord{Action: models.AuditActionDeleteCollection, CollectionID: id}, nil, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.collectionStore.DeleteCollection(ctx, id); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+	api.scheduler.Cancel(ctx, id)
+
+	log.Event(ctx, "collection deleted", log.INFO, logData)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getDraftHandler returns the draft of a page from a collection
+func (api *API) getDraftHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	uri := pageURI(req)
+	logData := log.Data{"collection_id": id, "uri": uri}
+
+	page, err := api.collectionStore.GetDraftPage(ctx, id, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// the ETag of the draft is given so that it can be sent back as If-Match when the draft is edited
+	policy := cachePolicy{pageType: page.Type, lastModified: page.LastUpdated, private: true}
+	api.writeCacheable(w, req, contentTypeJSON, page.Data, policy, logData)
+}
+
+// putDraftHandler adds or replaces the draft of a page in a collection
+func (api *API) putDraftHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	uri := pageURI(req)
+	logData := log.Data{"collection_id": id, "uri": uri}
+
+	page, err := readPage(req, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if _, err := api.editableCollection(ctx, id); err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	previous, err := api.collectionStore.GetDraftPage(ctx, id, uri)
+	if err != nil && err != apierrors.ErrCollectionItemNotFound {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	getCurrent := func() (*models.Page, error) { return api.collectionStore.GetDraftPage(ctx, id, uri) }
+	if err := checkIfMatch(req, previous, true); err != nil {
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	record, err := api.audit(ctx, models.AuditActionUpdateDraft, uri, id, previous, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// a draft being edited is only replaced if it is still the version that was checked, so that two editors
+	// saving the same draft cannot overwrite each other's changes
+	if previous == nil {
+		err = api.collectionStore.UpsertDraftPage(ctx, id, page)
+	} else {
+		err = api.collectionStore.ReplaceDraftPage(ctx, id, page, previous.ETag())
+	}
+	if err != nil {
+		api.auditFailed(ctx, record)
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	log.Event(ctx, "draft page stored", log.INFO, logData)
+	writePage(ctx, w, http.StatusOK, page, logData)
+}
+
+// deleteDraftHandler removes the draft of a page from a collection
+func (api *API) deleteDraftHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	uri := pageURI(req)
+	logData := log.Data{"collection_id": id, "uri": uri}
+
+	if _, err := api.editableCollection(ctx, id); err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	previous, err := api.collectionStore.GetDraftPage(ctx, id, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := checkIfMatch(req, previous, false); err != nil {
+		handleEditError(ctx, w, err, func() (*models.Page, error) { return previous, nil }, logData)
+		return
+	}
+
+	record, err := api.audit(ctx, models.AuditActionDeleteDraft, uri, id, previous, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.collectionStore.DeleteDraftPage(ctx, id, uri); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "draft page deleted", log.INFO, logData)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// completeItemHandler marks the draft of a page as complete and ready for review
+func (api *API) completeItemHandler(w http.ResponseWriter, req *http.Request) {
+	api.updateItemState(w, req, models.ItemStateComplete)
+}
+
+// reviewItemHandler marks the draft of a page as reviewed. Only complete pages can be reviewed.
+func (api *API) reviewItemHandler(w http.ResponseWriter, req *http.Request) {
+	api.updateItemState(w, req, models.ItemStateReviewed)
+}
+
+// updateItemState moves the draft of a page in a collection to the provided state and returns the updated collection
+func (api *API) updateItemState(w http.ResponseWriter, req *http.Request, state models.ItemState) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	uri := pageURI(req)
+	logData := log.Data{"collection_id": id, "uri": uri, "state": state}
+
+	collection, err := api.collectionStore.GetCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	item := collection.Item(uri)
+	if item == nil {
+		handleError(ctx, w, apierrors.ErrCollectionItemNotFound, logData)
+		return
+	}
+	if err := checkCollectionIfMatch(req, collection); err != nil {
+		handleCollectionEditError(ctx, w, err, collection, logData)
+		return
+	}
+	if state == models.ItemStateReviewed && item.State != models.ItemStateComplete {
+		handleError(ctx, w, apierrors.ErrInvalidItemState, logData)
+		return
+	}
+
+	action := models.AuditActionCompleteItem
+	if state == models.ItemStateReviewed {
+		action = models.AuditActionReviewItem
+	}
+	record, err := api.addAuditRecord(ctx, &models.AuditRecord{Action: action, URI: uri, CollectionID: id}, nil, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.collectionStore.UpdateItemState(ctx, id, uri, state); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	collection, err = api.collectionStore.GetCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "collection item state updated", log.INFO, logData)
+	writeCollection(ctx, w, http.StatusOK, collection, logData)
+}
+
+// publishCollectionHandler makes every page in a collection live at once, ahead of any publish date it is scheduled for
+func (api *API) publishCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logData := log.Data{"collection_id": id}
+
+	collection, err := api.editableCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	// the collection is only published as the caller last saw it, so that no change made since is published unseen
+	if err := checkCollectionIfMatch(req, collection); err != nil {
+		handleCollectionEditError(ctx, w, err, collection, logData)
+		return
+	}
+
+	records, err := api.auditPublish(ctx, collection)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// times are kept to the millisecond, as they are stored, so that the collection returned has the ETag it is read with
+	started := time.Now()
+	publishedAt := started.UTC().Truncate(time.Millisecond)
+	err = api.collectionStore.PublishCollection(ctx, id, publishedAt)
+	metrics.ObservePublish(metrics.TriggerManual, started, err)
+	if err != nil {
+		api.auditFailed(ctx, records...)
+		handleError(ctx, w, err, logData)
+		return
+	}
+	api.scheduler.Cancel(ctx, id)
+
+	// the collection is not read again once published, so that nothing can fail before its pages are announced
+	collection = collection.Published(publishedAt)
+	log.Event(ctx, "collection published", log.INFO, logData)
+	for _, e := range event.CollectionPublished(collection) {
+		api.sendContentPublished(ctx, e, logData)
+	}
+	writeCollection(ctx, w, http.StatusOK, collection, logData)
+}
+
+// auditPublish records the publication of every page in a collection, failing if the collection cannot be published
+func (api *API) auditPublish(ctx context.Context, collection *models.Collection) ([]*models.AuditRecord, error) {
+	if !collection.IsPublishable() {
+		return nil, apierrors.ErrCollectionNotPublishable
+	}
+	return audit.Publish(ctx, api.auditStore, api.collectionStore, api.contentStore, collection)
+}
+
+// editableCollection returns the collection, failing if it does not exist or has already been published
+func (api *API) editableCollection(ctx context.Context, id string) (*models.Collection, error) {
+	collection, err := api.collectionStore.GetCollection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.State == models.CollectionStatePublished {
+		return nil, apierrors.ErrCollectionPublished
+	}
+	return collection, nil
+}
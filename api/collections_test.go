@@ -0,0 +1,356 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/api/mock"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func createCollection(store *memory.Store, id string) {
+	So(store.CreateCollection(ctx, &models.Collection{
+		ID:    id,
+		Name:  "March 2021 inflation",
+		State: models.CollectionStateInProgress,
+		Items: []models.CollectionItem{},
+	}), ShouldBeNil)
+}
+
+func decodeCollection(w *httptest.ResponseRecorder) *models.Collection {
+	var collection models.Collection
+	So(json.Unmarshal(w.Body.Bytes(), &collection), ShouldBeNil)
+	return &collection
+}
+
+// unreadableOncePublished is a collection store whose collections cannot be read once one has been published
+type unreadableOncePublished struct {
+	*memory.Store
+	published bool
+}
+
+func (s *unreadableOncePublished) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	if s.published {
+		return nil, errStore
+	}
+	return s.Store.GetCollection(ctx, id)
+}
+
+func (s *unreadableOncePublished) PublishCollection(ctx context.Context, id string, publishedAt time.Time) error {
+	s.published = true
+	return s.Store.PublishCollection(ctx, id, publishedAt)
+}
+
+func TestPostCollection(t *testing.T) {
+	Convey("Given an empty store", t, func() {
+		store := memory.New()
+		a := newTestAPI(store, store)
+
+		Convey("When a collection is POSTed", func() {
+			w := doRequest(a, http.MethodPost, "/v1/collections", `{"name":"March 2021 inflation"}`)
+
+			Convey("Then an empty in progress collection is created", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				collection := decodeCollection(w)
+				So(collection.ID, ShouldNotBeEmpty)
+				So(collection.Name, ShouldEqual, "March 2021 inflation")
+				So(collection.State, ShouldEqual, models.CollectionStateInProgress)
+				So(collection.Items, ShouldBeEmpty)
+
+				stored, err := store.GetCollection(ctx, collection.ID)
+				So(err, ShouldBeNil)
+				So(stored.Name, ShouldEqual, collection.Name)
+			})
+		})
+
+		Convey("When a collection with a publish date is POSTed", func() {
+			scheduler := newSchedulerMock()
+			a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, scheduler, newEventProducerMock())
+			w := doRequest(a, http.MethodPost, "/v1/collections", `{"name":"March 2021 inflation","publish_date":"2021-03-24T07:00:00Z"}`)
+
+			Convey("Then the collection is scheduled for publishing at that date", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				collection := decodeCollection(w)
+				So(collection.PublishDate.Equal(time.Date(2021, 3, 24, 7, 0, 0, 0, time.UTC)), ShouldBeTrue)
+				So(scheduler.ScheduleCalls(), ShouldHaveLength, 1)
+				So(scheduler.ScheduleCalls()[0].Collection.ID, ShouldEqual, collection.ID)
+			})
+		})
+
+		Convey("When a collection without a name is POSTed", func() {
+			w := doRequest(a, http.MethodPost, "/v1/collections", `{"name":" "}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func TestGetCollections(t *testing.T) {
+	Convey("Given a store containing two collections", t, func() {
+		store := memory.New()
+		createCollection(store, "123")
+		createCollection(store, "456")
+		a := newTestAPI(store, store)
+
+		Convey("When the collections are requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/collections", "")
+
+			Convey("Then both are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var body struct {
+					Count int                  `json:"count"`
+					Items []*models.Collection `json:"items"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &body), ShouldBeNil)
+				So(body.Count, ShouldEqual, 2)
+				So(body.Items[0].ID, ShouldEqual, "123")
+				So(body.Items[1].ID, ShouldEqual, "456")
+			})
+		})
+
+		Convey("When a collection that does not exist is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/collections/789", "")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestCollectionWorkflow(t *testing.T) {
+	Convey("Given a store containing a published page and a collection", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Data: json.RawMessage(testPageBody)}), ShouldBeNil)
+		createCollection(store, "123")
+		events := newEventProducerMock()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), events)
+
+		draftBody := `{"type":"static_page","description":{"title":"Economy"}}`
+		storedDraft := `{"type":"static_page","uri":"/economy","description":{"title":"Economy"}}`
+
+		Convey("When a draft of the page is PUT to the collection", func() {
+			w := doRequest(a, http.MethodPut, "/v1/collections/123/content/economy", draftBody)
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			Convey("Then the draft is returned from the collection", func() {
+				w := doRequest(a, http.MethodGet, "/v1/collections/123/content/economy", "")
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, storedDraft)
+			})
+
+			Convey("Then the draft is returned with its ETag, to be revalidated on every request", func() {
+				w := doRequest(a, http.MethodGet, "/v1/collections/123/content/economy", "")
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag([]byte(storedDraft)))
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-cache")
+			})
+
+			Convey("When two editors save the draft they both read", func() {
+				etag := doRequest(a, http.MethodGet, "/v1/collections/123/content/economy", "").Header().Get("ETag")
+				first := doRequestIfMatch(a, http.MethodPut, "/v1/collections/123/content/economy", `{"type":"static_page","description":{"title":"The economy"}}`, etag)
+				second := doRequestIfMatch(a, http.MethodPut, "/v1/collections/123/content/economy", `{"type":"static_page","description":{"title":"Economics"}}`, etag)
+
+				Convey("Then the first change is saved", func() {
+					So(first.Code, ShouldEqual, http.StatusOK)
+					draft, err := store.GetDraftPage(ctx, "123", "/economy")
+					So(err, ShouldBeNil)
+					So(string(draft.Data), ShouldContainSubstring, "The economy")
+				})
+
+				Convey("Then the second is refused with the ETag of the first, rather than overwriting it", func() {
+					So(second.Code, ShouldEqual, http.StatusPreconditionFailed)
+					So(second.Header().Get("ETag"), ShouldEqual, models.ETag(first.Body.Bytes()))
+				})
+			})
+
+			Convey("Then the draft cannot be saved again without If-Match", func() {
+				w := doRequest(a, http.MethodPut, "/v1/collections/123/content/economy", draftBody)
+				So(w.Code, ShouldEqual, http.StatusPreconditionRequired)
+			})
+
+			Convey("Then the draft cannot be removed with an out of date ETag", func() {
+				w := doRequestIfMatch(a, http.MethodDelete, "/v1/collections/123/content/economy", "", models.ETag([]byte(testPageBody)))
+				So(w.Code, ShouldEqual, http.StatusPreconditionFailed)
+			})
+
+			Convey("Then the published page is returned without a collection ID", func() {
+				w := doRequest(a, http.MethodGet, "/v1/content/economy", "")
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, testPageBody)
+			})
+
+			Convey("Then the draft is returned when the collection ID header is provided", func() {
+				req := newRequest(publisher, http.MethodGet, "/v1/content/economy", "")
+				req.Header.Set("Collection-Id", "123")
+				w := serve(a, req)
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, storedDraft)
+			})
+
+			Convey("Then the collection is returned with its ETag, which changes with the state of its items", func() {
+				w := doRequest(a, http.MethodGet, "/v1/collections/123", "")
+				etag := w.Header().Get("ETag")
+				So(etag, ShouldEqual, models.ETag(w.Body.Bytes()))
+				w = doRequestIfMatch(a, http.MethodPost, "/v1/collections/123/complete/economy", "", etag)
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldNotEqual, etag)
+				So(w.Header().Get("ETag"), ShouldEqual, doRequest(a, http.MethodGet, "/v1/collections/123", "").Header().Get("ETag"))
+			})
+
+			Convey("Then the page cannot be completed, nor the collection published or deleted, without If-Match", func() {
+				etag := doRequest(a, http.MethodGet, "/v1/collections/123", "").Header().Get("ETag")
+				for _, w := range []*httptest.ResponseRecorder{
+					doRequest(a, http.MethodPost, "/v1/collections/123/complete/economy", ""),
+					doRequest(a, http.MethodPost, "/v1/collections/123/publish", ""),
+					doRequest(a, http.MethodDelete, "/v1/collections/123", ""),
+				} {
+					So(w.Code, ShouldEqual, http.StatusPreconditionRequired)
+					So(w.Header().Get("ETag"), ShouldEqual, etag)
+				}
+			})
+
+			Convey("Then the collection cannot be deleted with the ETag it had before the draft was added", func() {
+				w := doRequestIfMatch(a, http.MethodDelete, "/v1/collections/123", "", models.ETag([]byte(`{}`)))
+				So(w.Code, ShouldEqual, http.StatusPreconditionFailed)
+				_, err := store.GetCollection(ctx, "123")
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then the page cannot be reviewed before it is complete", func() {
+				w := doChange(a, http.MethodPost, "/v1/collections/123/review/economy", "", "/v1/collections/123")
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+
+			Convey("Then the collection cannot be published before the page is reviewed", func() {
+				w := doChange(a, http.MethodPost, "/v1/collections/123/publish", "", "/v1/collections/123")
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+
+			Convey("When the page is completed, reviewed and the collection published", func() {
+				w := doChange(a, http.MethodPost, "/v1/collections/123/complete/economy", "", "/v1/collections/123")
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(decodeCollection(w).Items[0].State, ShouldEqual, models.ItemStateComplete)
+				w = doChange(a, http.MethodPost, "/v1/collections/123/review/economy", "", "/v1/collections/123")
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(decodeCollection(w).Items[0].State, ShouldEqual, models.ItemStateReviewed)
+				w = doChange(a, http.MethodPost, "/v1/collections/123/publish", "", "/v1/collections/123")
+
+				Convey("Then the collection is published", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(decodeCollection(w).State, ShouldEqual, models.CollectionStatePublished)
+				})
+
+				Convey("Then the collection is returned as it is stored, with the ETag it is read with", func() {
+					read := doRequest(a, http.MethodGet, "/v1/collections/123", "")
+					So(w.Body.String(), ShouldEqual, read.Body.String())
+					So(w.Header().Get("ETag"), ShouldEqual, read.Header().Get("ETag"))
+				})
+
+				Convey("Then a content published event is sent for the page", func() {
+					So(events.ContentPublishedCalls(), ShouldHaveLength, 1)
+					e := events.ContentPublishedCalls()[0].E
+					So(e.URI, ShouldEqual, "/economy")
+					So(e.CollectionID, ShouldEqual, "123")
+				})
+
+				Convey("Then the draft is served as the published page", func() {
+					w := doRequest(a, http.MethodGet, "/v1/content/economy", "")
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Body.String(), ShouldEqual, storedDraft)
+				})
+
+				Convey("Then the collection cannot be deleted", func() {
+					w := doChange(a, http.MethodDelete, "/v1/collections/123", "", "/v1/collections/123")
+					So(w.Code, ShouldEqual, http.StatusConflict)
+				})
+			})
+
+			Convey("When the draft is removed from the collection", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/collections/123/content/economy", "")
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+
+				Convey("Then the published page is returned for the collection", func() {
+					req := newRequest(publisher, http.MethodGet, "/v1/content/economy", "")
+					req.Header.Set("Collection-Id", "123")
+					w := serve(a, req)
+					So(w.Body.String(), ShouldEqual, testPageBody)
+				})
+			})
+		})
+
+		Convey("When an invalid draft is PUT to the collection", func() {
+			w := doRequest(a, http.MethodPut, "/v1/collections/123/content/economy", `{"type":"static_page"}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page is requested with a collection ID that does not exist", func() {
+			req := newRequest(publisher, http.MethodGet, "/v1/content/economy", "")
+			req.Header.Set("Collection-Id", "456")
+			w := serve(a, req)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When the collection is deleted", func() {
+			w := doChange(a, http.MethodDelete, "/v1/collections/123", "", "/v1/collections/123")
+
+			Convey("Then it is removed", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				_, err := store.GetCollection(ctx, "123")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a collection ready to publish that cannot be read once published", t, func() {
+		store := memory.New()
+		createCollection(store, "123")
+		collections := &unreadableOncePublished{Store: store}
+		events := newEventProducerMock()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, collections, store, store, newSchedulerMock(), events)
+		So(doRequest(a, http.MethodPut, "/v1/collections/123/content/economy", `{"type":"static_page","description":{"title":"Economy"}}`).Code, ShouldEqual, http.StatusOK)
+		So(doChange(a, http.MethodPost, "/v1/collections/123/complete/economy", "", "/v1/collections/123").Code, ShouldEqual, http.StatusOK)
+		So(doChange(a, http.MethodPost, "/v1/collections/123/review/economy", "", "/v1/collections/123").Code, ShouldEqual, http.StatusOK)
+
+		Convey("When the collection is published", func() {
+			w := doChange(a, http.MethodPost, "/v1/collections/123/publish", "", "/v1/collections/123")
+
+			Convey("Then the published collection is returned and its pages are announced", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(decodeCollection(w).State, ShouldEqual, models.CollectionStatePublished)
+				So(events.ContentPublishedCalls(), ShouldHaveLength, 1)
+				So(events.ContentPublishedCalls()[0].E.URI, ShouldEqual, "/economy")
+			})
+		})
+	})
+
+	Convey("Given a collection store that returns an error", t, func() {
+		a := newTestAPI(memory.New(), &mock.CollectionStoreMock{
+			GetCollectionFunc: func(ctx context.Context, id string) (*models.Collection, error) { return nil, errStore },
+		})
+
+		Convey("When the collection is published", func() {
+			w := doChange(a, http.MethodPost, "/v1/collections/123/publish", "", "/v1/collections/123")
+
+			Convey("Then a 500 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"InternalError","description":"internal error"}]}`)
+			})
+		})
+	})
+}
@@ -0,0 +1,322 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// getContentHandler returns the page stored at the requested URI, in the language requested
+func (api *API) getContentHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	if v := req.URL.Query().Get("version"); v != "" {
+		logData["version"] = v
+		api.writeVersion(w, req, uri, v, logData)
+		return
+	}
+	// previous versions are also read from the URIs the ONS website gives them, which pages cannot be stored at
+	if pageURI, version, ok := models.ParseVersionURI(uri); ok {
+		logData["version"] = version
+		api.writeVersion(w, req, pageURI, strconv.Itoa(version), logData)
+		return
+	}
+
+	// the page returned depends on the Accept-Language header when the lang query parameter is not given
+	w.Header().Set("Vary", "Accept-Language")
+	lang, err := readLanguage(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	depth, err := api.readResolveDepth(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	collectionID := readCollectionID(ctx, req, logData)
+	page, returned, err := api.getPageInLanguage(ctx, collectionID, uri, lang, logData)
+	if err == apierrors.ErrPageNotFound {
+		api.handlePageNotFound(w, req, uri, logData)
+		return
+	}
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	data := page.Data
+	policy := cachePolicy{pageType: page.Type, lastModified: page.LastUpdated, private: collectionID != ""}
+	if depth > 0 {
+		logData["depth"] = depth
+		r := api.newResolver(collectionID, lang, logData)
+		if data, err = r.resolvePage(ctx, page, depth); err != nil {
+			handleError(ctx, w, err, logData)
+			return
+		}
+		policy.lastModified = r.lastModified(page.LastUpdated)
+	}
+
+	setLanguageHeaders(w, lang, returned)
+	api.writeCacheable(w, req, contentTypeJSON, data, policy, logData)
+}
+
+// readCollectionID returns the ID of the collection to read drafts from, given in the Collection-Id header. Drafts
+// are only ever visible to authorised requests that provide the ID of their collection.
+func readCollectionID(ctx context.Context, req *http.Request, logData log.Data) string {
+	collectionID, err := dprequest.GetCollectionID(req)
+	if err != nil {
+		log.Event(ctx, "reading collection id failed", log.WARN, log.Error(err), logData)
+	}
+	return collectionID
+}
+
+// getPageInCollection returns the draft of the page from the collection if it has one, or otherwise the published
+// page. The published page is returned if no collection ID is provided.
+func (api *API) getPageInCollection(ctx context.Context, collectionID, uri string, logData log.Data) (*models.Page, error) {
+	if collectionID == "" {
+		return api.contentStore.GetPage(ctx, uri)
+	}
+
+	logData["collection_id"] = collectionID
+	if err := auth.Check(ctx, auth.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	page, err := api.collectionStore.GetDraftPage(ctx, collectionID, uri)
+	if err == apierrors.ErrCollectionItemNotFound {
+		return api.contentStore.GetPage(ctx, uri)
+	}
+	return page, err
+}
+
+// putContentHandler creates or replaces the page at the requested URI, or its translation into the language
+// given by the lang query parameter
+func (api *API) putContentHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	lang, err := readLanguageParam(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	if lang.IsTranslation() {
+		api.putTranslation(w, req, uri, lang)
+		return
+	}
+
+	page, err := readPage(req, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	previous, err := api.contentStore.GetPage(ctx, uri)
+	if err != nil && err != apierrors.ErrPageNotFound {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	getCurrent := func() (*models.Page, error) { return api.contentStore.GetPage(ctx, uri) }
+	if err := checkIfMatch(req, previous, true); err != nil {
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	action := models.AuditActionUpdate
+	if previous == nil {
+		action = models.AuditActionCreate
+	}
+	record, err := api.audit(ctx, action, uri, "", previous, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// the page is only replaced if it is still the version that was checked, in case it has changed since
+	created := previous == nil
+	if created {
+		err = api.contentStore.CreatePage(ctx, page)
+	} else {
+		err = api.contentStore.ReplacePage(ctx, page, previous.ETag())
+	}
+	if err != nil {
+		api.auditFailed(ctx, record)
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	log.Event(ctx, "page stored", log.INFO, log.Data{"uri": uri, "created": created})
+	api.sendContentPublished(ctx, &event.ContentPublished{URI: uri, Type: page.Type, Lang: lang, Timestamp: page.LastUpdated}, logData)
+	setLanguageHeaders(w, lang, lang)
+	writePage(ctx, w, status, page, logData)
+}
+
+// postContentHandler creates a new page at the requested URI, or its translation into the language given by the
+// lang query parameter, failing if one already exists
+func (api *API) postContentHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	lang, err := readLanguageParam(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	if lang.IsTranslation() {
+		api.postTranslation(w, req, uri, lang)
+		return
+	}
+
+	page, err := readPage(req, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// check the page does not exist before auditing its creation, as the audit record must be made first
+	if _, err := api.contentStore.GetPage(ctx, uri); err != apierrors.ErrPageNotFound {
+		if err == nil {
+			err = apierrors.ErrPageAlreadyExists
+		}
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	record, err := api.audit(ctx, models.AuditActionCreate, uri, "", nil, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.contentStore.CreatePage(ctx, page); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "page created", log.INFO, logData)
+	api.sendContentPublished(ctx, &event.ContentPublished{URI: uri, Type: page.Type, Lang: lang, Timestamp: page.LastUpdated}, logData)
+	setLanguageHeaders(w, lang, lang)
+	writePage(ctx, w, http.StatusCreated, page, logData)
+}
+
+// deleteContentHandler removes the page at the requested URI along with its translations, or only its translation
+// into the language given by the lang query parameter
+func (api *API) deleteContentHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	lang, err := readLanguageParam(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	if lang.IsTranslation() {
+		api.deleteTranslation(w, req, uri, lang)
+		return
+	}
+
+	if models.IsVersionURI(uri) {
+		handleError(ctx, w, apierrors.ErrVersionReadOnly, logData)
+		return
+	}
+
+	page, err := api.contentStore.GetPage(ctx, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := checkIfMatch(req, page, false); err != nil {
+		handleEditError(ctx, w, err, func() (*models.Page, error) { return page, nil }, logData)
+		return
+	}
+
+	// the translations of the page are deleted with it, and are covered by the audit record of its deletion
+	record, err := api.audit(ctx, models.AuditActionDelete, uri, "", page, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.contentStore.DeletePage(ctx, uri); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "page deleted", log.INFO, logData)
+	api.sendContentDeleted(ctx, &event.ContentDeleted{URI: uri, Type: page.Type, Lang: lang, Timestamp: time.Now().UTC()}, logData)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sendContentPublished notifies other services that a page has been published. The page has already been
+// stored, so a failure to send the event is logged rather than failing the request.
+func (api *API) sendContentPublished(ctx context.Context, e *event.ContentPublished, logData log.Data) {
+	if err := api.eventProducer.ContentPublished(ctx, e); err != nil {
+		log.Event(ctx, "sending content published event failed", log.ERROR, log.Error(err), logData)
+	}
+}
+
+// sendContentDeleted notifies other services that a page has been deleted, logging any failure to do so
+func (api *API) sendContentDeleted(ctx context.Context, e *event.ContentDeleted, logData log.Data) {
+	if err := api.eventProducer.ContentDeleted(ctx, e); err != nil {
+		log.Event(ctx, "sending content deleted event failed", log.ERROR, log.Error(err), logData)
+	}
+}
+
+// pageURI returns the normalised page URI from the request path
+func pageURI(req *http.Request) string {
+	return models.CleanURI(mux.Vars(req)["uri"])
+}
+
+// readPage reads the request body into a page, validating it against the model for its declared type
+func readPage(req *http.Request, uri string) (*models.Page, error) {
+	body, err := readPageBody(req, uri)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPage(uri, body, time.Now().UTC())
+}
+
+// readPageBody reads the JSON object in the request body, to be stored as the page at the URI
+func readPageBody(req *http.Request, uri string) ([]byte, error) {
+	if models.IsVersionURI(uri) {
+		return nil, apierrors.ErrVersionReadOnly
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, apierrors.ErrInvalidBody
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return nil, apierrors.ErrInvalidBody
+	}
+	return body, nil
+}
@@ -0,0 +1,418 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/api/mock"
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ctx          = context.Background()
+	errStore     = errors.New("store is unavailable")
+	testPageBody = `{"type":"static_page","description":{"title":"About us"},"markdown":["Official statistics"]}`
+	publisher    = &auth.Identity{ID: "publisher@ons.gov.uk", Role: auth.RolePublisher}
+	viewer       = &auth.Identity{ID: "viewer@ons.gov.uk", Role: auth.RoleViewer}
+	storedPage   = `{"type":"static_page","uri":"/economy","description":{"title":"About us"},"markdown":["Official statistics"]}`
+)
+
+func newTestAPI(contentStore api.ContentStore, collectionStore api.CollectionStore) *api.API {
+	return api.Setup(ctx, newTestConfig(), mux.NewRouter(), contentStore, collectionStore, memory.New(), memory.New(), newSchedulerMock(), newEventProducerMock())
+}
+
+func newTestConfig() *config.Config {
+	cfg, err := config.Get()
+	So(err, ShouldBeNil)
+	return cfg
+}
+
+func newSchedulerMock() *mock.SchedulerMock {
+	return &mock.SchedulerMock{
+		ScheduleFunc:    func(ctx context.Context, collection *models.Collection) {},
+		CancelFunc:      func(ctx context.Context, collectionID string) {},
+		NextPublishFunc: func() (time.Time, bool) { return time.Time{}, false },
+	}
+}
+
+func newEventProducerMock() *mock.EventProducerMock {
+	return &mock.EventProducerMock{
+		ContentPublishedFunc: func(ctx context.Context, e *event.ContentPublished) error { return nil },
+		ContentDeletedFunc:   func(ctx context.Context, e *event.ContentDeleted) error { return nil },
+	}
+}
+
+// doRequest makes a request to the API as a publisher
+func doRequest(a *api.API, method, target, body string) *httptest.ResponseRecorder {
+	return serve(a, newRequest(publisher, method, target, body))
+}
+
+// doRequestIfMatch makes a request to the API as a publisher, giving the ETag of the version it replaces
+func doRequestIfMatch(a *api.API, method, target, body, etag string) *httptest.ResponseRecorder {
+	req := newRequest(publisher, method, target, body)
+	req.Header.Set("If-Match", etag)
+	return serve(a, req)
+}
+
+// doReplace replaces the resource at the target as a publisher, giving the ETag that it is returned with
+func doReplace(a *api.API, target, body string) *httptest.ResponseRecorder {
+	etag := doRequest(a, http.MethodGet, target, "").Header().Get("ETag")
+	return doRequestIfMatch(a, http.MethodPut, target, body, etag)
+}
+
+// doChange makes a request to the API as a publisher, giving the ETag that the resource it changes is read with from
+// the read target
+func doChange(a *api.API, method, target, body, read string) *httptest.ResponseRecorder {
+	etag := doRequest(a, http.MethodGet, read, "").Header().Get("ETag")
+	return doRequestIfMatch(a, method, target, body, etag)
+}
+
+// newRequest returns a request made by the identity, or an unauthenticated request if the identity is nil
+func newRequest(identity *auth.Identity, method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if identity != nil {
+		req = req.WithContext(auth.WithIdentity(req.Context(), identity))
+	}
+	return req
+}
+
+func serve(a *api.API, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	a.Router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetContent(t *testing.T) {
+	Convey("Given a store containing a page", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{
+			URI:         "/economy/inflationandpriceindices",
+			Data:        json.RawMessage(testPageBody),
+			LastUpdated: time.Now(),
+		}), ShouldBeNil)
+		a := newTestAPI(store, store)
+
+		Convey("When the page is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy/inflationandpriceindices", "")
+
+			Convey("Then the page is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+				So(w.Body.String(), ShouldEqual, testPageBody)
+			})
+		})
+
+		Convey("When the page is requested with a trailing slash", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy/inflationandpriceindices/", "")
+
+			Convey("Then the same page is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, testPageBody)
+			})
+		})
+
+		Convey("When a page that does not exist is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy", "")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+
+	Convey("Given a store that returns an error", t, func() {
+		a := newTestAPI(&mock.ContentStoreMock{
+			GetPageFunc: func(ctx context.Context, uri string) (*models.Page, error) { return nil, errStore },
+		}, &mock.CollectionStoreMock{})
+
+		Convey("When a page is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy", "")
+
+			Convey("Then a 500 is returned without leaking the error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Body.String(), ShouldNotContainSubstring, errStore.Error())
+			})
+		})
+	})
+}
+
+func TestPutContent(t *testing.T) {
+	Convey("Given an empty store", t, func() {
+		store := memory.New()
+		a := newTestAPI(store, store)
+
+		Convey("When pages are PUT at URIs ending with the names of actions on pages", func() {
+			uris := []string{"/releases/cancel", "/economy/children", "/aboutus/versions", "/economy/move"}
+			for _, uri := range uris {
+				So(doRequest(a, http.MethodPut, "/v1/content"+uri, testPageBody).Code, ShouldEqual, http.StatusCreated)
+			}
+
+			Convey("Then each is stored and can be read at its URI", func() {
+				for _, uri := range uris {
+					_, err := store.GetPage(ctx, uri)
+					So(err, ShouldBeNil)
+					w := doRequest(a, http.MethodGet, "/v1/content"+uri, "")
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Body.String(), ShouldContainSubstring, `"uri":"`+uri+`"`)
+				}
+			})
+		})
+
+		Convey("When a page is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", testPageBody)
+
+			Convey("Then it is created and stored against the normalised URI", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(w.Body.String(), ShouldEqual, storedPage)
+				page, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(page.Type, ShouldEqual, models.PageTypeStaticPage)
+				So(string(page.Data), ShouldEqual, storedPage)
+			})
+
+			Convey("Then it is returned with the ETag it is read with, to be given when it is next changed", func() {
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag([]byte(storedPage)))
+				So(w.Header().Get("ETag"), ShouldEqual, doRequest(a, http.MethodGet, "/v1/content/economy", "").Header().Get("ETag"))
+				w := doRequestIfMatch(a, http.MethodPut, "/v1/content/economy", `{"type":"static_page","description":{"title":"Contact us"}}`, w.Header().Get("ETag"))
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag(w.Body.Bytes()))
+			})
+
+			Convey("Then PUTting it again with its ETag replaces it", func() {
+				w := doRequestIfMatch(a, http.MethodPut, "/v1/content/economy", `{"type":"static_page","description":{"title":"Contact us"}}`, models.ETag([]byte(storedPage)))
+				So(w.Code, ShouldEqual, http.StatusOK)
+				page, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/economy","description":{"title":"Contact us"}}`)
+			})
+
+			Convey("Then PUTting it again without If-Match is refused, and the page is unchanged", func() {
+				w := doRequest(a, http.MethodPut, "/v1/content/economy", `{"type":"static_page","description":{"title":"Contact us"}}`)
+				So(w.Code, ShouldEqual, http.StatusPreconditionRequired)
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag([]byte(storedPage)))
+				page, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, storedPage)
+			})
+
+			Convey("Then PUTting it again with an out of date ETag is refused, giving the current ETag", func() {
+				w := doRequestIfMatch(a, http.MethodPut, "/v1/content/economy", `{"type":"static_page","description":{"title":"Contact us"}}`, models.ETag([]byte(testPageBody)))
+				So(w.Code, ShouldEqual, http.StatusPreconditionFailed)
+				So(w.Header().Get("ETag"), ShouldEqual, models.ETag([]byte(storedPage)))
+				page, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, storedPage)
+			})
+		})
+
+		Convey("When a page that does not exist is PUT with If-Match", func() {
+			w := doRequestIfMatch(a, http.MethodPut, "/v1/content/economy", testPageBody, models.ETag([]byte(storedPage)))
+
+			Convey("Then it is refused, as there is no version to match", func() {
+				So(w.Code, ShouldEqual, http.StatusPreconditionFailed)
+				_, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			})
+		})
+
+		Convey("When a body that is not a JSON object is PUT", func() {
+			for _, body := range []string{"", "not json", "[1,2,3]", "null"} {
+				w := doRequest(a, http.MethodPut, "/v1/content/economy", body)
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			}
+
+			Convey("Then nothing is stored", func() {
+				_, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a page with an unsupported type is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", `{"type":"visualisation_page"}`)
+
+			Convey("Then a 400 is returned describing the problem", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"ValidationFailed","description":"unsupported page type \"visualisation_page\"","field":"type"}]}`)
+			})
+		})
+
+		Convey("When a page that does not match its declared type is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", `{"type":"bulletin","description":{"title":"CPI"}}`)
+
+			Convey("Then a 400 is returned listing the invalid fields", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"ValidationFailed","description":"is required","field":"description.releaseDate"}]}`)
+			})
+
+			Convey("Then nothing is stored", func() {
+				_, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a store that returns an error", t, func() {
+		a := newTestAPI(&mock.ContentStoreMock{
+			GetPageFunc:    func(ctx context.Context, uri string) (*models.Page, error) { return nil, apierrors.ErrPageNotFound },
+			CreatePageFunc: func(ctx context.Context, page *models.Page) error { return errStore },
+		}, &mock.CollectionStoreMock{})
+
+		Convey("When a page is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", testPageBody)
+
+			Convey("Then a 500 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+
+	Convey("Given a page that is changed by another request while it is being replaced", t, func() {
+		current := &models.Page{URI: "/economy", Type: models.PageTypeStaticPage, Data: json.RawMessage(storedPage)}
+		contentStore := &mock.ContentStoreMock{
+			GetPageFunc:     func(ctx context.Context, uri string) (*models.Page, error) { return current, nil },
+			ReplacePageFunc: func(ctx context.Context, page *models.Page, etag string) error { return apierrors.ErrEditConflict },
+		}
+		a := newTestAPI(contentStore, &mock.CollectionStoreMock{})
+
+		Convey("When the page is PUT with the ETag it had", func() {
+			w := doRequestIfMatch(a, http.MethodPut, "/v1/content/economy", testPageBody, current.ETag())
+
+			Convey("Then the store is asked to replace only that version", func() {
+				So(contentStore.ReplacePageCalls(), ShouldHaveLength, 1)
+				So(contentStore.ReplacePageCalls()[0].Etag, ShouldEqual, current.ETag())
+			})
+
+			Convey("Then a 409 is returned with the ETag of the version that is now current", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"EditConflict"`)
+				So(w.Header().Get("ETag"), ShouldEqual, current.ETag())
+			})
+		})
+	})
+}
+
+func TestPostContent(t *testing.T) {
+	Convey("Given an empty store", t, func() {
+		store := memory.New()
+		a := newTestAPI(store, store)
+
+		Convey("When a page is POSTed", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content/economy", testPageBody)
+
+			Convey("Then it is created", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(w.Body.String(), ShouldEqual, storedPage)
+			})
+
+			Convey("Then POSTing it again results in a conflict", func() {
+				w := doRequest(a, http.MethodPost, "/v1/content/economy", testPageBody)
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+
+		Convey("When an invalid body is POSTed", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content/economy", "{")
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page without a type is POSTed", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content/economy", `{"description":{"title":"About us"}}`)
+
+			Convey("Then a 400 is returned describing the missing type", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"ValidationFailed","description":"is required","field":"type"}]}`)
+			})
+		})
+	})
+}
+
+func TestDeleteContent(t *testing.T) {
+	Convey("Given a store containing a page", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Data: json.RawMessage(testPageBody)}), ShouldBeNil)
+		a := newTestAPI(store, store)
+
+		Convey("When the page is deleted", func() {
+			w := doRequest(a, http.MethodDelete, "/v1/content/economy", "")
+
+			Convey("Then a 204 is returned and the page is removed", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				_, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then deleting it again returns a 404", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/content/economy", "")
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestContentEvents(t *testing.T) {
+	Convey("Given an API with an event producer", t, func() {
+		store := memory.New()
+		events := newEventProducerMock()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), events)
+
+		Convey("When a page is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", testPageBody)
+
+			Convey("Then a content published event is sent", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(events.ContentPublishedCalls(), ShouldHaveLength, 1)
+				e := events.ContentPublishedCalls()[0].E
+				So(e.URI, ShouldEqual, "/economy")
+				So(e.Type, ShouldEqual, models.PageTypeStaticPage)
+				So(e.CollectionID, ShouldBeEmpty)
+			})
+
+			Convey("Then deleting it sends a content deleted event", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/content/economy", "")
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(events.ContentDeletedCalls(), ShouldHaveLength, 1)
+				e := events.ContentDeletedCalls()[0].E
+				So(e.URI, ShouldEqual, "/economy")
+				So(e.Type, ShouldEqual, models.PageTypeStaticPage)
+			})
+		})
+
+		Convey("When a page is PUT and the event cannot be sent", func() {
+			events.ContentPublishedFunc = func(ctx context.Context, e *event.ContentPublished) error { return errStore }
+			w := doRequest(a, http.MethodPut, "/v1/content/economy", testPageBody)
+
+			Convey("Then the page is still stored", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				_, err := store.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When a page that does not exist is deleted", func() {
+			w := doRequest(a, http.MethodDelete, "/v1/content/economy", "")
+
+			Convey("Then no event is sent", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(events.ContentDeletedCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// getDataHandler serves a page in the legacy data.json format of Zebedee's /data endpoint, so that clients written
+// for Zebedee can read content from this API unchanged. As with Zebedee, the collection to read drafts from is
+// given in the path (or the Collection-Id header), and references to other pages are resolved if the
+// resolveReferences query parameter is present. The Welsh translation of a page is returned if the lang query
+// parameter is cy, falling back to the English page if it has not been translated.
+func (api *API) getDataHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	query := req.URL.Query()
+	logData := log.Data{"uri": query.Get("uri"), "lang": query.Get("lang")}
+
+	if query.Get("uri") == "" {
+		handleError(ctx, w, apierrors.ErrURIRequired, logData)
+		return
+	}
+	uri := models.CleanURI(query.Get("uri"))
+
+	collectionID := mux.Vars(req)["collection_id"]
+	if collectionID == "" {
+		var err error
+		if collectionID, err = dprequest.GetCollectionID(req); err != nil {
+			log.Event(ctx, "reading collection id failed", log.WARN, log.Error(err), logData)
+		}
+	}
+
+	// as with Zebedee, any language other than Welsh is taken to mean English
+	lang := models.LanguageEnglish
+	if models.Language(query.Get("lang")) == models.LanguageWelsh {
+		lang = models.LanguageWelsh
+	}
+
+	page, returned, err := api.getPageInLanguage(ctx, collectionID, uri, lang, logData)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// the titles of linked pages can change without the page itself changing, so only the ETag can tell if the
+	// client has the same response once references are resolved
+	policy := cachePolicy{pageType: page.Type, lastModified: page.LastUpdated, private: collectionID != ""}
+	data := page.Data
+	if _, ok := query["resolveReferences"]; ok {
+		policy.lastModified = time.Time{}
+		data, err = models.UpdateContent(page.Data, func(content models.Content) error {
+			return api.resolveReferences(ctx, collectionID, lang, content, logData)
+		})
+		if err != nil {
+			handleError(ctx, w, err, logData)
+			return
+		}
+	}
+
+	data, err = models.ZebedeeJSON(data)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	setLanguageHeaders(w, lang, returned)
+	api.writeCacheable(w, req, contentTypeJSON, data, policy, logData)
+}
+
+// resolveReferences gives every link from the content the title of the page it links to in the language, reading
+// pages from the collection if one is provided. Links to pages that do not exist are left as they are.
+func (api *API) resolveReferences(ctx context.Context, collectionID string, lang models.Language, content models.Content, logData log.Data) error {
+	titles := make(map[string]string)
+	for _, link := range models.Links(content) {
+		uri := models.CleanURI(link.URI)
+		title, ok := titles[uri]
+		if !ok {
+			page, _, err := api.getPageInLanguage(ctx, collectionID, uri, lang, logData)
+			switch err {
+			case nil:
+				if title, err = pageTitle(page); err != nil {
+					return err
+				}
+			case apierrors.ErrPageNotFound:
+			default:
+				return err
+			}
+			titles[uri] = title
+		}
+
+		if title != "" {
+			link.Title = title
+		}
+	}
+	return nil
+}
+
+// pageTitle returns the title from the description of the page
+func pageTitle(page *models.Page) (string, error) {
+	content, err := models.ParseContent(page.Data)
+	if err != nil {
+		return "", err
+	}
+	return content.Base().Description.Title, nil
+}
@@ -0,0 +1,170 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the legacy data endpoint")
+
+// goldenPages are the URIs that each page in testdata/data is stored at, covering every page type
+var goldenPages = []struct {
+	name string
+	uri  string
+}{
+	{"article", "/economy/grossdomesticproductgdp/articles/coronavirusandtheimpactonoutputintheukeconomy/january2021"},
+	{"bulletin", "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021"},
+	{"compendium_landing_page", "/economy/grossdomesticproductgdp/compendium/unitedkingdomnationalaccountsthebluebook/2020"},
+	{"dataset_landing_page", "/economy/inflationandpriceindices/datasets/consumerpriceinflation"},
+	{"release", "/releases/consumerpriceinflationukmarch2021"},
+	{"static_methodology", "/economy/inflationandpriceindices/methodologies/consumerpricesindexincludingowneroccupiershousingcostscpih"},
+	{"static_page", "/aboutus"},
+	{"timeseries", "/economy/inflationandpriceindices/timeseries/l55o/mm23"},
+}
+
+// storeGoldenPages PUTs every page in testdata/data at its URI
+func storeGoldenPages(a *api.API) {
+	for _, page := range goldenPages {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "data", page.name+".json"))
+		So(err, ShouldBeNil)
+		w := doRequest(a, http.MethodPut, "/v1/content"+page.uri, string(body))
+		So(w.Code, ShouldEqual, http.StatusCreated)
+	}
+}
+
+// assertGolden compares the response body with the golden file, rewriting the file instead when -update is set
+func assertGolden(body []byte, name string) {
+	var indented bytes.Buffer
+	So(json.Indent(&indented, body, "", "  "), ShouldBeNil)
+	indented.WriteString("\n")
+
+	path := filepath.Join("testdata", "data", name+".golden")
+	if *update {
+		So(ioutil.WriteFile(path, indented.Bytes(), 0644), ShouldBeNil)
+	}
+	golden, err := ioutil.ReadFile(path)
+	So(err, ShouldBeNil)
+	So(indented.String(), ShouldEqual, string(golden))
+}
+
+func TestGetData(t *testing.T) {
+	Convey("Given a store containing a page of every type", t, func() {
+		store := memory.New()
+		a := newTestAPI(store, store)
+		storeGoldenPages(a)
+
+		Convey("When each page is requested from the legacy data endpoint", func() {
+			for _, page := range goldenPages {
+				w := serve(a, newRequest(nil, http.MethodGet, "/data?uri="+page.uri, ""))
+
+				Convey("Then the "+page.name+" is returned in the Zebedee format", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+					assertGolden(w.Body.Bytes(), page.name)
+				})
+			}
+		})
+
+		Convey("When a page is requested with its references resolved", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/data?uri=/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021&resolveReferences", ""))
+
+			Convey("Then links to stored pages are given their titles, and other links are left as they are", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				assertGolden(w.Body.Bytes(), "bulletin_resolved")
+			})
+		})
+
+		Convey("When a page is requested in Welsh", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/data?uri=/aboutus&lang=cy", ""))
+
+			Convey("Then the English page is returned, as it has not been translated", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("X-Language-Fallback"), ShouldEqual, "true")
+				assertGolden(w.Body.Bytes(), "static_page")
+			})
+		})
+
+		Convey("When a page that has been translated is requested in Welsh", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/aboutus?lang=cy", `{"type":"static_page","description":{"title":"Amdanom ni"}}`)
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			w = serve(a, newRequest(nil, http.MethodGet, "/data?uri=/aboutus&lang=cy", ""))
+
+			Convey("Then the Welsh page is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Language"), ShouldEqual, "cy")
+				So(w.Body.String(), ShouldEqual, `{"description":{"language":"cy","title":"Amdanom ni"},"type":"static_page","uri":"/aboutus"}`)
+			})
+		})
+
+		Convey("When a page is requested without a uri", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/data", ""))
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page that does not exist is requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/data?uri=/economy", ""))
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+
+	Convey("Given a collection containing a draft of a published page", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/aboutus", Data: json.RawMessage(`{"type":"static_page","uri":"/aboutus","description":{"title":"About us"}}`)}), ShouldBeNil)
+		So(store.CreateCollection(ctx, &models.Collection{ID: "123", Name: "About us", State: models.CollectionStateInProgress}), ShouldBeNil)
+		So(store.UpsertDraftPage(ctx, "123", &models.Page{URI: "/aboutus", Data: json.RawMessage(`{"type":"static_page","uri":"/aboutus","description":{"title":"About the ONS"}}`)}), ShouldBeNil)
+		a := newTestAPI(store, store)
+
+		Convey("When a viewer requests the page from the collection", func() {
+			w := serve(a, newRequest(viewer, http.MethodGet, "/data/123?uri=/aboutus", ""))
+
+			Convey("Then the draft is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"description":{"title":"About the ONS"},"type":"static_page","uri":"/aboutus"}`)
+			})
+		})
+
+		Convey("When a viewer requests the page with the collection in the Collection-Id header", func() {
+			req := newRequest(viewer, http.MethodGet, "/data?uri=/aboutus", "")
+			req.Header.Set("Collection-Id", "123")
+			w := serve(a, req)
+
+			Convey("Then the draft is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, "About the ONS")
+			})
+		})
+
+		Convey("When the page is requested from the collection without authenticating", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/data/123?uri=/aboutus", ""))
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+
+		Convey("When the page is requested without a collection", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/data?uri=/aboutus", ""))
+
+			Convey("Then the published page is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, "About us")
+			})
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// pageMove records the URI a page was moved from and the URI it was moved to
+type pageMove struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// moveResponse is the body returned when pages have been moved
+type moveResponse struct {
+	Count int         `json:"count"`
+	Items []*pageMove `json:"items"`
+}
+
+// getBreadcrumbHandler returns a summary of each published page above the page at the URI, starting from the root
+func (api *API) getBreadcrumbHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	breadcrumb, err := api.contentStore.GetBreadcrumb(ctx, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, summariesResponse{Count: len(breadcrumb), Items: breadcrumb}, logData)
+}
+
+// getChildrenHandler returns a summary of each published page directly below the URI
+func (api *API) getChildrenHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	children, err := api.contentStore.GetChildren(ctx, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, summariesResponse{Count: len(children), Items: children}, logData)
+}
+
+// moveContentHandler moves the page at the URI, and every page below it, to the destination given in the request
+// body. The translations and previous versions of the pages are moved with them.
+func (api *API) moveContentHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	from := pageURI(req)
+	logData := log.Data{"uri": from}
+
+	if models.IsVersionURI(from) {
+		handleError(ctx, w, apierrors.ErrVersionReadOnly, logData)
+		return
+	}
+
+	var moveRequest models.MoveRequest
+	if err := json.NewDecoder(req.Body).Decode(&moveRequest); err != nil {
+		handleError(ctx, w, apierrors.ErrInvalidBody, logData)
+		return
+	}
+	if moveRequest.Destination == "" {
+		handleError(ctx, w, apierrors.ErrDestinationRequired, logData)
+		return
+	}
+
+	to := models.CleanURI(moveRequest.Destination)
+	logData["destination"] = to
+	if models.IsWithin(to, from) || models.IsWithin(from, to) || models.IsVersionURI(to) {
+		handleError(ctx, w, apierrors.ErrInvalidDestination, logData)
+		return
+	}
+
+	pages, err := api.contentStore.GetSubtree(ctx, from)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// the subtree is moved in one transaction, which must complete within the query timeout
+	if len(pages) > api.maxMovePages {
+		logData["pages"] = len(pages)
+		handleError(ctx, w, apierrors.ErrTooManyPages, logData)
+		return
+	}
+
+	// check the destination is free before auditing the move, as the audit records must be made first
+	if _, err := api.contentStore.GetPage(ctx, to); err != apierrors.ErrPageNotFound {
+		if err == nil {
+			err = apierrors.ErrPageAlreadyExists
+		}
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// the translations and versions of each page are moved with it, and are covered by the audit record of its move
+	movedAt := time.Now().UTC()
+	moved := make([]*models.Page, len(pages))
+	records := make([]*models.AuditRecord, len(pages))
+	for i, page := range pages {
+		if moved[i], err = page.Move(models.MovedURI(page.URI, from, to), movedAt); err != nil {
+			handleError(ctx, w, err, logData)
+			return
+		}
+		record := &models.AuditRecord{Action: models.AuditActionMove, URI: page.URI, MovedTo: moved[i].URI}
+		if records[i], err = api.addAuditRecord(ctx, record, page, moved[i]); err != nil {
+			api.auditFailed(ctx, records[:i]...)
+			handleError(ctx, w, err, logData)
+			return
+		}
+	}
+
+	if err := api.contentStore.MovePages(ctx, from, to, movedAt); err != nil {
+		api.auditFailed(ctx, records...)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	logData["pages"] = len(pages)
+	log.Event(ctx, "pages moved", log.INFO, logData)
+
+	moves := make([]*pageMove, len(pages))
+	for i, page := range pages {
+		moves[i] = &pageMove{From: page.URI, To: moved[i].URI}
+		api.sendContentDeleted(ctx, &event.ContentDeleted{URI: page.URI, Type: page.Type, Lang: models.LanguageEnglish, Timestamp: movedAt}, logData)
+		api.sendContentPublished(ctx, &event.ContentPublished{URI: moved[i].URI, Type: page.Type, Lang: models.LanguageEnglish, Timestamp: movedAt}, logData)
+	}
+	writeJSON(ctx, w, http.StatusOK, moveResponse{Count: len(moves), Items: moves}, logData)
+}
@@ -0,0 +1,171 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// storeStaticPage stores a static page with the title at the URI
+func storeStaticPage(store *memory.Store, uri, title string) {
+	data := json.RawMessage(`{"type":"static_page","uri":"` + uri + `","description":{"title":"` + title + `"}}`)
+	So(store.CreatePage(ctx, &models.Page{URI: uri, Type: models.PageTypeStaticPage, Data: data}), ShouldBeNil)
+}
+
+func TestGetHierarchy(t *testing.T) {
+	Convey("Given a tree of pages", t, func() {
+		store := memory.New()
+		storeStaticPage(store, "/economy", "Economy")
+		storeStaticPage(store, "/economy/inflationandpriceindices", "Inflation and price indices")
+		storeStaticPage(store, "/economy/inflationandpriceindices/bulletins/consumerpriceinflation", "Consumer price inflation")
+		a := newTestAPI(store, store)
+
+		Convey("When the breadcrumb of the deepest page is requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content-actions/breadcrumb/economy/inflationandpriceindices/bulletins/consumerpriceinflation", ""))
+
+			Convey("Then each page above it is returned, starting from the root", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"count":2,"items":[`+
+					`{"uri":"/economy","type":"static_page","title":"Economy"},`+
+					`{"uri":"/economy/inflationandpriceindices","type":"static_page","title":"Inflation and price indices"}]}`)
+			})
+		})
+
+		Convey("When the children of a page are requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content-actions/children/economy", ""))
+
+			Convey("Then the pages directly below it are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"count":1,"items":[{"uri":"/economy/inflationandpriceindices","type":"static_page","title":"Inflation and price indices"}]}`)
+			})
+		})
+
+		Convey("When the children of a page without any are requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content-actions/children/economy/inflationandpriceindices/bulletins/consumerpriceinflation", ""))
+
+			Convey("Then an empty list is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"count":0,"items":[]}`)
+			})
+		})
+
+		Convey("When the breadcrumb of a page that does not exist is requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content-actions/breadcrumb/economy/inflationandpriceindices/bulletins", ""))
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestMoveContent(t *testing.T) {
+	Convey("Given a tree of pages", t, func() {
+		store := memory.New()
+		storeStaticPage(store, "/economy", "Economy")
+		storeStaticPage(store, "/economy/inflation", "Inflation")
+		storeStaticPage(store, "/economy/inflation/cpi", "Consumer price inflation")
+		storeStaticPage(store, "/economy/gdp", "GDP")
+		events := newEventProducerMock()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), events)
+
+		Convey("When a publisher moves a subtree", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/move/economy/inflation", `{"destination":"/economy/prices"}`)
+
+			Convey("Then every page in it is moved, and the moves are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"count":2,"items":[`+
+					`{"from":"/economy/inflation","to":"/economy/prices"},`+
+					`{"from":"/economy/inflation/cpi","to":"/economy/prices/cpi"}]}`)
+
+				page, err := store.GetPage(ctx, "/economy/prices/cpi")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldContainSubstring, `"uri":"/economy/prices/cpi"`)
+				children, err := store.GetChildren(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(children[1].URI, ShouldEqual, "/economy/prices")
+			})
+
+			Convey("Then the move of each page is audited", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				for _, record := range records {
+					So(record.Action, ShouldEqual, models.AuditActionMove)
+					So(record.MovedTo, ShouldEqual, models.MovedURI(record.URI, "/economy/inflation", "/economy/prices"))
+					So(record.BeforeHash, ShouldNotEqual, record.AfterHash)
+				}
+			})
+
+			Convey("Then each page is deleted from its old URI and published at its new one", func() {
+				So(events.ContentDeletedCalls(), ShouldHaveLength, 2)
+				So(events.ContentDeletedCalls()[0].E.URI, ShouldEqual, "/economy/inflation")
+				So(events.ContentPublishedCalls(), ShouldHaveLength, 2)
+				So(events.ContentPublishedCalls()[1].E.URI, ShouldEqual, "/economy/prices/cpi")
+			})
+		})
+
+		Convey("When a subtree is moved onto a page that exists", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/move/economy/inflation", `{"destination":"/economy/gdp"}`)
+
+			Convey("Then a 409 is returned, and nothing is moved or audited", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(readAuditRecords(store), ShouldBeEmpty)
+				_, err := store.GetPage(ctx, "/economy/inflation")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When a subtree with more pages than can be moved at once is moved", func() {
+			cfg := *newTestConfig()
+			cfg.MoveMaxPages = 1
+			a := api.Setup(ctx, &cfg, mux.NewRouter(), store, store, store, store, newSchedulerMock(), events)
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/move/economy/inflation", `{"destination":"/economy/prices"}`)
+
+			Convey("Then a 409 is returned, and nothing is moved or audited", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"TooManyPages"`)
+				So(readAuditRecords(store), ShouldBeEmpty)
+				_, err := store.GetPage(ctx, "/economy/inflation/cpi")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When a subtree is moved below itself", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/move/economy", `{"destination":"/economy/archive"}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page is moved without a destination", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/move/economy/inflation", `{}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page that does not exist is moved", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/move/business", `{"destination":"/businessindustryandtrade"}`)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a viewer moves a page", func() {
+			w := serve(a, newRequest(viewer, http.MethodPost, "/v1/content-actions/move/economy/inflation", `{"destination":"/economy/prices"}`))
+
+			Convey("Then a 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+//go:generate moq -out mock/contentStore.go -pkg mock . ContentStore
+//go:generate moq -out mock/collectionStore.go -pkg mock . CollectionStore
+//go:generate moq -out mock/auditStore.go -pkg mock . AuditStore
+//go:generate moq -out mock/redirectStore.go -pkg mock . RedirectStore
+//go:generate moq -out mock/scheduler.go -pkg mock . Scheduler
+//go:generate moq -out mock/eventProducer.go -pkg mock . EventProducer
+
+// ContentStore defines the required methods from the store of website content
+type ContentStore interface {
+	GetPage(ctx context.Context, uri string) (*models.Page, error)
+	CreatePage(ctx context.Context, page *models.Page) error
+	UpsertPage(ctx context.Context, page *models.Page) (bool, error)
+	ReplacePage(ctx context.Context, page *models.Page, etag string) error
+	DeletePage(ctx context.Context, uri string) error
+	GetPageVersions(ctx context.Context, uri string) ([]*models.PageVersion, error)
+	GetPageVersion(ctx context.Context, uri string, version int) (*models.PageVersion, error)
+	GetTranslation(ctx context.Context, uri string, lang models.Language) (*models.Page, error)
+	CreateTranslation(ctx context.Context, lang models.Language, page *models.Page) error
+	UpsertTranslation(ctx context.Context, lang models.Language, page *models.Page) (bool, error)
+	ReplaceTranslation(ctx context.Context, lang models.Language, page *models.Page, etag string) error
+	DeleteTranslation(ctx context.Context, uri string, lang models.Language) error
+	GetUntranslatedPages(ctx context.Context, lang models.Language) ([]*models.PageSummary, error)
+	GetBreadcrumb(ctx context.Context, uri string) ([]*models.PageSummary, error)
+	GetChildren(ctx context.Context, uri string) ([]*models.PageSummary, error)
+	GetSubtree(ctx context.Context, uri string) ([]*models.Page, error)
+	MovePages(ctx context.Context, from, to string, movedAt time.Time) error
+	GetReleases(ctx context.Context, filter models.ReleaseFilter) ([]*models.ReleaseSummary, int, error)
+	GetTimeseries(ctx context.Context, cdid, datasetID string) (*models.Page, error)
+	UpdateTimeseriesValues(ctx context.Context, page *models.Page, etag string) error
+}
+
+// CollectionStore defines the required methods from the store of collections and their draft pages
+type CollectionStore interface {
+	CreateCollection(ctx context.Context, collection *models.Collection) error
+	GetCollection(ctx context.Context, id string) (*models.Collection, error)
+	GetCollections(ctx context.Context) ([]*models.Collection, error)
+	DeleteCollection(ctx context.Context, id string) error
+	GetDraftPage(ctx context.Context, collectionID, uri string) (*models.Page, error)
+	UpsertDraftPage(ctx context.Context, collectionID string, page *models.Page) error
+	ReplaceDraftPage(ctx context.Context, collectionID string, page *models.Page, etag string) error
+	DeleteDraftPage(ctx context.Context, collectionID, uri string) error
+	UpdateItemState(ctx context.Context, collectionID, uri string, state models.ItemState) error
+	PublishCollection(ctx context.Context, collectionID string, publishedAt time.Time) error
+}
+
+// AuditStore defines the required methods from the store of audit records of changes to content
+type AuditStore interface {
+	AddAuditRecord(ctx context.Context, record *models.AuditRecord) error
+	GetAuditRecords(ctx context.Context, filter models.AuditFilter) ([]*models.AuditRecord, int, error)
+}
+
+// RedirectStore defines the required methods from the store of redirects from URIs that no longer have a page
+type RedirectStore interface {
+	GetRedirect(ctx context.Context, from string) (*models.Redirect, error)
+	GetRedirects(ctx context.Context) ([]*models.Redirect, error)
+	UpsertRedirect(ctx context.Context, redirect *models.Redirect) (bool, error)
+	UpsertRedirects(ctx context.Context, redirects []*models.Redirect) error
+	DeleteRedirect(ctx context.Context, from string) error
+}
+
+// Scheduler defines the required methods from the scheduler that publishes collections at their publish date
+type Scheduler interface {
+	Schedule(ctx context.Context, collection *models.Collection)
+	Cancel(ctx context.Context, collectionID string)
+	NextPublish() (time.Time, bool)
+}
+
+// EventProducer defines the required methods to notify other services of changes to content
+type EventProducer interface {
+	ContentPublished(ctx context.Context, e *event.ContentPublished) error
+	ContentDeleted(ctx context.Context, e *event.ContentDeleted) error
+}
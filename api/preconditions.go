@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// checkIfMatch returns an error unless the If-Match header of the request matches the ETag of the current version
+// of the page, which is nil if there is none. When required, a page that exists can only be replaced by a request
+// that gives its ETag, so that changes made since the caller read it are not silently overwritten.
+func checkIfMatch(req *http.Request, current *models.Page, required bool) error {
+	if current == nil {
+		return checkETagMatch(req, "", required)
+	}
+	return checkETagMatch(req, current.ETag(), required)
+}
+
+// checkCollectionIfMatch returns an error unless the If-Match header of the request matches the ETag of the
+// collection, so that a collection is only changed by a caller who has seen every change made to it
+func checkCollectionIfMatch(req *http.Request, collection *models.Collection) error {
+	return checkETagMatch(req, collection.ETag(), true)
+}
+
+// checkETagMatch returns an error unless the If-Match header of the request matches the ETag, which is empty if
+// there is no current version
+func checkETagMatch(req *http.Request, etag string, required bool) error {
+	ifMatch := req.Header.Get("If-Match")
+	switch {
+	case ifMatch == "" && etag != "" && required:
+		return apierrors.ErrPreconditionRequired
+	case ifMatch == "":
+		return nil
+	case etag == "" || !matchesETag(ifMatch, etag, true):
+		return apierrors.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// handleEditError writes the error from a conditional change to a page, giving the ETag of the version that is now
+// current so that the caller can read it and make their change again. The current version is read with get, as
+// after a conflict it is not the version the request was checked against.
+func handleEditError(ctx context.Context, w http.ResponseWriter, err error, get func() (*models.Page, error), logData log.Data) {
+	if isEditError(err) {
+		if current, getErr := get(); getErr == nil {
+			w.Header().Set("ETag", current.ETag())
+		}
+	}
+	handleError(ctx, w, err, logData)
+}
+
+// handleCollectionEditError writes the error from a conditional change to a collection, giving the ETag of the
+// collection as handleEditError does for pages
+func handleCollectionEditError(ctx context.Context, w http.ResponseWriter, err error, collection *models.Collection, logData log.Data) {
+	if isEditError(err) {
+		w.Header().Set("ETag", collection.ETag())
+	}
+	handleError(ctx, w, err, logData)
+}
+
+// isEditError returns true if the error is the refusal of a change made to a version that is not current
+func isEditError(err error) bool {
+	switch err {
+	case apierrors.ErrPreconditionRequired, apierrors.ErrPreconditionFailed, apierrors.ErrEditConflict:
+		return true
+	}
+	return false
+}
+
+// writePage writes the page that was stored by a request, with its ETag so that it can be changed again without
+// being read first
+func writePage(ctx context.Context, w http.ResponseWriter, status int, page *models.Page, logData log.Data) {
+	w.Header().Set("ETag", page.ETag())
+	writeJSONBody(ctx, w, status, page.Data, logData)
+}
+
+// writeCollection writes the collection with its ETag, to be given as If-Match when the collection is changed
+func writeCollection(ctx context.Context, w http.ResponseWriter, status int, collection *models.Collection, logData log.Data) {
+	w.Header().Set("ETag", collection.ETag())
+	writeJSON(ctx, w, status, collection, logData)
+}
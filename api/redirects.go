@@ -0,0 +1,185 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// redirectsResponse is the body returned when listing or uploading redirects
+type redirectsResponse struct {
+	Count int                `json:"count"`
+	Items []*models.Redirect `json:"items"`
+}
+
+// getRedirectsHandler returns every redirect, by the URI it is from
+func (api *API) getRedirectsHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logData := log.Data{}
+
+	redirects, err := api.redirectStore.GetRedirects(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, redirectsResponse{Count: len(redirects), Items: redirects}, logData)
+}
+
+// postRedirectsHandler stores every redirect in a CSV request body, for migrating redirects in bulk. Either every
+// redirect is stored, or none of them are. The redirects are returned as stored, with chains followed to the end.
+// Files that are too large to store at once are rejected without reading past the limit.
+func (api *API) postRedirectsHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logData := log.Data{}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, api.maxRedirectBytes))
+	if err != nil {
+		logData["max_bytes"] = api.maxRedirectBytes
+		handleError(ctx, w, apierrors.ErrTooManyRedirects, logData)
+		return
+	}
+
+	redirects, err := models.ParseRedirectsCSV(bytes.NewReader(body), time.Now().UTC(), api.maxRedirectRows)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	logData["redirects"] = len(redirects)
+
+	records := make([]*models.AuditRecord, len(redirects))
+	for i, redirect := range redirects {
+		record := &models.AuditRecord{Action: models.AuditActionUpdateRedirect, URI: redirect.From, RedirectTo: redirect.To}
+		if records[i], err = api.addAuditRecord(ctx, record, nil, nil); err != nil {
+			api.auditFailed(ctx, records[:i]...)
+			handleError(ctx, w, err, logData)
+			return
+		}
+	}
+
+	if err := api.redirectStore.UpsertRedirects(ctx, redirects); err != nil {
+		api.auditFailed(ctx, records...)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "redirects uploaded", log.INFO, logData)
+	writeJSON(ctx, w, http.StatusOK, redirectsResponse{Count: len(redirects), Items: redirects}, logData)
+}
+
+// getRedirectHandler returns the redirect from the URI
+func (api *API) getRedirectHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	from := pageURI(req)
+	logData := log.Data{"uri": from}
+
+	redirect, err := api.redirectStore.GetRedirect(ctx, from)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, redirect, logData)
+}
+
+// putRedirectHandler creates or replaces the redirect from the URI. The redirect is returned as stored, leading to
+// the end of any chain of redirects that it starts.
+func (api *API) putRedirectHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	from := pageURI(req)
+	logData := log.Data{"uri": from}
+
+	var redirectRequest models.RedirectRequest
+	if err := json.NewDecoder(req.Body).Decode(&redirectRequest); err != nil {
+		handleError(ctx, w, apierrors.ErrInvalidBody, logData)
+		return
+	}
+	if strings.TrimSpace(redirectRequest.To) == "" {
+		handleError(ctx, w, apierrors.ErrRedirectToRequired, logData)
+		return
+	}
+
+	redirect := &models.Redirect{From: from, To: models.CleanURI(redirectRequest.To), LastUpdated: time.Now().UTC()}
+	logData["to"] = redirect.To
+
+	record, err := api.addAuditRecord(ctx, &models.AuditRecord{Action: models.AuditActionUpdateRedirect, URI: from, RedirectTo: redirect.To}, nil, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	created, err := api.redirectStore.UpsertRedirect(ctx, redirect)
+	if err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	log.Event(ctx, "redirect stored", log.INFO, log.Data{"uri": from, "to": redirect.To, "created": created})
+	writeJSON(ctx, w, status, redirect, logData)
+}
+
+// deleteRedirectHandler removes the redirect from the URI
+func (api *API) deleteRedirectHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	from := pageURI(req)
+	logData := log.Data{"uri": from}
+
+	redirect, err := api.redirectStore.GetRedirect(ctx, from)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	record, err := api.addAuditRecord(ctx, &models.AuditRecord{Action: models.AuditActionDeleteRedirect, URI: from, RedirectTo: redirect.To}, nil, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.redirectStore.DeleteRedirect(ctx, from); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "redirect deleted", log.INFO, logData)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePageNotFound responds to a request for a page that does not exist, redirecting it to the URI the page was
+// moved to if there is a redirect from the URI. The query of the request is kept, so that the page is returned in
+// the same way from its new URI.
+func (api *API) handlePageNotFound(w http.ResponseWriter, req *http.Request, uri string, logData log.Data) {
+	ctx := req.Context()
+
+	redirect, err := api.redirectStore.GetRedirect(ctx, uri)
+	if err != nil {
+		if err == apierrors.ErrRedirectNotFound {
+			err = apierrors.ErrPageNotFound
+		}
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	location := "/v1/content" + redirect.To
+	if req.URL.RawQuery != "" {
+		location += "?" + req.URL.RawQuery
+	}
+	logData["location"] = location
+	log.Event(ctx, "redirecting request for a page that has moved", log.INFO, logData)
+
+	w.Header().Set("Location", location)
+	writeJSON(ctx, w, http.StatusMovedPermanently, redirect, logData)
+}
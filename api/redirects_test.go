@@ -0,0 +1,152 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedirects(t *testing.T) {
+	Convey("Given a published page", t, func() {
+		store := memory.New()
+		storeStaticPage(store, "/economy/inflation", "Inflation")
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+
+		Convey("When a publisher creates a redirect to it", func() {
+			w := doRequest(a, http.MethodPut, "/v1/redirects/inflation", `{"to":"economy/inflation"}`)
+
+			Convey("Then the redirect is stored", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(w.Body.String(), ShouldContainSubstring, `"from":"/inflation","to":"/economy/inflation"`)
+				w := serve(a, newRequest(viewer, http.MethodGet, "/v1/redirects/inflation", ""))
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then requests for its URI are redirected to the page, keeping their query", func() {
+				w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/inflation?lang=cy", ""))
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/v1/content/economy/inflation?lang=cy")
+			})
+
+			Convey("And a redirect back to its URI is created", func() {
+				w := doRequest(a, http.MethodPut, "/v1/redirects/economy/prices", `{"to":"/inflation"}`)
+
+				Convey("Then it leads to the end of the chain instead", func() {
+					So(w.Code, ShouldEqual, http.StatusCreated)
+					So(w.Body.String(), ShouldContainSubstring, `"from":"/economy/prices","to":"/economy/inflation"`)
+				})
+			})
+
+			Convey("And it is deleted", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/redirects/inflation", "")
+
+				Convey("Then requests for its URI are not found", func() {
+					So(w.Code, ShouldEqual, http.StatusNoContent)
+					w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/inflation", ""))
+					So(w.Code, ShouldEqual, http.StatusNotFound)
+				})
+			})
+		})
+
+		Convey("When a redirect is created from the page", func() {
+			w := doRequest(a, http.MethodPut, "/v1/redirects/economy/inflation", `{"to":"/economy"}`)
+
+			Convey("Then a 409 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+
+		Convey("When a redirect to its own URI is created", func() {
+			w := doRequest(a, http.MethodPut, "/v1/redirects/inflation", `{"to":"/inflation"}`)
+
+			Convey("Then a 409 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+
+		Convey("When a redirect is created without saying where it leads", func() {
+			w := doRequest(a, http.MethodPut, "/v1/redirects/inflation", `{}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the page is moved", func() {
+			So(doRequest(a, http.MethodPost, "/v1/content-actions/move/economy/inflation", `{"destination":"/economy/prices"}`).Code, ShouldEqual, http.StatusOK)
+
+			Convey("Then requests for its old URI are redirected to the new one", func() {
+				w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/economy/inflation", ""))
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/v1/content/economy/prices")
+			})
+		})
+
+		Convey("When a publisher uploads redirects as CSV", func() {
+			w := doRequest(a, http.MethodPost, "/v1/redirects", "from,to\n/inflation,/economy/inflation\n/cpi,/inflation\n")
+
+			Convey("Then every redirect is stored, leading to the end of any chain", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				w := serve(a, newRequest(viewer, http.MethodGet, "/v1/redirects", ""))
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"count":2`)
+				So(w.Body.String(), ShouldContainSubstring, `"from":"/cpi","to":"/economy/inflation"`)
+			})
+		})
+
+		Convey("When CSV containing an invalid line is uploaded", func() {
+			w := doRequest(a, http.MethodPost, "/v1/redirects", "/inflation,/economy/inflation\n/cpi\n")
+
+			Convey("Then the line is described, and no redirect is stored", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"field":"line 2"`)
+				redirects, err := store.GetRedirects(ctx)
+				So(err, ShouldBeNil)
+				So(redirects, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When CSV with more redirects than can be stored at once is uploaded", func() {
+			cfg := *newTestConfig()
+			cfg.RedirectsMaxRows = 1
+			a := api.Setup(ctx, &cfg, mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+			w := doRequest(a, http.MethodPost, "/v1/redirects", "from,to\n/inflation,/economy/inflation\n/cpi,/inflation\n")
+
+			Convey("Then a 400 is returned, and no redirect is stored or audited", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"TooManyRedirects"`)
+				redirects, err := store.GetRedirects(ctx)
+				So(err, ShouldBeNil)
+				So(redirects, ShouldBeEmpty)
+				So(readAuditRecords(store), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a CSV file larger than can be stored at once is uploaded", func() {
+			cfg := *newTestConfig()
+			cfg.RedirectsMaxBytes = 16
+			a := api.Setup(ctx, &cfg, mux.NewRouter(), store, store, store, store, newSchedulerMock(), newEventProducerMock())
+			w := doRequest(a, http.MethodPost, "/v1/redirects", "from,to\n/inflation,/economy/inflation\n")
+
+			Convey("Then a 400 is returned, and no redirect is stored", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"TooManyRedirects"`)
+				redirects, err := store.GetRedirects(ctx)
+				So(err, ShouldBeNil)
+				So(redirects, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a viewer creates a redirect", func() {
+			w := serve(a, newRequest(viewer, http.MethodPut, "/v1/redirects/inflation", `{"to":"/economy/inflation"}`))
+
+			Convey("Then a 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+}
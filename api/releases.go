@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// releasesResponse is the body returned when listing a page of the release calendar
+type releasesResponse struct {
+	Count      int                      `json:"count"`
+	Offset     int                      `json:"offset"`
+	Limit      int                      `json:"limit"`
+	TotalCount int                      `json:"total_count"`
+	Items      []*models.ReleaseSummary `json:"items"`
+}
+
+// getReleasesHandler returns a page of the releases matching the from, to and status query parameters, in order
+// of release date
+func (api *API) getReleasesHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logData := log.Data{"query": req.URL.Query()}
+
+	filter, err := api.readReleaseFilter(req)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	releases, total, err := api.contentStore.GetReleases(ctx, filter)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, releasesResponse{
+		Count:      len(releases),
+		Offset:     filter.Offset,
+		Limit:      filter.Limit,
+		TotalCount: total,
+		Items:      releases,
+	}, logData)
+}
+
+// readReleaseFilter reads the filter for releases from the query parameters of the request
+func (api *API) readReleaseFilter(req *http.Request) (models.ReleaseFilter, error) {
+	query := req.URL.Query()
+	filter := models.ReleaseFilter{Status: models.ReleaseStatus(query.Get("status"))}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return filter, apierrors.ErrInvalidReleaseStatus
+	}
+
+	var err error
+	if filter.From, err = readTime(query.Get("from"), apierrors.ErrInvalidReleaseDateFilter); err != nil {
+		return filter, err
+	}
+	if filter.To, err = readTime(query.Get("to"), apierrors.ErrInvalidReleaseDateFilter); err != nil {
+		return filter, err
+	}
+	filter.Offset, filter.Limit, err = api.readPagination(req)
+	return filter, err
+}
+
+// readPagination reads the offset and limit query parameters of the request, defaulting to the first page of the
+// default limit
+func (api *API) readPagination(req *http.Request) (offset, limit int, err error) {
+	query := req.URL.Query()
+	limit = api.defaultLimit
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, apierrors.ErrInvalidOffset
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 || limit > api.maxLimit {
+			return 0, 0, apierrors.ErrInvalidLimit
+		}
+	}
+	return offset, limit, nil
+}
+
+// confirmReleaseHandler confirms the date of a provisional release, moving it to the release date in the request
+// body if one is given
+func (api *API) confirmReleaseHandler(w http.ResponseWriter, req *http.Request) {
+	var confirmRequest models.ReleaseDateRequest
+	if err := json.NewDecoder(req.Body).Decode(&confirmRequest); err != nil {
+		handleError(req.Context(), w, apierrors.ErrInvalidBody, log.Data{"uri": pageURI(req)})
+		return
+	}
+
+	api.changeRelease(w, req, func(release *models.Release) error {
+		return release.Confirm(confirmRequest.ReleaseDate)
+	})
+}
+
+// postponeReleaseHandler moves a release to the later date in the request body, recording the reason given
+func (api *API) postponeReleaseHandler(w http.ResponseWriter, req *http.Request) {
+	var postponeRequest models.PostponeRequest
+	if err := json.NewDecoder(req.Body).Decode(&postponeRequest); err != nil {
+		handleError(req.Context(), w, apierrors.ErrInvalidBody, log.Data{"uri": pageURI(req)})
+		return
+	}
+
+	api.changeRelease(w, req, func(release *models.Release) error {
+		if postponeRequest.ReleaseDate == nil {
+			return apierrors.ErrReleaseDateRequired
+		}
+		if strings.TrimSpace(postponeRequest.Reason) == "" {
+			return apierrors.ErrReasonRequired
+		}
+		return release.Postpone(*postponeRequest.ReleaseDate, strings.TrimSpace(postponeRequest.Reason))
+	})
+}
+
+// cancelReleaseHandler cancels a release, giving the reason in the request body as its cancellation notice
+func (api *API) cancelReleaseHandler(w http.ResponseWriter, req *http.Request) {
+	var cancelRequest models.CancelRequest
+	if err := json.NewDecoder(req.Body).Decode(&cancelRequest); err != nil {
+		handleError(req.Context(), w, apierrors.ErrInvalidBody, log.Data{"uri": pageURI(req)})
+		return
+	}
+
+	api.changeRelease(w, req, func(release *models.Release) error {
+		if strings.TrimSpace(cancelRequest.Reason) == "" {
+			return apierrors.ErrReasonRequired
+		}
+		return release.Cancel(strings.TrimSpace(cancelRequest.Reason))
+	})
+}
+
+// changeRelease applies the change to the published release at the URI of the request, and stores it as an
+// update to the page, keeping the release as it was as a previous version
+func (api *API) changeRelease(w http.ResponseWriter, req *http.Request, change func(*models.Release) error) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	if models.IsVersionURI(uri) {
+		handleError(ctx, w, apierrors.ErrVersionReadOnly, logData)
+		return
+	}
+
+	previous, err := api.contentStore.GetPage(ctx, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	getCurrent := func() (*models.Page, error) { return api.contentStore.GetPage(ctx, uri) }
+	if err := checkIfMatch(req, previous, true); err != nil {
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	page, err := models.UpdateRelease(previous, time.Now().UTC(), change)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	record, err := api.audit(ctx, models.AuditActionUpdate, uri, "", previous, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.contentStore.ReplacePage(ctx, page, previous.ETag()); err != nil {
+		api.auditFailed(ctx, record)
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	log.Event(ctx, "release updated", log.INFO, logData)
+	api.sendContentPublished(ctx, &event.ContentPublished{URI: uri, Type: page.Type, Lang: models.LanguageEnglish, Timestamp: page.LastUpdated}, logData)
+	writePage(ctx, w, http.StatusOK, page, logData)
+}
@@ -0,0 +1,174 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func storeRelease(store *memory.Store, uri, description string) {
+	data := json.RawMessage(`{"type":"release","uri":"` + uri + `","description":` + description + `}`)
+	So(store.CreatePage(ctx, &models.Page{URI: uri, Type: models.PageTypeRelease, Data: data}), ShouldBeNil)
+}
+
+func TestGetReleases(t *testing.T) {
+	Convey("Given a calendar of releases", t, func() {
+		store := memory.New()
+		storeRelease(store, "/releases/cpi", `{"title":"CPI","releaseDate":"2021-04-21T06:00:00Z","finalised":true}`)
+		storeRelease(store, "/releases/gdp", `{"title":"GDP","releaseDate":"2021-05-12T06:00:00Z","provisionalDate":"May 2021"}`)
+		storeRelease(store, "/releases/lms", `{"title":"LMS","releaseDate":"2021-05-18T06:00:00Z","finalised":true}`)
+		a := newTestAPI(store, store)
+
+		Convey("When anyone lists the releases in a range of dates", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/releases?from=2021-05-01T00:00:00Z&to=2021-05-31T00:00:00Z", ""))
+
+			Convey("Then the releases in the range are returned in order of release date", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"count":2,"offset":0,"limit":20,"total_count":2`)
+				So(w.Body.String(), ShouldContainSubstring, `"uri":"/releases/gdp","title":"GDP","status":"provisional"`)
+			})
+		})
+
+		Convey("When a page of confirmed releases is listed", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/releases?status=confirmed&offset=1&limit=1", ""))
+
+			Convey("Then only that page is returned, with the total number of confirmed releases", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"count":1,"offset":1,"limit":1,"total_count":2`)
+				So(w.Body.String(), ShouldContainSubstring, `"uri":"/releases/lms"`)
+			})
+		})
+
+		Convey("When releases are listed with invalid query parameters", func() {
+			Convey("Then a 400 is returned", func() {
+				for _, query := range []string{"status=upcoming", "from=yesterday", "offset=-1", "limit=0", "limit=1001"} {
+					w := serve(a, newRequest(nil, http.MethodGet, "/v1/releases?"+query, ""))
+					So(w.Code, ShouldEqual, http.StatusBadRequest)
+				}
+			})
+		})
+	})
+}
+
+func TestChangeRelease(t *testing.T) {
+	Convey("Given a provisional release and a page that is not a release", t, func() {
+		store := memory.New()
+		storeRelease(store, "/releases/gdp", `{"title":"GDP","releaseDate":"2021-05-12T06:00:00Z","provisionalDate":"May 2021"}`)
+		storeStaticPage(store, "/economy", "Economy")
+		a := newTestAPI(store, store)
+
+		Convey("When a publisher confirms it at a new date", func() {
+			w := doChange(a, http.MethodPost, "/v1/content-actions/confirm/releases/gdp", `{"release_date":"2021-05-13T06:00:00Z"}`, "/v1/content/releases/gdp")
+
+			Convey("Then it is confirmed at that date, and the provisional release is kept as a previous version", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"releaseDate":"2021-05-13T06:00:00Z"`)
+				So(w.Body.String(), ShouldContainSubstring, `"finalised":true`)
+				So(w.Body.String(), ShouldNotContainSubstring, "provisionalDate")
+				versions, err := store.GetPageVersions(ctx, "/releases/gdp")
+				So(err, ShouldBeNil)
+				So(versions, ShouldHaveLength, 1)
+			})
+
+			Convey("And it is postponed", func() {
+				w := doChange(a, http.MethodPost, "/v1/content-actions/postpone/releases/gdp", `{"release_date":"2021-05-20T06:00:00Z","reason":"Data delayed"}`, "/v1/content/releases/gdp")
+
+				Convey("Then the previous date is recorded with the reason", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Body.String(), ShouldContainSubstring, `"dateChanges":[{"previousDate":"2021-05-13T06:00:00Z","changeNotice":"Data delayed"}]`)
+					w := serve(a, newRequest(nil, http.MethodGet, "/v1/releases?status=postponed", ""))
+					So(w.Body.String(), ShouldContainSubstring, `"total_count":1`)
+				})
+			})
+
+			Convey("And it is confirmed again", func() {
+				w := doChange(a, http.MethodPost, "/v1/content-actions/confirm/releases/gdp", `{}`, "/v1/content/releases/gdp")
+
+				Convey("Then a 409 is returned", func() {
+					So(w.Code, ShouldEqual, http.StatusConflict)
+				})
+			})
+		})
+
+		Convey("When it is postponed without a reason", func() {
+			w := doChange(a, http.MethodPost, "/v1/content-actions/postpone/releases/gdp", `{"release_date":"2021-05-20T06:00:00Z"}`, "/v1/content/releases/gdp")
+
+			Convey("Then a 400 is returned and the release is unchanged", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, "reason is required")
+				versions, err := store.GetPageVersions(ctx, "/releases/gdp")
+				So(err, ShouldBeNil)
+				So(versions, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When it is postponed to an earlier date", func() {
+			w := doChange(a, http.MethodPost, "/v1/content-actions/postpone/releases/gdp", `{"release_date":"2021-05-01T06:00:00Z","reason":"Brought forward"}`, "/v1/content/releases/gdp")
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When it is cancelled", func() {
+			w := doChange(a, http.MethodPost, "/v1/content-actions/cancel/releases/gdp", `{"reason":"Merged into the GDP quarterly release"}`, "/v1/content/releases/gdp")
+
+			Convey("Then the reason is its cancellation notice", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"cancelled":true,"cancellationNotice":["Merged into the GDP quarterly release"]`)
+			})
+
+			Convey("And it is postponed", func() {
+				w := doChange(a, http.MethodPost, "/v1/content-actions/postpone/releases/gdp", `{"release_date":"2021-05-20T06:00:00Z","reason":"Data delayed"}`, "/v1/content/releases/gdp")
+
+				Convey("Then a 409 is returned", func() {
+					So(w.Code, ShouldEqual, http.StatusConflict)
+				})
+			})
+		})
+
+		Convey("When it is cancelled with the ETag of a version that is no longer current", func() {
+			w := doRequestIfMatch(a, http.MethodPost, "/v1/content-actions/cancel/releases/gdp", `{"reason":"Not needed"}`, models.ETag([]byte(`{}`)))
+
+			Convey("Then a 412 is returned and the release is unchanged", func() {
+				So(w.Code, ShouldEqual, http.StatusPreconditionFailed)
+				page, err := store.GetPage(ctx, "/releases/gdp")
+				So(err, ShouldBeNil)
+				So(w.Header().Get("ETag"), ShouldEqual, page.ETag())
+				So(string(page.Data), ShouldNotContainSubstring, `"cancelled":true`)
+			})
+		})
+
+		Convey("When it is cancelled without If-Match", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content-actions/cancel/releases/gdp", `{"reason":"Not needed"}`)
+
+			Convey("Then a 428 is returned with the current ETag, and the release is unchanged", func() {
+				So(w.Code, ShouldEqual, http.StatusPreconditionRequired)
+				page, err := store.GetPage(ctx, "/releases/gdp")
+				So(err, ShouldBeNil)
+				So(w.Header().Get("ETag"), ShouldEqual, page.ETag())
+				So(string(page.Data), ShouldNotContainSubstring, `"cancelled":true`)
+			})
+		})
+
+		Convey("When a page that is not a release is cancelled", func() {
+			w := doChange(a, http.MethodPost, "/v1/content-actions/cancel/economy", `{"reason":"Not needed"}`, "/v1/content/economy")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a viewer cancels the release", func() {
+			w := serve(a, newRequest(viewer, http.MethodPost, "/v1/content-actions/cancel/releases/gdp", `{"reason":"Not needed"}`))
+
+			Convey("Then a 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// readResolveDepth returns the depth of links to resolve, from the resolve and depth query parameters. Zero is
+// returned if references are not to be resolved. The depth defaults to resolving only the links from the page.
+func (api *API) readResolveDepth(req *http.Request) (int, error) {
+	query := req.URL.Query()
+	if query.Get("resolve") == "" {
+		return 0, nil
+	}
+	resolve, err := strconv.ParseBool(query.Get("resolve"))
+	if err != nil {
+		return 0, apierrors.ErrInvalidResolve
+	}
+	if !resolve {
+		return 0, nil
+	}
+
+	if query.Get("depth") == "" {
+		return 1, nil
+	}
+	depth, err := strconv.Atoi(query.Get("depth"))
+	if err != nil || depth < 1 || depth > api.maxResolveDepth {
+		return 0, apierrors.ErrInvalidResolveDepth
+	}
+	return depth, nil
+}
+
+// resolver resolves the links from a page into summaries of the pages they refer to, reading pages from the
+// collection and in the language that the page was requested from. Each page is read at most once, however
+// many times it is referred to.
+type resolver struct {
+	api          *API
+	collectionID string
+	lang         models.Language
+	pages        map[string]*linkedPage
+	logData      log.Data
+}
+
+// linkedPage is a page read by the resolver, or the error from reading it
+type linkedPage struct {
+	page *models.Page
+	err  error
+}
+
+// newResolver returns a resolver for a single request
+func (api *API) newResolver(collectionID string, lang models.Language, logData log.Data) *resolver {
+	return &resolver{
+		api:          api,
+		collectionID: collectionID,
+		lang:         lang,
+		pages:        make(map[string]*linkedPage),
+		logData:      logData,
+	}
+}
+
+// resolvePage returns the JSON of the page with its links resolved to the depth given
+func (r *resolver) resolvePage(ctx context.Context, page *models.Page, depth int) (body []byte, err error) {
+	ctx, span := tracing.Start(ctx, "resolvePage", uriAttribute(page.URI), attribute.Int("content.resolve_depth", depth))
+	defer func() { tracing.End(span, err) }()
+
+	return models.UpdateContent(page.Data, func(content models.Content) error {
+		return r.resolve(ctx, content, depth, map[string]bool{page.URI: true})
+	})
+}
+
+// resolve gives every link from the content a summary of the page it refers to, and resolves the links from that
+// page in turn until the depth is reached. Ancestors are the URIs of the content and of the pages that lead to it,
+// so that a link back to any of them is marked as a cycle instead of being followed. A link to a page that does not
+// exist is given the error, rather than failing the whole page.
+func (r *resolver) resolve(ctx context.Context, content models.Content, depth int, ancestors map[string]bool) error {
+	for _, link := range models.Links(content) {
+		uri := models.CleanURI(link.URI)
+		linked, err := r.read(ctx, uri)
+		switch err {
+		case nil:
+		case apierrors.ErrPageNotFound:
+			link.Resolved = &models.Reference{Error: err.Error()}
+			continue
+		default:
+			return err
+		}
+
+		link.Resolved = models.NewReference(linked)
+		switch {
+		case ancestors[uri]:
+			link.Resolved.Cycle = true
+		case depth > 1:
+			ancestors[uri] = true
+			if err := r.resolve(ctx, linked, depth-1, ancestors); err != nil {
+				return err
+			}
+			delete(ancestors, uri)
+			link.Resolved.Links = models.Links(linked)
+		}
+	}
+	return nil
+}
+
+// lastModified returns the time that the page, or any page read to resolve its links, was last updated
+func (r *resolver) lastModified(pageUpdated time.Time) time.Time {
+	latest := pageUpdated
+	for _, linked := range r.pages {
+		if linked.err == nil && linked.page.LastUpdated.After(latest) {
+			latest = linked.page.LastUpdated
+		}
+	}
+	return latest
+}
+
+// read returns the content of the page at the URI. The page is parsed each time it is read, as the links resolved
+// from it depend on where it is referred to from.
+func (r *resolver) read(ctx context.Context, uri string) (models.Content, error) {
+	linked, ok := r.pages[uri]
+	if !ok {
+		page, _, err := r.api.getPageInLanguage(ctx, r.collectionID, uri, r.lang, r.logData)
+		linked = &linkedPage{page: page, err: err}
+		r.pages[uri] = linked
+	}
+	if linked.err != nil {
+		return nil, linked.err
+	}
+	return models.ParseContent(linked.page.Data)
+}
@@ -0,0 +1,100 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// storeMethodology stores a methodology page at the URI, linking to each of the URIs
+func storeMethodology(store *memory.Store, uri, title string, links ...string) {
+	content := &models.StaticMethodology{PageBase: models.PageBase{Type: models.PageTypeStaticMethodology, URI: uri, Description: models.PageDescription{Title: title}}}
+	for _, link := range links {
+		content.Links = append(content.Links, models.Link{URI: link})
+	}
+	data, err := json.Marshal(content)
+	So(err, ShouldBeNil)
+	So(store.CreatePage(ctx, &models.Page{URI: uri, Type: models.PageTypeStaticMethodology, Data: data}), ShouldBeNil)
+}
+
+func TestResolveReferences(t *testing.T) {
+	Convey("Given pages that link to each other, and to a page that does not exist", t, func() {
+		store := memory.New()
+		storeMethodology(store, "/a", "A", "/b", "/missing")
+		storeMethodology(store, "/b", "B", "/c")
+		storeMethodology(store, "/c", "C", "/a")
+		a := newTestAPI(store, store)
+
+		Convey("When a page is requested with its references resolved", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=true", ""))
+
+			Convey("Then only the links from the page are resolved, reporting the missing page inline", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"type":"static_methodology","uri":"/a","description":{"title":"A"},"links":[`+
+					`{"uri":"/b","resolved":{"type":"static_methodology","title":"B"}},`+
+					`{"uri":"/missing","resolved":{"error":"page not found"}}]}`)
+			})
+		})
+
+		Convey("When a page is requested with its references resolved to a depth that reaches a cycle", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=true&depth=3", ""))
+
+			Convey("Then the links are resolved in turn, and the link back to the page is marked as a cycle", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"type":"static_methodology","uri":"/a","description":{"title":"A"},"links":[`+
+					`{"uri":"/b","resolved":{"type":"static_methodology","title":"B","links":[`+
+					`{"uri":"/c","resolved":{"type":"static_methodology","title":"C","links":[`+
+					`{"uri":"/a","resolved":{"type":"static_methodology","title":"A","cycle":true}}]}}]}},`+
+					`{"uri":"/missing","resolved":{"error":"page not found"}}]}`)
+			})
+		})
+
+		Convey("When a page is requested with its references resolved in Welsh", func() {
+			So(store.CreateTranslation(ctx, models.LanguageWelsh, &models.Page{URI: "/b", Type: models.PageTypeStaticMethodology,
+				Data: json.RawMessage(`{"type":"static_methodology","uri":"/b","description":{"title":"Be","language":"cy"}}`)}), ShouldBeNil)
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=true&lang=cy", ""))
+
+			Convey("Then the titles of translated pages are in Welsh", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `{"uri":"/b","resolved":{"type":"static_methodology","title":"Be"}}`)
+			})
+		})
+
+		Convey("When a page is requested without resolving its references", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=false", ""))
+
+			Convey("Then the page is returned as it is stored", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldNotContainSubstring, "resolved")
+			})
+		})
+
+		Convey("When a page is requested with references resolved beyond the maximum depth", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=true&depth=4", ""))
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page is requested with an invalid depth", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=true&depth=0", ""))
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page is requested with an invalid resolve parameter", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/a?resolve=yes", ""))
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
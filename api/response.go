@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// contentTypeJSON is the content type of every JSON response
+const contentTypeJSON = "application/json; charset=utf-8"
+
+// summariesResponse is the body returned when listing summaries of pages
+type summariesResponse struct {
+	Count int                   `json:"count"`
+	Items []*models.PageSummary `json:"items"`
+}
+
+// writeJSON marshals the provided value and writes it to the response with the provided status code
+func writeJSON(ctx context.Context, w http.ResponseWriter, status int, v interface{}, logData log.Data) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	writeJSONBody(ctx, w, status, body, logData)
+}
+
+// writeJSONBody writes the provided JSON body and status code to the response
+func writeJSONBody(ctx context.Context, w http.ResponseWriter, status int, body []byte, logData log.Data) {
+	writeBody(ctx, w, status, contentTypeJSON, body, logData)
+}
+
+// writeBody writes the provided body, of the content type, and status code to the response
+func writeBody(ctx context.Context, w http.ResponseWriter, status int, contentType string, body []byte, logData log.Data) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		log.Event(ctx, "writing response failed", log.ERROR, log.Error(err), logData)
+	}
+}
+
+// handleError maps the provided error to an HTTP status code and writes it to the response with its code.
+// Internal errors are logged, but only described to the caller as an internal error.
+func handleError(ctx context.Context, w http.ResponseWriter, err error, logData log.Data) {
+	var validationErrs models.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		log.Event(ctx, "request failed validation", log.WARN, log.Error(err), logData)
+		writeValidationErrors(ctx, w, validationErrs, logData)
+		return
+	}
+
+	var status int
+	switch err {
+	case apierrors.ErrPageNotFound,
+		apierrors.ErrCollectionNotFound,
+		apierrors.ErrCollectionItemNotFound,
+		apierrors.ErrVersionNotFound,
+		apierrors.ErrTranslationNotFound,
+		apierrors.ErrRedirectNotFound,
+		apierrors.ErrReleaseNotFound,
+		apierrors.ErrTimeseriesNotFound,
+		apierrors.ErrNotFound:
+		status = http.StatusNotFound
+	case apierrors.ErrPageAlreadyExists,
+		apierrors.ErrCollectionPublished,
+		apierrors.ErrCollectionNotPublishable,
+		apierrors.ErrInvalidItemState,
+		apierrors.ErrTranslationAlreadyExists,
+		apierrors.ErrTranslationTypeMismatch,
+		apierrors.ErrPageHasDrafts,
+		apierrors.ErrTooManyPages,
+		apierrors.ErrRedirectLoop,
+		apierrors.ErrRedirectFromPage,
+		apierrors.ErrReleaseCancelled,
+		apierrors.ErrReleasePublished,
+		apierrors.ErrReleaseConfirmed,
+		apierrors.ErrEditConflict:
+		status = http.StatusConflict
+	case apierrors.ErrInvalidBody,
+		apierrors.ErrCollectionNameRequired,
+		apierrors.ErrInvalidVersion,
+		apierrors.ErrInvalidAuditTime,
+		apierrors.ErrURIRequired,
+		apierrors.ErrInvalidLanguage,
+		apierrors.ErrInvalidTranslationLang,
+		apierrors.ErrInvalidResolve,
+		apierrors.ErrInvalidResolveDepth,
+		apierrors.ErrRedirectToRequired,
+		apierrors.ErrTooManyRedirects,
+		apierrors.ErrDestinationRequired,
+		apierrors.ErrInvalidDestination,
+		apierrors.ErrReleaseDateRequired,
+		apierrors.ErrReleaseDateNotPostponed,
+		apierrors.ErrReasonRequired,
+		apierrors.ErrInvalidReleaseStatus,
+		apierrors.ErrInvalidReleaseDateFilter,
+		apierrors.ErrInvalidOffset,
+		apierrors.ErrInvalidLimit,
+		apierrors.ErrTimeseriesAmbiguous,
+		apierrors.ErrTimeseriesValuesEmpty,
+		apierrors.ErrInvalidDownloadFormat,
+		apierrors.ErrInvalidToken:
+		status = http.StatusBadRequest
+	case apierrors.ErrVersionReadOnly,
+		apierrors.ErrMethodNotAllowed:
+		status = http.StatusMethodNotAllowed
+	case apierrors.ErrPreconditionFailed:
+		status = http.StatusPreconditionFailed
+	case apierrors.ErrPreconditionRequired:
+		status = http.StatusPreconditionRequired
+	case apierrors.ErrUnauthorised:
+		status = http.StatusUnauthorized
+	case apierrors.ErrForbidden:
+		status = http.StatusForbidden
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	// errors made by the caller are expected, so only errors in the service are logged at ERROR
+	severity := log.WARN
+	if status >= http.StatusInternalServerError {
+		severity = log.ERROR
+	}
+	log.Event(ctx, "request unsuccessful", severity, log.Error(err), logData)
+	writeError(ctx, w, status, apierrors.NewResponse(err), logData)
+}
+
+// writeError writes the error response with the status code
+func writeError(ctx context.Context, w http.ResponseWriter, status int, resp apierrors.Response, logData log.Data) {
+	if err := resp.Write(w, status); err != nil {
+		log.Event(ctx, "writing error response failed", log.ERROR, log.Error(err), logData)
+	}
+}
+
+// writeValidationErrors writes a 400 response listing every validation failure
+func writeValidationErrors(ctx context.Context, w http.ResponseWriter, errs models.ValidationErrors, logData log.Data) {
+	resp := apierrors.Response{Errors: make([]apierrors.ErrorItem, len(errs))}
+	for i, e := range errs {
+		resp.Errors[i] = apierrors.ErrorItem{Code: apierrors.ValidationFailedCode, Description: e.Description, Field: e.Field}
+	}
+	writeError(ctx, w, http.StatusBadRequest, resp, logData)
+}
+
+// notFoundHandler returns a 404 for requests that do not match any route
+func notFoundHandler(w http.ResponseWriter, req *http.Request) {
+	handleError(req.Context(), w, apierrors.ErrNotFound, log.Data{"path": req.URL.Path})
+}
+
+// methodNotAllowedHandler returns a 405 for requests to a route that does not support their method
+func methodNotAllowedHandler(w http.ResponseWriter, req *http.Request) {
+	handleError(req.Context(), w, apierrors.ErrMethodNotAllowed, log.Data{"path": req.URL.Path, "method": req.Method})
+}
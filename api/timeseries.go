@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/download"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// getTimeseriesDataHandler returns the download of the timeseries with the CDID in the requested format. Downloads
+// are cached until the timeseries is republished.
+func (api *API) getTimeseriesDataHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cdid, datasetID := timeseriesID(req)
+	logData := log.Data{"cdid": cdid, "dataset_id": datasetID}
+
+	format, err := download.ParseFormat(req.URL.Query().Get("format"))
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	logData["format"] = format
+
+	page, err := api.contentStore.GetTimeseries(ctx, cdid, datasetID)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	logData["uri"] = page.URI
+
+	timeseries, err := models.NewTimeseriesDownload(page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	body, ok := api.downloads.Get(page.URI, format, page.LastUpdated)
+	metrics.ObserveCache("downloads", ok)
+	if !ok {
+		_, span := tracing.Start(ctx, "download.Render", uriAttribute(page.URI), attribute.String("download.format", string(format)))
+		body, err = download.Render(timeseries, format)
+		tracing.End(span, err)
+		if err != nil {
+			handleError(ctx, w, err, logData)
+			return
+		}
+		api.downloads.Add(page.URI, format, page.LastUpdated, body)
+	}
+
+	if format != download.FormatJSON {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", download.Filename(timeseries, format)))
+	}
+	policy := cachePolicy{pageType: page.Type, lastModified: page.LastUpdated}
+	api.writeCacheable(w, req, format.ContentType(), body, policy, logData)
+}
+
+// patchTimeseriesDataHandler adds the observations in the request body to the timeseries with the CDID, replacing
+// any it has for the same dates, without the rest of the page being sent or stored again
+func (api *API) patchTimeseriesDataHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cdid, datasetID := timeseriesID(req)
+	logData := log.Data{"cdid": cdid, "dataset_id": datasetID}
+
+	var values models.TimeseriesValues
+	if err := json.NewDecoder(req.Body).Decode(&values); err != nil {
+		handleError(ctx, w, apierrors.ErrInvalidBody, logData)
+		return
+	}
+	if values.IsEmpty() {
+		handleError(ctx, w, apierrors.ErrTimeseriesValuesEmpty, logData)
+		return
+	}
+
+	previous, err := api.contentStore.GetTimeseries(ctx, cdid, datasetID)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+	logData["uri"] = previous.URI
+
+	getCurrent := func() (*models.Page, error) { return api.contentStore.GetTimeseries(ctx, cdid, datasetID) }
+	if err := checkIfMatch(req, previous, true); err != nil {
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	page, err := models.UpdateTimeseries(previous, values, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	record, err := api.audit(ctx, models.AuditActionUpdate, page.URI, "", previous, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// the observations are only stored if the page is still the version they were added to, so that two updates
+	// made at once cannot lose each other's observations
+	if err := api.contentStore.UpdateTimeseriesValues(ctx, page, previous.ETag()); err != nil {
+		api.auditFailed(ctx, record)
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+	api.downloads.Invalidate(page.URI)
+
+	log.Event(ctx, "timeseries values updated", log.INFO, logData)
+	api.sendContentPublished(ctx, &event.ContentPublished{URI: page.URI, Type: page.Type, Lang: models.LanguageEnglish, Timestamp: page.LastUpdated}, logData)
+	writePage(ctx, w, http.StatusOK, page, logData)
+}
+
+// timeseriesID returns the CDID of the timeseries requested and the ID of its source dataset, if one was given.
+// Both are upper case, as they are in timeseries pages.
+func timeseriesID(req *http.Request) (cdid, datasetID string) {
+	return strings.ToUpper(mux.Vars(req)["cdid"]), strings.ToUpper(req.URL.Query().Get("dataset"))
+}
@@ -0,0 +1,153 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/api/mock"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func storeTimeseries(store *memory.Store, uri, data string) {
+	So(store.CreatePage(ctx, &models.Page{URI: uri, Type: models.PageTypeTimeseries, Data: json.RawMessage(data)}), ShouldBeNil)
+}
+
+func TestTimeseriesData(t *testing.T) {
+	Convey("Given a published timeseries", t, func() {
+		store := memory.New()
+		storeTimeseries(store, "/economy/inflation/timeseries/d7g7/mm23", `{"type":"timeseries","description":{"title":"CPI annual rate","cdid":"D7G7","datasetId":"MM23","unit":"%","releaseDate":"2021-03-17T07:00:00Z"},"months":[{"date":"2021 FEB","value":"0.4"}]}`)
+		a := newTestAPI(store, store)
+
+		Convey("When anyone downloads its data as CSV by its CDID", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=csv", ""))
+
+			Convey("Then a CSV file is returned with the metadata and observations", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+				So(w.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="d7g7-mm23.csv"`)
+				So(w.Body.String(), ShouldStartWith, "Title,CPI annual rate\nCDID,D7G7\n")
+				So(w.Body.String(), ShouldEndWith, "\n2021 FEB,0.4\n")
+			})
+
+			Convey("And a publisher adds an observation", func() {
+				w := doChange(a, http.MethodPatch, "/v1/timeseries/D7G7/data?dataset=mm23", `{"months":[{"date":"2021 MAR","value":"0.7"}]}`, "/v1/content/economy/inflation/timeseries/d7g7/mm23")
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"months":[{"date":"2021 FEB","value":"0.4"},{"date":"2021 MAR","value":"0.7"}]`)
+
+				Convey("Then the download is generated again with the new observation", func() {
+					w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=csv", ""))
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Body.String(), ShouldEndWith, "\n2021 FEB,0.4\n2021 MAR,0.7\n")
+				})
+			})
+
+			Convey("And the timeseries is republished", func() {
+				w := doReplace(a, "/v1/content/economy/inflation/timeseries/d7g7/mm23", `{"type":"timeseries","description":{"title":"CPI annual rate","cdid":"D7G7","datasetId":"MM23"},"months":[{"date":"2021 FEB","value":"0.5"}]}`)
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				Convey("Then the cached download is not returned", func() {
+					w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=csv", ""))
+					So(w.Body.String(), ShouldEndWith, "\n2021 FEB,0.5\n")
+				})
+			})
+		})
+
+		Convey("When its data is downloaded as XLSX", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=xlsx", ""))
+
+			Convey("Then a workbook is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+				So(w.Body.String(), ShouldStartWith, "PK")
+			})
+		})
+
+		Convey("When its data is requested without a format", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data", ""))
+
+			Convey("Then it is returned as JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Disposition"), ShouldBeEmpty)
+				So(w.Body.String(), ShouldContainSubstring, `"months":[{"date":"2021 FEB","value":"0.4"}]`)
+			})
+		})
+
+		Convey("When its data is requested in an unsupported format", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/d7g7/data?format=xls", ""))
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the data of a CDID that is not published is requested", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/timeseries/l55o/data", ""))
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When no observations are sent to be added", func() {
+			w := doRequest(a, http.MethodPatch, "/v1/timeseries/d7g7/data", `{}`)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When an observation is added without If-Match", func() {
+			w := doRequest(a, http.MethodPatch, "/v1/timeseries/d7g7/data", `{"months":[{"date":"2021 MAR","value":"0.7"}]}`)
+
+			Convey("Then a 428 is returned with the ETag of the timeseries", func() {
+				So(w.Code, ShouldEqual, http.StatusPreconditionRequired)
+				page, err := store.GetPage(ctx, "/economy/inflation/timeseries/d7g7/mm23")
+				So(err, ShouldBeNil)
+				So(w.Header().Get("ETag"), ShouldEqual, page.ETag())
+			})
+		})
+
+		Convey("When a viewer adds an observation", func() {
+			w := serve(a, newRequest(viewer, http.MethodPatch, "/v1/timeseries/d7g7/data", `{"months":[{"date":"2021 MAR","value":"0.7"}]}`))
+
+			Convey("Then a 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+}
+
+func TestTimeseriesDataConflict(t *testing.T) {
+	Convey("Given a timeseries that another request adds an observation to while a publisher is adding one", t, func() {
+		store := memory.New()
+		uri := "/economy/inflation/timeseries/d7g7/mm23"
+		storeTimeseries(store, uri, `{"type":"timeseries","description":{"title":"CPI annual rate","cdid":"D7G7"},"months":[{"date":"2021 FEB","value":"0.4"}]}`)
+		other := `{"type":"timeseries","description":{"title":"CPI annual rate","cdid":"D7G7"},"months":[{"date":"2021 FEB","value":"0.4"},{"date":"2021 APR","value":"1.5"}]}`
+		contentStore := &mock.ContentStoreMock{
+			GetTimeseriesFunc: store.GetTimeseries,
+			UpdateTimeseriesValuesFunc: func(ctx context.Context, page *models.Page, etag string) error {
+				So(store.UpdateTimeseriesValues(ctx, &models.Page{URI: uri, Type: models.PageTypeTimeseries, Data: json.RawMessage(other)}, etag), ShouldBeNil)
+				return store.UpdateTimeseriesValues(ctx, page, etag)
+			},
+		}
+		a := newTestAPI(contentStore, store)
+
+		Convey("When the publisher's observation is added", func() {
+			previous, err := store.GetPage(ctx, uri)
+			So(err, ShouldBeNil)
+			w := doRequestIfMatch(a, http.MethodPatch, "/v1/timeseries/d7g7/data", `{"months":[{"date":"2021 MAR","value":"0.7"}]}`, previous.ETag())
+
+			Convey("Then it is refused with the ETag of the timeseries with the other observation, which is kept", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				page, err := store.GetPage(ctx, uri)
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, other)
+				So(w.Header().Get("ETag"), ShouldEqual, page.ETag())
+			})
+		})
+	})
+}
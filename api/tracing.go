@@ -0,0 +1,268 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedContentStore records a span for every operation on the content store, so that the time spent in the store
+// can be told apart from the rest of a request
+type tracedContentStore struct {
+	store ContentStore
+}
+
+// uriAttribute is the attribute that spans are given for the URI of the page they operate on
+func uriAttribute(uri string) attribute.KeyValue {
+	return attribute.String("content.uri", uri)
+}
+
+// langAttribute is the attribute that spans are given for the language of the translation they operate on
+func langAttribute(lang models.Language) attribute.KeyValue {
+	return attribute.String("content.lang", string(lang))
+}
+
+func (s tracedContentStore) GetPage(ctx context.Context, uri string) (page *models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetPage", uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetPage(ctx, uri)
+}
+
+func (s tracedContentStore) CreatePage(ctx context.Context, page *models.Page) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.CreatePage", uriAttribute(page.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.CreatePage(ctx, page)
+}
+
+func (s tracedContentStore) UpsertPage(ctx context.Context, page *models.Page) (created bool, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.UpsertPage", uriAttribute(page.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpsertPage(ctx, page)
+}
+
+func (s tracedContentStore) ReplacePage(ctx context.Context, page *models.Page, etag string) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.ReplacePage", uriAttribute(page.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.ReplacePage(ctx, page, etag)
+}
+
+func (s tracedContentStore) DeletePage(ctx context.Context, uri string) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.DeletePage", uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.DeletePage(ctx, uri)
+}
+
+func (s tracedContentStore) GetPageVersions(ctx context.Context, uri string) (versions []*models.PageVersion, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetPageVersions", uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetPageVersions(ctx, uri)
+}
+
+func (s tracedContentStore) GetPageVersion(ctx context.Context, uri string, version int) (pageVersion *models.PageVersion, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetPageVersion", uriAttribute(uri), attribute.Int("content.version", version))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetPageVersion(ctx, uri, version)
+}
+
+func (s tracedContentStore) GetTranslation(ctx context.Context, uri string, lang models.Language) (page *models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetTranslation", uriAttribute(uri), langAttribute(lang))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetTranslation(ctx, uri, lang)
+}
+
+func (s tracedContentStore) CreateTranslation(ctx context.Context, lang models.Language, page *models.Page) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.CreateTranslation", uriAttribute(page.URI), langAttribute(lang))
+	defer func() { tracing.End(span, err) }()
+	return s.store.CreateTranslation(ctx, lang, page)
+}
+
+func (s tracedContentStore) UpsertTranslation(ctx context.Context, lang models.Language, page *models.Page) (created bool, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.UpsertTranslation", uriAttribute(page.URI), langAttribute(lang))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpsertTranslation(ctx, lang, page)
+}
+
+func (s tracedContentStore) ReplaceTranslation(ctx context.Context, lang models.Language, page *models.Page, etag string) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.ReplaceTranslation", uriAttribute(page.URI), langAttribute(lang))
+	defer func() { tracing.End(span, err) }()
+	return s.store.ReplaceTranslation(ctx, lang, page, etag)
+}
+
+func (s tracedContentStore) DeleteTranslation(ctx context.Context, uri string, lang models.Language) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.DeleteTranslation", uriAttribute(uri), langAttribute(lang))
+	defer func() { tracing.End(span, err) }()
+	return s.store.DeleteTranslation(ctx, uri, lang)
+}
+
+func (s tracedContentStore) GetUntranslatedPages(ctx context.Context, lang models.Language) (pages []*models.PageSummary, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetUntranslatedPages", langAttribute(lang))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetUntranslatedPages(ctx, lang)
+}
+
+func (s tracedContentStore) GetBreadcrumb(ctx context.Context, uri string) (breadcrumb []*models.PageSummary, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetBreadcrumb", uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetBreadcrumb(ctx, uri)
+}
+
+func (s tracedContentStore) GetChildren(ctx context.Context, uri string) (children []*models.PageSummary, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetChildren", uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetChildren(ctx, uri)
+}
+
+func (s tracedContentStore) GetSubtree(ctx context.Context, uri string) (pages []*models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetSubtree", uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetSubtree(ctx, uri)
+}
+
+func (s tracedContentStore) MovePages(ctx context.Context, from, to string, movedAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.MovePages", uriAttribute(from), attribute.String("content.destination", to))
+	defer func() { tracing.End(span, err) }()
+	return s.store.MovePages(ctx, from, to, movedAt)
+}
+
+func (s tracedContentStore) GetReleases(ctx context.Context, filter models.ReleaseFilter) (releases []*models.ReleaseSummary, total int, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetReleases")
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetReleases(ctx, filter)
+}
+
+func (s tracedContentStore) GetTimeseries(ctx context.Context, cdid, datasetID string) (page *models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetTimeseries", attribute.String("timeseries.cdid", cdid), attribute.String("timeseries.dataset_id", datasetID))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetTimeseries(ctx, cdid, datasetID)
+}
+
+func (s tracedContentStore) UpdateTimeseriesValues(ctx context.Context, page *models.Page, etag string) (err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.UpdateTimeseriesValues", uriAttribute(page.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpdateTimeseriesValues(ctx, page, etag)
+}
+
+// tracedCollectionStore records a span for every operation on the store of collections and their drafts
+type tracedCollectionStore struct {
+	store CollectionStore
+}
+
+// collectionAttribute is the attribute that spans are given for the ID of the collection they operate on
+func collectionAttribute(id string) attribute.KeyValue {
+	return attribute.String("collection.id", id)
+}
+
+func (s tracedCollectionStore) CreateCollection(ctx context.Context, collection *models.Collection) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.CreateCollection", collectionAttribute(collection.ID))
+	defer func() { tracing.End(span, err) }()
+	return s.store.CreateCollection(ctx, collection)
+}
+
+func (s tracedCollectionStore) GetCollection(ctx context.Context, id string) (collection *models.Collection, err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.GetCollection", collectionAttribute(id))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetCollection(ctx, id)
+}
+
+func (s tracedCollectionStore) GetCollections(ctx context.Context) (collections []*models.Collection, err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.GetCollections")
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetCollections(ctx)
+}
+
+func (s tracedCollectionStore) DeleteCollection(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.DeleteCollection", collectionAttribute(id))
+	defer func() { tracing.End(span, err) }()
+	return s.store.DeleteCollection(ctx, id)
+}
+
+func (s tracedCollectionStore) GetDraftPage(ctx context.Context, collectionID, uri string) (page *models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.GetDraftPage", collectionAttribute(collectionID), uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetDraftPage(ctx, collectionID, uri)
+}
+
+func (s tracedCollectionStore) UpsertDraftPage(ctx context.Context, collectionID string, page *models.Page) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.UpsertDraftPage", collectionAttribute(collectionID), uriAttribute(page.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpsertDraftPage(ctx, collectionID, page)
+}
+
+func (s tracedCollectionStore) ReplaceDraftPage(ctx context.Context, collectionID string, page *models.Page, etag string) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.ReplaceDraftPage", collectionAttribute(collectionID), uriAttribute(page.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.ReplaceDraftPage(ctx, collectionID, page, etag)
+}
+
+func (s tracedCollectionStore) DeleteDraftPage(ctx context.Context, collectionID, uri string) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.DeleteDraftPage", collectionAttribute(collectionID), uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.DeleteDraftPage(ctx, collectionID, uri)
+}
+
+func (s tracedCollectionStore) UpdateItemState(ctx context.Context, collectionID, uri string, state models.ItemState) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.UpdateItemState", collectionAttribute(collectionID), uriAttribute(uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpdateItemState(ctx, collectionID, uri, state)
+}
+
+func (s tracedCollectionStore) PublishCollection(ctx context.Context, collectionID string, publishedAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.PublishCollection", collectionAttribute(collectionID))
+	defer func() { tracing.End(span, err) }()
+	return s.store.PublishCollection(ctx, collectionID, publishedAt)
+}
+
+// tracedAuditStore records a span for every operation on the store of audit records
+type tracedAuditStore struct {
+	store AuditStore
+}
+
+func (s tracedAuditStore) AddAuditRecord(ctx context.Context, record *models.AuditRecord) (err error) {
+	ctx, span := tracing.Start(ctx, "AuditStore.AddAuditRecord", uriAttribute(record.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.AddAuditRecord(ctx, record)
+}
+
+func (s tracedAuditStore) GetAuditRecords(ctx context.Context, filter models.AuditFilter) (records []*models.AuditRecord, total int, err error) {
+	ctx, span := tracing.Start(ctx, "AuditStore.GetAuditRecords")
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetAuditRecords(ctx, filter)
+}
+
+// tracedRedirectStore records a span for every operation on the store of redirects
+type tracedRedirectStore struct {
+	store RedirectStore
+}
+
+func (s tracedRedirectStore) GetRedirect(ctx context.Context, from string) (redirect *models.Redirect, err error) {
+	ctx, span := tracing.Start(ctx, "RedirectStore.GetRedirect", uriAttribute(from))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetRedirect(ctx, from)
+}
+
+func (s tracedRedirectStore) GetRedirects(ctx context.Context) (redirects []*models.Redirect, err error) {
+	ctx, span := tracing.Start(ctx, "RedirectStore.GetRedirects")
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetRedirects(ctx)
+}
+
+func (s tracedRedirectStore) UpsertRedirect(ctx context.Context, redirect *models.Redirect) (created bool, err error) {
+	ctx, span := tracing.Start(ctx, "RedirectStore.UpsertRedirect", uriAttribute(redirect.From))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpsertRedirect(ctx, redirect)
+}
+
+func (s tracedRedirectStore) UpsertRedirects(ctx context.Context, redirects []*models.Redirect) (err error) {
+	ctx, span := tracing.Start(ctx, "RedirectStore.UpsertRedirects", attribute.Int("redirect.count", len(redirects)))
+	defer func() { tracing.End(span, err) }()
+	return s.store.UpsertRedirects(ctx, redirects)
+}
+
+func (s tracedRedirectStore) DeleteRedirect(ctx context.Context, from string) (err error) {
+	ctx, span := tracing.Start(ctx, "RedirectStore.DeleteRedirect", uriAttribute(from))
+	defer func() { tracing.End(span, err) }()
+	return s.store.DeleteRedirect(ctx, from)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// headerLanguageFallback is set on responses that contain the English page because it has not been translated
+// into the language requested
+const headerLanguageFallback = "X-Language-Fallback"
+
+// getUntranslatedHandler returns a summary of every page that has not been translated into the language given by
+// the lang query parameter, which defaults to Welsh
+func (api *API) getUntranslatedHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	lang := models.LanguageWelsh
+	if v := req.URL.Query().Get("lang"); v != "" {
+		lang = models.Language(v)
+	}
+	logData := log.Data{"lang": lang}
+
+	if !lang.IsTranslation() {
+		handleError(ctx, w, apierrors.ErrInvalidTranslationLang, logData)
+		return
+	}
+
+	pages, err := api.contentStore.GetUntranslatedPages(ctx, lang)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, summariesResponse{Count: len(pages), Items: pages}, logData)
+}
+
+// readLanguage returns the language that a page is requested in, from the lang query parameter if it is given or
+// otherwise from the Accept-Language header
+func readLanguage(req *http.Request) (models.Language, error) {
+	if _, ok := req.URL.Query()["lang"]; ok {
+		return readLanguageParam(req)
+	}
+	return models.PreferredLanguage(req.Header.Get("Accept-Language")), nil
+}
+
+// readLanguageParam returns the language given by the lang query parameter, which defaults to English. Changes to
+// content are only ever made in the language given by the parameter, so that a browser's Accept-Language header
+// cannot cause a translation to be written instead of the English page.
+func readLanguageParam(req *http.Request) (models.Language, error) {
+	lang := models.Language(req.URL.Query().Get("lang"))
+	if lang == "" {
+		return models.LanguageEnglish, nil
+	}
+	if !lang.IsValid() {
+		return "", apierrors.ErrInvalidLanguage
+	}
+	return lang, nil
+}
+
+// getPageInLanguage returns the translation of the page into the language, falling back to the page (or its draft
+// in the collection) in English if it has not been translated. The language of the page returned is returned with it.
+// Drafts are only ever in English.
+func (api *API) getPageInLanguage(ctx context.Context, collectionID, uri string, lang models.Language, logData log.Data) (*models.Page, models.Language, error) {
+	if lang.IsTranslation() {
+		logData["lang"] = lang
+		if collectionID != "" {
+			if err := auth.Check(ctx, auth.PermissionRead); err != nil {
+				return nil, "", err
+			}
+		}
+
+		page, err := api.contentStore.GetTranslation(ctx, uri, lang)
+		if err != apierrors.ErrTranslationNotFound {
+			return page, lang, err
+		}
+		log.Event(ctx, "page has not been translated, falling back to english", log.INFO, logData)
+	}
+
+	page, err := api.getPageInCollection(ctx, collectionID, uri, logData)
+	return page, models.LanguageEnglish, err
+}
+
+// setLanguageHeaders sets the language of the page returned on the response, indicating if it is not the
+// language that was requested
+func setLanguageHeaders(w http.ResponseWriter, requested, returned models.Language) {
+	w.Header().Set("Content-Language", string(returned))
+	if returned != requested {
+		w.Header().Set(headerLanguageFallback, "true")
+	}
+}
+
+// putTranslation creates or replaces the translation of the page at the URI into the language
+func (api *API) putTranslation(w http.ResponseWriter, req *http.Request, uri string, lang models.Language) {
+	ctx := req.Context()
+	logData := log.Data{"uri": uri, "lang": lang}
+
+	page, err := api.readTranslation(ctx, req, uri, lang)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	previous, err := api.contentStore.GetTranslation(ctx, uri, lang)
+	if err != nil && err != apierrors.ErrTranslationNotFound {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	getCurrent := func() (*models.Page, error) { return api.contentStore.GetTranslation(ctx, uri, lang) }
+	if err := checkIfMatch(req, previous, true); err != nil {
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	action := models.AuditActionUpdate
+	if previous == nil {
+		action = models.AuditActionCreate
+	}
+	record, err := api.auditTranslation(ctx, action, uri, lang, previous, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	created := previous == nil
+	if created {
+		err = api.contentStore.CreateTranslation(ctx, lang, page)
+	} else {
+		err = api.contentStore.ReplaceTranslation(ctx, lang, page, previous.ETag())
+	}
+	if err != nil {
+		api.auditFailed(ctx, record)
+		handleEditError(ctx, w, err, getCurrent, logData)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	log.Event(ctx, "translation stored", log.INFO, log.Data{"uri": uri, "lang": lang, "created": created})
+	api.sendContentPublished(ctx, &event.ContentPublished{URI: uri, Type: page.Type, Lang: lang, Timestamp: page.LastUpdated}, logData)
+	setLanguageHeaders(w, lang, lang)
+	writePage(ctx, w, status, page, logData)
+}
+
+// postTranslation creates a new translation of the page at the URI into the language, failing if one already exists
+func (api *API) postTranslation(w http.ResponseWriter, req *http.Request, uri string, lang models.Language) {
+	ctx := req.Context()
+	logData := log.Data{"uri": uri, "lang": lang}
+
+	page, err := api.readTranslation(ctx, req, uri, lang)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	// check the translation does not exist before auditing its creation, as the audit record must be made first
+	if _, err := api.contentStore.GetTranslation(ctx, uri, lang); err != apierrors.ErrTranslationNotFound {
+		if err == nil {
+			err = apierrors.ErrTranslationAlreadyExists
+		}
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	record, err := api.auditTranslation(ctx, models.AuditActionCreate, uri, lang, nil, page)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.contentStore.CreateTranslation(ctx, lang, page); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "translation created", log.INFO, logData)
+	api.sendContentPublished(ctx, &event.ContentPublished{URI: uri, Type: page.Type, Lang: lang, Timestamp: page.LastUpdated}, logData)
+	setLanguageHeaders(w, lang, lang)
+	writePage(ctx, w, http.StatusCreated, page, logData)
+}
+
+// deleteTranslation removes the translation of the page at the URI into the language, leaving the English page
+func (api *API) deleteTranslation(w http.ResponseWriter, req *http.Request, uri string, lang models.Language) {
+	ctx := req.Context()
+	logData := log.Data{"uri": uri, "lang": lang}
+
+	if models.IsVersionURI(uri) {
+		handleError(ctx, w, apierrors.ErrVersionReadOnly, logData)
+		return
+	}
+
+	page, err := api.contentStore.GetTranslation(ctx, uri, lang)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := checkIfMatch(req, page, false); err != nil {
+		handleEditError(ctx, w, err, func() (*models.Page, error) { return page, nil }, logData)
+		return
+	}
+
+	record, err := api.auditTranslation(ctx, models.AuditActionDelete, uri, lang, page, nil)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	if err := api.contentStore.DeleteTranslation(ctx, uri, lang); err != nil {
+		api.auditFailed(ctx, record)
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	log.Event(ctx, "translation deleted", log.INFO, logData)
+	api.sendContentDeleted(ctx, &event.ContentDeleted{URI: uri, Type: page.Type, Lang: lang, Timestamp: time.Now().UTC()}, logData)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readTranslation reads the request body into a translation of the page at the URI. The English page must
+// exist, and the translation must be of the same type.
+func (api *API) readTranslation(ctx context.Context, req *http.Request, uri string, lang models.Language) (*models.Page, error) {
+	body, err := readPageBody(req, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := models.NewTranslation(uri, lang, body, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	english, err := api.contentStore.GetPage(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if english.Type != page.Type {
+		return nil, apierrors.ErrTranslationTypeMismatch
+	}
+	return page, nil
+}
@@ -0,0 +1,261 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const welshPageBody = `{"type":"static_page","description":{"title":"Amdanom ni"}}`
+
+func TestGetTranslatedContent(t *testing.T) {
+	Convey("Given a page that has been translated into Welsh, and one that has not", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/aboutus", Type: models.PageTypeStaticPage, Data: json.RawMessage(testPageBody)}), ShouldBeNil)
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Type: models.PageTypeStaticPage, Data: json.RawMessage(testPageBody)}), ShouldBeNil)
+		So(store.CreateTranslation(ctx, models.LanguageWelsh, &models.Page{URI: "/aboutus", Type: models.PageTypeStaticPage, Data: json.RawMessage(welshPageBody)}), ShouldBeNil)
+		a := newTestAPI(store, store)
+
+		Convey("When the translated page is requested in Welsh", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/aboutus?lang=cy", ""))
+
+			Convey("Then the Welsh page is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, welshPageBody)
+				So(w.Header().Get("Content-Language"), ShouldEqual, "cy")
+				So(w.Header().Get("X-Language-Fallback"), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the translated page is requested with an Accept-Language header preferring Welsh", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/aboutus", "")
+			req.Header.Set("Accept-Language", "cy-GB,cy;q=0.9,en;q=0.8")
+			w := serve(a, req)
+
+			Convey("Then the Welsh page is returned, varying by the header", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, welshPageBody)
+				So(w.Header().Get("Vary"), ShouldEqual, "Accept-Language")
+			})
+		})
+
+		Convey("When the translated page is requested in English, overriding an Accept-Language header", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/aboutus?lang=en", "")
+			req.Header.Set("Accept-Language", "cy")
+			w := serve(a, req)
+
+			Convey("Then the English page is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, testPageBody)
+				So(w.Header().Get("Content-Language"), ShouldEqual, "en")
+			})
+		})
+
+		Convey("When the untranslated page is requested in Welsh", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/economy?lang=cy", ""))
+
+			Convey("Then the English page is returned, indicating the fallback", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, testPageBody)
+				So(w.Header().Get("Content-Language"), ShouldEqual, "en")
+				So(w.Header().Get("X-Language-Fallback"), ShouldEqual, "true")
+			})
+		})
+
+		Convey("When a page is requested in a language that pages are not written in", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/content/aboutus?lang=fr", ""))
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the Welsh page is requested from a collection without authenticating", func() {
+			req := newRequest(nil, http.MethodGet, "/v1/content/aboutus?lang=cy", "")
+			req.Header.Set("Collection-Id", "123")
+			w := serve(a, req)
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
+
+func TestWriteTranslations(t *testing.T) {
+	Convey("Given a published English page", t, func() {
+		store := memory.New()
+		events := newEventProducerMock()
+		a := api.Setup(ctx, newTestConfig(), mux.NewRouter(), store, store, store, store, newSchedulerMock(), events)
+		So(doRequest(a, http.MethodPut, "/v1/content/aboutus", testPageBody).Code, ShouldEqual, http.StatusCreated)
+
+		Convey("When a publisher PUTs its Welsh translation", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/aboutus?lang=cy", welshPageBody)
+
+			Convey("Then the translation is stored in Welsh, leaving the English page as it is", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(w.Header().Get("Content-Language"), ShouldEqual, "cy")
+				translation, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(string(translation.Data), ShouldEqual, `{"type":"static_page","uri":"/aboutus","description":{"title":"Amdanom ni","language":"cy"}}`)
+
+				page, err := store.GetPage(ctx, "/aboutus")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldContainSubstring, "About us")
+			})
+
+			Convey("Then its creation is audited in Welsh", func() {
+				records := readAuditRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[0].Action, ShouldEqual, models.AuditActionCreate)
+				So(records[0].Lang, ShouldEqual, models.LanguageWelsh)
+				So(records[1].Lang, ShouldBeEmpty)
+			})
+
+			Convey("Then a content published event is sent for the translation", func() {
+				So(events.ContentPublishedCalls(), ShouldHaveLength, 2)
+				So(events.ContentPublishedCalls()[0].E.Lang, ShouldEqual, models.LanguageEnglish)
+				So(events.ContentPublishedCalls()[1].E.Lang, ShouldEqual, models.LanguageWelsh)
+			})
+
+			Convey("And it is PUT again", func() {
+				w := doReplace(a, "/v1/content/aboutus?lang=cy", welshPageBody)
+
+				Convey("Then the translation is updated", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(readAuditRecords(store)[0].Action, ShouldEqual, models.AuditActionUpdate)
+				})
+			})
+
+			Convey("And it is POSTed", func() {
+				w := doRequest(a, http.MethodPost, "/v1/content/aboutus?lang=cy", welshPageBody)
+
+				Convey("Then a 409 is returned", func() {
+					So(w.Code, ShouldEqual, http.StatusConflict)
+				})
+			})
+
+			Convey("And the translation is deleted", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/content/aboutus?lang=cy", "")
+
+				Convey("Then only the translation is removed, and its deletion is audited and sent", func() {
+					So(w.Code, ShouldEqual, http.StatusNoContent)
+					_, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+					So(err, ShouldNotBeNil)
+					_, err = store.GetPage(ctx, "/aboutus")
+					So(err, ShouldBeNil)
+					So(readAuditRecords(store)[0].Action, ShouldEqual, models.AuditActionDelete)
+					So(events.ContentDeletedCalls()[0].E.Lang, ShouldEqual, models.LanguageWelsh)
+				})
+			})
+
+			Convey("And the English page is deleted", func() {
+				w := doRequest(a, http.MethodDelete, "/v1/content/aboutus", "")
+
+				Convey("Then the translation is deleted with it", func() {
+					So(w.Code, ShouldEqual, http.StatusNoContent)
+					_, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+					So(err, ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("When a Welsh translation is POSTed", func() {
+			w := doRequest(a, http.MethodPost, "/v1/content/aboutus?lang=cy", welshPageBody)
+
+			Convey("Then it is created", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				_, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When a Welsh translation of a different type is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/aboutus?lang=cy", `{"type":"static_methodology","description":{"title":"Amdanom ni"}}`)
+
+			Convey("Then a 409 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+
+		Convey("When a Welsh translation of a page that does not exist is PUT", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy?lang=cy", welshPageBody)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a translation that does not exist is deleted", func() {
+			w := doRequest(a, http.MethodDelete, "/v1/content/aboutus?lang=cy", "")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a page is PUT in a language that pages are not written in", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/aboutus?lang=fr", welshPageBody)
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a page is PUT with an Accept-Language header preferring Welsh", func() {
+			english, err := store.GetPage(ctx, "/aboutus")
+			So(err, ShouldBeNil)
+			req := newRequest(publisher, http.MethodPut, "/v1/content/aboutus", `{"type":"static_page","description":{"title":"About the ONS"}}`)
+			req.Header.Set("Accept-Language", "cy")
+			req.Header.Set("If-Match", english.ETag())
+			w := serve(a, req)
+
+			Convey("Then the English page is updated, as changes are only made in the language given by lang", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				_, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestGetUntranslated(t *testing.T) {
+	Convey("Given two pages, one of which has been translated into Welsh", t, func() {
+		store := memory.New()
+		So(store.CreatePage(ctx, &models.Page{URI: "/aboutus", Type: models.PageTypeStaticPage, Data: json.RawMessage(testPageBody)}), ShouldBeNil)
+		So(store.CreatePage(ctx, &models.Page{URI: "/economy", Type: models.PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`)}), ShouldBeNil)
+		So(store.CreateTranslation(ctx, models.LanguageWelsh, &models.Page{URI: "/aboutus", Type: models.PageTypeStaticPage, Data: json.RawMessage(welshPageBody)}), ShouldBeNil)
+		a := newTestAPI(store, store)
+
+		Convey("When a viewer lists the pages that have not been translated", func() {
+			w := serve(a, newRequest(viewer, http.MethodGet, "/v1/translations/missing", ""))
+
+			Convey("Then the page without a Welsh translation is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"count":1,"items":[{"uri":"/economy","type":"static_page","title":"Economy"}]}`)
+			})
+		})
+
+		Convey("When the pages that have not been translated into English are listed", func() {
+			w := doRequest(a, http.MethodGet, "/v1/translations/missing?lang=en", "")
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the pages that have not been translated are listed without authenticating", func() {
+			w := serve(a, newRequest(nil, http.MethodGet, "/v1/translations/missing", ""))
+
+			Convey("Then a 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// versionsResponse is the body returned when listing the previous versions of a page
+type versionsResponse struct {
+	Count int                   `json:"count"`
+	Items []*models.PageVersion `json:"items"`
+}
+
+// getVersionsHandler lists the previous versions of the page at the requested URI
+func (api *API) getVersionsHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	uri := pageURI(req)
+	logData := log.Data{"uri": uri}
+
+	versions, err := api.contentStore.GetPageVersions(ctx, uri)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	writeJSON(ctx, w, http.StatusOK, versionsResponse{Count: len(versions), Items: versions}, logData)
+}
+
+// writeVersion writes the requested version of a page to the response
+func (api *API) writeVersion(w http.ResponseWriter, req *http.Request, uri, v string, logData log.Data) {
+	ctx := req.Context()
+	number, err := strconv.Atoi(v)
+	if err != nil || number < 1 {
+		handleError(ctx, w, apierrors.ErrInvalidVersion, logData)
+		return
+	}
+
+	version, err := api.contentStore.GetPageVersion(ctx, uri, number)
+	if err != nil {
+		handleError(ctx, w, err, logData)
+		return
+	}
+
+	policy := cachePolicy{pageType: version.Type, lastModified: version.LastUpdated}
+	api.writeCacheable(w, req, contentTypeJSON, version.Data, policy, logData)
+}
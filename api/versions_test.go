@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/api/mock"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	bulletinBody   = `{"type":"bulletin","description":{"title":"Consumer price inflation, UK: February 2021","releaseDate":"2021-03-24T07:00:00Z"}}`
+	storedBulletin = `{"type":"bulletin","uri":"/economy","description":{"title":"Consumer price inflation, UK: February 2021","releaseDate":"2021-03-24T07:00:00Z"}}`
+)
+
+func TestVersions(t *testing.T) {
+	Convey("Given a page that has been corrected", t, func() {
+		store := memory.New()
+		a := newTestAPI(store, store)
+		So(doRequest(a, http.MethodPut, "/v1/content/economy", bulletinBody).Code, ShouldEqual, http.StatusCreated)
+		corrected := `{"type":"bulletin","description":{"title":"Consumer price inflation, UK: February 2021","releaseDate":"2021-03-24T07:00:00Z"},"alerts":[{"type":"correction","markdown":"Figure 3 has been corrected"}]}`
+		So(doReplace(a, "/v1/content/economy", corrected).Code, ShouldEqual, http.StatusOK)
+
+		Convey("When the versions of the page are requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content-actions/versions/economy", "")
+
+			Convey("Then the previous version is listed with its correction notice", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var body struct {
+					Count int                   `json:"count"`
+					Items []*models.PageVersion `json:"items"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &body), ShouldBeNil)
+				So(body.Count, ShouldEqual, 1)
+				So(body.Items[0].URI, ShouldEqual, "/economy/previous/v1")
+				So(body.Items[0].Version, ShouldEqual, 1)
+				So(body.Items[0].CorrectionNotice, ShouldEqual, "Figure 3 has been corrected")
+			})
+		})
+
+		Convey("When the previous version is requested with the version parameter", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy?version=1", "")
+
+			Convey("Then the page as it was before the correction is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, storedBulletin)
+			})
+		})
+
+		Convey("When the previous version is requested at its previous version URI", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy/previous/v1", "")
+
+			Convey("Then the page as it was before the correction is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, storedBulletin)
+			})
+		})
+
+		Convey("When a version that does not exist is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy?version=2", "")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When an invalid version is requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content/economy?version=latest", "")
+
+			Convey("Then a 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the previous version is overwritten", func() {
+			w := doRequest(a, http.MethodPut, "/v1/content/economy/previous/v1", bulletinBody)
+
+			Convey("Then the request is rejected and the version is unchanged", func() {
+				So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+				So(doRequest(a, http.MethodGet, "/v1/content/economy/previous/v1", "").Body.String(), ShouldEqual, storedBulletin)
+			})
+		})
+
+		Convey("When the previous version is deleted", func() {
+			w := doRequest(a, http.MethodDelete, "/v1/content/economy/previous/v1", "")
+
+			Convey("Then the request is rejected", func() {
+				So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+			})
+		})
+	})
+
+	Convey("Given a page that does not exist", t, func() {
+		store := memory.New()
+		a := newTestAPI(store, store)
+
+		Convey("When its versions are requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content-actions/versions/economy", "")
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+
+	Convey("Given a store that returns an error", t, func() {
+		a := newTestAPI(&mock.ContentStoreMock{
+			GetPageVersionsFunc: func(ctx context.Context, uri string) ([]*models.PageVersion, error) { return nil, errStore },
+		}, &mock.CollectionStoreMock{})
+
+		Convey("When the versions of a page are requested", func() {
+			w := doRequest(a, http.MethodGet, "/v1/content-actions/versions/economy", "")
+
+			Convey("Then a 500 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
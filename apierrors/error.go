@@ -0,0 +1,61 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ValidationFailedCode is the code given to each field of a request body that failed validation
+const ValidationFailedCode = "ValidationFailed"
+
+// Error is an error that can be returned to callers of the API. Its code identifies it and does not change, so
+// callers can rely on it where the description may be reworded.
+type Error struct {
+	Code        string
+	Description string
+}
+
+// New returns an error with the code and description
+func New(code, description string) error {
+	return &Error{Code: code, Description: description}
+}
+
+// Error implements the error interface, returning the description
+func (e *Error) Error() string {
+	return e.Description
+}
+
+// ErrorItem describes one of the errors in an error response
+type ErrorItem struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Field       string `json:"field,omitempty"`
+}
+
+// Response is the body of every unsuccessful response from the API
+type Response struct {
+	Errors []ErrorItem `json:"errors"`
+}
+
+// NewResponse returns the response for the error. Errors that are not an Error are internal, so their details are
+// not given to the caller.
+func NewResponse(err error) Response {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = ErrInternalServer.(*Error)
+	}
+	return Response{Errors: []ErrorItem{{Code: apiErr.Code, Description: apiErr.Description}}}
+}
+
+// Write writes the response as JSON with the status code
+func (r Response) Write(w http.ResponseWriter, status int) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,49 @@
+package apierrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewResponse(t *testing.T) {
+	Convey("Given an error returned by the API", t, func() {
+		Convey("Then the response gives its code and description", func() {
+			So(NewResponse(ErrPageNotFound), ShouldResemble, Response{Errors: []ErrorItem{{Code: "PageNotFound", Description: "page not found"}}})
+		})
+
+		Convey("Then the response is the same when it is wrapped", func() {
+			err := fmt.Errorf("reading page: %w", ErrPageNotFound)
+			So(NewResponse(err), ShouldResemble, NewResponse(ErrPageNotFound))
+		})
+	})
+
+	Convey("Given an error from a dependency", t, func() {
+		err := errors.New("mongo is unavailable")
+
+		Convey("Then the response describes it only as an internal error", func() {
+			So(NewResponse(err), ShouldResemble, Response{Errors: []ErrorItem{{Code: "InternalError", Description: "internal error"}}})
+		})
+	})
+}
+
+func TestWriteResponse(t *testing.T) {
+	Convey("Given an error response", t, func() {
+		resp := Response{Errors: []ErrorItem{{Code: ValidationFailedCode, Description: "is required", Field: "type"}}}
+
+		Convey("When it is written", func() {
+			w := httptest.NewRecorder()
+			So(resp.Write(w, http.StatusBadRequest), ShouldBeNil)
+
+			Convey("Then it is written as JSON with the status code", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"ValidationFailed","description":"is required","field":"type"}]}`)
+			})
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package apierrors
+
+// A list of errors returned by the content API, with the code that identifies each to callers
+var (
+	ErrPageNotFound      = New("PageNotFound", "page not found")
+	ErrPageAlreadyExists = New("PageAlreadyExists", "page already exists")
+	ErrPageHasDrafts     = New("PageHasDrafts", "pages with drafts in a collection cannot be moved")
+	ErrTooManyPages      = New("TooManyPages", "too many pages to move at once, move the pages below it first")
+	ErrInvalidBody       = New("InvalidBody", "request body must be a valid JSON object")
+	ErrNotFound          = New("NotFound", "resource not found")
+	ErrMethodNotAllowed  = New("MethodNotAllowed", "method not allowed")
+	ErrInternalServer    = New("InternalError", "internal error")
+	ErrUnauthorised      = New("Unauthorised", "request could not be authenticated")
+	ErrForbidden         = New("Forbidden", "caller does not have permission to perform this request")
+	ErrInvalidToken      = New("InvalidToken", "florence token could not be read")
+
+	ErrPreconditionRequired = New("PreconditionRequired", "If-Match must be given with the ETag of the version being replaced")
+	ErrPreconditionFailed   = New("PreconditionFailed", "If-Match does not match the ETag of the current version")
+	ErrEditConflict         = New("EditConflict", "content was changed by another request while it was being saved")
+
+	ErrCollectionNotFound       = New("CollectionNotFound", "collection not found")
+	ErrCollectionPublished      = New("CollectionPublished", "collection has already been published")
+	ErrCollectionNotPublishable = New("CollectionNotPublishable", "collection must contain at least one page and every page must be reviewed before publishing")
+	ErrCollectionNameRequired   = New("CollectionNameRequired", "collection name is required")
+	ErrCollectionItemNotFound   = New("CollectionItemNotFound", "page not found in collection")
+	ErrInvalidItemState         = New("InvalidItemState", "page must be complete before it can be reviewed")
+
+	ErrVersionNotFound = New("VersionNotFound", "version not found")
+	ErrInvalidVersion  = New("InvalidVersion", "version must be a positive integer")
+	ErrVersionReadOnly = New("VersionReadOnly", "previous versions of a page cannot be modified")
+
+	ErrInvalidAuditTime = New("InvalidAuditTime", "from and to must be RFC3339 timestamps")
+
+	ErrURIRequired = New("URIRequired", "uri query parameter is required")
+
+	ErrRedirectNotFound   = New("RedirectNotFound", "redirect not found")
+	ErrRedirectLoop       = New("RedirectLoop", "redirect would create a loop")
+	ErrRedirectFromPage   = New("RedirectFromPage", "redirects cannot be made from a uri that has a page")
+	ErrRedirectToRequired = New("RedirectToRequired", "to is required")
+	ErrTooManyRedirects   = New("TooManyRedirects", "too many redirects to store at once, upload them in smaller files")
+
+	ErrDestinationRequired = New("DestinationRequired", "destination is required")
+	ErrInvalidDestination  = New("InvalidDestination", "destination must not be above, below or the same as the page being moved")
+
+	ErrReleaseNotFound          = New("ReleaseNotFound", "release not found")
+	ErrReleaseCancelled         = New("ReleaseCancelled", "release has been cancelled")
+	ErrReleasePublished         = New("ReleasePublished", "release has already been published")
+	ErrReleaseConfirmed         = New("ReleaseConfirmed", "release date has already been confirmed")
+	ErrReleaseDateRequired      = New("ReleaseDateRequired", "release_date is required")
+	ErrReleaseDateNotPostponed  = New("ReleaseDateNotPostponed", "release_date must be after the current release date")
+	ErrReasonRequired           = New("ReasonRequired", "reason is required")
+	ErrInvalidReleaseStatus     = New("InvalidReleaseStatus", "status must be one of: provisional, confirmed, postponed, cancelled, published")
+	ErrInvalidReleaseDateFilter = New("InvalidReleaseDateFilter", "from and to must be RFC3339 timestamps")
+	ErrInvalidOffset            = New("InvalidOffset", "offset must be a non-negative integer")
+	ErrInvalidLimit             = New("InvalidLimit", "limit must be a positive integer no greater than the maximum limit")
+
+	ErrTimeseriesNotFound    = New("TimeseriesNotFound", "timeseries not found")
+	ErrTimeseriesAmbiguous   = New("TimeseriesAmbiguous", "cdid is published in more than one dataset, so dataset must be given")
+	ErrTimeseriesValuesEmpty = New("TimeseriesValuesEmpty", "at least one year, quarter or month value is required")
+	ErrInvalidDownloadFormat = New("InvalidDownloadFormat", "format must be one of: csv, xlsx, json")
+
+	ErrInvalidResolve      = New("InvalidResolve", "resolve must be true or false")
+	ErrInvalidResolveDepth = New("InvalidResolveDepth", "depth must be a positive integer no greater than the maximum resolve depth")
+
+	ErrTranslationNotFound      = New("TranslationNotFound", "translation not found")
+	ErrTranslationAlreadyExists = New("TranslationAlreadyExists", "translation already exists")
+	ErrTranslationTypeMismatch  = New("TranslationTypeMismatch", "translation must have the same type as the english page")
+	ErrInvalidLanguage          = New("InvalidLanguage", "lang must be one of: en, cy")
+	ErrInvalidTranslationLang   = New("InvalidTranslationLang", "lang must be a language that pages are translated into: cy")
+)
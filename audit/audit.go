@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/models"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gofrs/uuid"
+)
+
+// Store defines the required methods from the store of audit records of changes to content
+type Store interface {
+	AddAuditRecord(ctx context.Context, record *models.AuditRecord) error
+}
+
+// DraftStore defines the required methods to read the drafts that a collection publishes
+type DraftStore interface {
+	GetDraftPage(ctx context.Context, collectionID, uri string) (*models.Page, error)
+}
+
+// PageStore defines the required methods to read the published pages that a collection replaces
+type PageStore interface {
+	GetPage(ctx context.Context, uri string) (*models.Page, error)
+}
+
+// Add completes the record of a change with the caller, the request and the hashes of the page before and after the
+// change, and stores it. Before and after are nil if there was no page before the change or will be none after it.
+func Add(ctx context.Context, store Store, record *models.AuditRecord, before, after *models.Page) error {
+	identity := auth.FromContext(ctx)
+	if identity == nil {
+		return apierrors.ErrUnauthorised
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	record.ID = id.String()
+	record.User = identity.ID
+	record.Service = identity.Service
+	record.BeforeHash = models.HashPage(before)
+	record.AfterHash = models.HashPage(after)
+	record.RequestID = dprequest.GetRequestId(ctx)
+	record.Timestamp = time.Now().UTC()
+	return store.AddAuditRecord(ctx, record)
+}
+
+// Failed records that the changes in the audit records were not made, because the store failed to make them after
+// they were recorded. The error from the store is what the caller is told of, so failing to record this is logged.
+func Failed(ctx context.Context, store Store, records ...*models.AuditRecord) {
+	for _, record := range records {
+		failed := *record
+		id, err := uuid.NewV4()
+		if err == nil {
+			failed.ID = id.String()
+			failed.Failed = true
+			failed.Timestamp = time.Now().UTC()
+			err = store.AddAuditRecord(ctx, &failed)
+		}
+		if err != nil {
+			logData := log.Data{"audit_id": record.ID, "action": record.Action, "uri": record.URI, "collection_id": record.CollectionID}
+			log.Event(ctx, "recording failed change failed", log.ERROR, log.Error(err), logData)
+		}
+	}
+}
+
+// Publish records the publication of every page in the collection, against the published page that it replaces. If
+// any cannot be recorded, those that were are recorded as failed, as the collection will not be published.
+func Publish(ctx context.Context, store Store, drafts DraftStore, pages PageStore, collection *models.Collection) ([]*models.AuditRecord, error) {
+	records := make([]*models.AuditRecord, 0, len(collection.Items))
+	for _, item := range collection.Items {
+		record, err := publishItem(ctx, store, drafts, pages, collection.ID, item.URI)
+		if err != nil {
+			Failed(ctx, store, records...)
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// publishItem records the publication of the draft of the page at the URI in the collection
+func publishItem(ctx context.Context, store Store, drafts DraftStore, pages PageStore, collectionID, uri string) (*models.AuditRecord, error) {
+	draft, err := drafts.GetDraftPage(ctx, collectionID, uri)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := pages.GetPage(ctx, uri)
+	if err != nil && err != apierrors.ErrPageNotFound {
+		return nil, err
+	}
+
+	record := &models.AuditRecord{Action: models.AuditActionPublish, URI: uri, CollectionID: collectionID}
+	return record, Add(ctx, store, record, previous, draft)
+}
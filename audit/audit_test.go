@@ -0,0 +1,80 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/audit"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var publisher = &auth.Identity{ID: "publisher@ons.gov.uk", Role: auth.RolePublisher}
+
+func readRecords(store *memory.Store) []*models.AuditRecord {
+	records, _, err := store.GetAuditRecords(context.Background(), models.AuditFilter{})
+	So(err, ShouldBeNil)
+	return records
+}
+
+func TestAdd(t *testing.T) {
+	Convey("Given an empty audit store", t, func() {
+		store := memory.New()
+
+		Convey("When a change is recorded without a caller", func() {
+			err := audit.Add(context.Background(), store, &models.AuditRecord{Action: models.AuditActionCreate, URI: "/economy"}, nil, nil)
+
+			Convey("Then it is rejected", func() {
+				So(err, ShouldEqual, apierrors.ErrUnauthorised)
+				So(readRecords(store), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a change is recorded and then fails", func() {
+			ctx := auth.WithIdentity(context.Background(), publisher)
+			record := &models.AuditRecord{Action: models.AuditActionCreate, URI: "/economy"}
+			So(audit.Add(ctx, store, record, nil, &models.Page{Data: json.RawMessage(`{}`)}), ShouldBeNil)
+			audit.Failed(ctx, store, record)
+
+			Convey("Then the failure is recorded as a copy of the change", func() {
+				records := readRecords(store)
+				So(records, ShouldHaveLength, 2)
+				So(records[0].Failed, ShouldBeTrue)
+				So(records[0].ID, ShouldNotEqual, record.ID)
+				So(records[0].AfterHash, ShouldEqual, record.AfterHash)
+				So(records[0].User, ShouldEqual, publisher.ID)
+				So(record.Failed, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestPublish(t *testing.T) {
+	Convey("Given a collection with a draft and an item without one", t, func() {
+		ctx := auth.WithIdentity(context.Background(), publisher)
+		store := memory.New()
+		So(store.CreateCollection(ctx, &models.Collection{ID: "123", Name: "Economy", State: models.CollectionStateInProgress}), ShouldBeNil)
+		So(store.UpsertDraftPage(ctx, "123", &models.Page{URI: "/economy", Data: json.RawMessage(`{"type":"static_page"}`)}), ShouldBeNil)
+		collection, err := store.GetCollection(ctx, "123")
+		So(err, ShouldBeNil)
+		collection.Items = append(collection.Items, models.CollectionItem{URI: "/business"})
+
+		Convey("When its publication is recorded", func() {
+			records, err := audit.Publish(ctx, store, store, store, collection)
+
+			Convey("Then it fails, and the publication of the draft is recorded as failed", func() {
+				So(err, ShouldEqual, apierrors.ErrCollectionItemNotFound)
+				So(records, ShouldBeNil)
+				recorded := readRecords(store)
+				So(recorded, ShouldHaveLength, 2)
+				So(recorded[1].Action, ShouldEqual, models.AuditActionPublish)
+				So(recorded[1].URI, ShouldEqual, "/economy")
+				So(recorded[0].Failed, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/metrics"
+)
+
+// identityMetricsName is the name the identity cache is given in the cache metrics
+const identityMetricsName = "identities"
+
+// IdentityCache remembers who the tokens of recent requests belong to for the TTL, so that a caller making many
+// requests is not identified by Zebedee for each of them. Tokens that are not recognised are remembered too. A token
+// that is revoked can still be used until its identity expires from the cache, so the TTL is kept short. Other
+// failures to identify a caller are not cached, so that they are tried again.
+type IdentityCache struct {
+	client     IdentityClient
+	ttl        time.Duration
+	maxEntries int
+
+	mutex   sync.Mutex
+	entries map[tokens]*identityEntry
+}
+
+// tokens are the Florence and service tokens a request was made with
+type tokens struct {
+	florence string
+	service  string
+}
+
+// identityEntry is a cached result of identifying the caller with a pair of tokens
+type identityEntry struct {
+	identity *Identity
+	err      error
+	expires  time.Time
+}
+
+// NewIdentityCache returns a cache of at most maxEntries identities from the client, each for at most the TTL.
+// Nothing is cached if the TTL is not positive.
+func NewIdentityCache(client IdentityClient, ttl time.Duration, maxEntries int) *IdentityCache {
+	return &IdentityCache{
+		client:     client,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[tokens]*identityEntry),
+	}
+}
+
+// Identify returns the identity the tokens belong to, asking the client if it is not cached. Identities returned
+// from the cache are shared between requests, so must not be modified.
+func (c *IdentityCache) Identify(ctx context.Context, florenceToken, serviceToken string) (*Identity, error) {
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return c.client.Identify(ctx, florenceToken, serviceToken)
+	}
+	k := tokens{florence: florenceToken, service: serviceToken}
+
+	c.mutex.Lock()
+	e, ok := c.entries[k]
+	c.mutex.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		metrics.ObserveCache(identityMetricsName, true)
+		return e.identity, e.err
+	}
+	metrics.ObserveCache(identityMetricsName, false)
+
+	identity, err := c.client.Identify(ctx, florenceToken, serviceToken)
+	if err == nil || err == apierrors.ErrUnauthorised {
+		c.add(k, &identityEntry{identity: identity, err: err, expires: time.Now().Add(c.ttl)})
+	}
+	return identity, err
+}
+
+// add caches the identity, first removing the identities that have expired if the cache is full. The identity is
+// not cached if every other one is still current.
+func (c *IdentityCache) add(k tokens, e *identityEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.entries[k]; !ok && len(c.entries) >= c.maxEntries {
+		now := time.Now()
+		for key, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+	c.entries[k] = e
+	metrics.SetCacheSize(identityMetricsName, len(c.entries), 0)
+}
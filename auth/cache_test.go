@@ -0,0 +1,109 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/auth/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIdentityCache(t *testing.T) {
+	Convey("Given an identity client that recognises a publisher", t, func() {
+		client := &mock.IdentityClientMock{
+			IdentifyFunc: func(ctx context.Context, florenceToken, serviceToken string) (*auth.Identity, error) {
+				if florenceToken == "publisher-token" {
+					return publisher, nil
+				}
+				return nil, apierrors.ErrUnauthorised
+			},
+		}
+		cache := auth.NewIdentityCache(client, time.Minute, 10)
+
+		Convey("When the publisher is identified twice", func() {
+			first, err := cache.Identify(ctx, "publisher-token", "")
+			So(err, ShouldBeNil)
+			second, err := cache.Identify(ctx, "publisher-token", "")
+			So(err, ShouldBeNil)
+
+			Convey("Then the client is only asked once", func() {
+				So(first, ShouldEqual, publisher)
+				So(second, ShouldEqual, publisher)
+				So(client.IdentifyCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("Then a caller with another token is identified separately", func() {
+				_, err := cache.Identify(ctx, "", "publisher-token")
+				So(err, ShouldEqual, apierrors.ErrUnauthorised)
+				So(client.IdentifyCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a token that is not recognised is used twice", func() {
+			_, err1 := cache.Identify(ctx, "expired-token", "")
+			_, err2 := cache.Identify(ctx, "expired-token", "")
+
+			Convey("Then it is only checked once", func() {
+				So(err1, ShouldEqual, apierrors.ErrUnauthorised)
+				So(err2, ShouldEqual, apierrors.ErrUnauthorised)
+				So(client.IdentifyCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given an identity client that fails", t, func() {
+		errZebedee := errors.New("zebedee is unavailable")
+		client := &mock.IdentityClientMock{
+			IdentifyFunc: func(ctx context.Context, florenceToken, serviceToken string) (*auth.Identity, error) {
+				return nil, errZebedee
+			},
+		}
+		cache := auth.NewIdentityCache(client, time.Minute, 10)
+
+		Convey("Then the failure is not cached", func() {
+			_, err := cache.Identify(ctx, "publisher-token", "")
+			So(err, ShouldEqual, errZebedee)
+			_, _ = cache.Identify(ctx, "publisher-token", "")
+			So(client.IdentifyCalls(), ShouldHaveLength, 2)
+		})
+	})
+
+	Convey("Given a cache whose identities have expired", t, func() {
+		client := &mock.IdentityClientMock{
+			IdentifyFunc: func(ctx context.Context, florenceToken, serviceToken string) (*auth.Identity, error) {
+				return publisher, nil
+			},
+		}
+		cache := auth.NewIdentityCache(client, time.Nanosecond, 1)
+		_, err := cache.Identify(ctx, "publisher-token", "")
+		So(err, ShouldBeNil)
+		time.Sleep(time.Millisecond)
+
+		Convey("Then callers are identified again, replacing the expired identities when it is full", func() {
+			_, err := cache.Identify(ctx, "publisher-token", "")
+			So(err, ShouldBeNil)
+			_, err = cache.Identify(ctx, "viewer-token", "")
+			So(err, ShouldBeNil)
+			So(client.IdentifyCalls(), ShouldHaveLength, 3)
+		})
+	})
+
+	Convey("Given a cache that is disabled", t, func() {
+		client := &mock.IdentityClientMock{
+			IdentifyFunc: func(ctx context.Context, florenceToken, serviceToken string) (*auth.Identity, error) {
+				return publisher, nil
+			},
+		}
+		cache := auth.NewIdentityCache(client, 0, 10)
+
+		Convey("Then every caller is identified by the client", func() {
+			_, _ = cache.Identify(ctx, "publisher-token", "")
+			_, _ = cache.Identify(ctx, "publisher-token", "")
+			So(client.IdentifyCalls(), ShouldHaveLength, 2)
+		})
+	})
+}
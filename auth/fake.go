@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// FakeIdentityClient identifies callers from a fixed set of tokens, so that the API can be run and tested without
+// Zebedee
+type FakeIdentityClient struct {
+	Identities map[string]*Identity
+}
+
+// NewFakeIdentityClient returns a client that recognises the tokens of the provided identities
+func NewFakeIdentityClient(identities map[string]*Identity) *FakeIdentityClient {
+	return &FakeIdentityClient{Identities: identities}
+}
+
+// Identify returns the identity the Florence token, or otherwise the service token, belongs to
+func (c *FakeIdentityClient) Identify(ctx context.Context, florenceToken, serviceToken string) (*Identity, error) {
+	token := florenceToken
+	if token == "" {
+		token = serviceToken
+	}
+
+	identity, ok := c.Identities[token]
+	if !ok {
+		return nil, apierrors.ErrUnauthorised
+	}
+	return identity, nil
+}
+
+// Checker always reports the fake client as healthy
+func (c *FakeIdentityClient) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	return state.Update(healthcheck.StatusOK, "fake identity client is OK", 0)
+}
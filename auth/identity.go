@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	dphandlers "github.com/ONSdigital/dp-net/handlers"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+)
+
+type contextKey string
+
+const identityKey = contextKey("identity")
+
+// Identity is the user or service that a request was made by
+type Identity struct {
+	ID      string
+	Service bool
+	Role    Role
+}
+
+// WithIdentity returns a copy of the context holding the identity of the caller
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	ctx = dprequest.SetCaller(ctx, identity.ID)
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// FromContext returns the identity of the caller, or nil if the request was not authenticated
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey).(*Identity)
+	return identity
+}
+
+// Middleware identifies the caller of every request that provides a Florence or service token. Requests without
+// a token, with one that is not recognised, or whose caller cannot be identified because the identity service is
+// failing, continue unauthenticated, so that published content can still be read. It is left to the permission
+// checks to reject them where required.
+func Middleware(client IdentityClient) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+
+			florenceToken, err := dphandlers.GetFlorenceToken(ctx, req)
+			if err != nil {
+				log.Event(ctx, "reading florence token failed", log.WARN, log.Error(err))
+				fail(ctx, w, req, http.StatusBadRequest, apierrors.ErrInvalidToken)
+				return
+			}
+			serviceToken := getServiceToken(req)
+
+			if florenceToken == "" && serviceToken == "" {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			identity, err := client.Identify(ctx, florenceToken, serviceToken)
+			switch err {
+			case nil:
+			case apierrors.ErrUnauthorised:
+				log.Event(ctx, "token not recognised, continuing unauthenticated", log.WARN, log.Error(err))
+				h.ServeHTTP(w, req)
+				return
+			default:
+				log.Event(ctx, "identifying caller failed, continuing unauthenticated", log.ERROR, log.Error(err))
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			log.Event(ctx, "caller identified", log.INFO, log.Data{"caller": identity.ID, "role": identity.Role})
+			h.ServeHTTP(w, req.WithContext(WithIdentity(ctx, identity)))
+		})
+	}
+}
+
+// getServiceToken returns the service token from the Authorization header, without its bearer prefix
+func getServiceToken(req *http.Request) string {
+	token := req.Header.Get(dprequest.AuthHeaderKey)
+	if len(token) >= len(dprequest.BearerPrefix) && strings.EqualFold(token[:len(dprequest.BearerPrefix)], dprequest.BearerPrefix) {
+		token = token[len(dprequest.BearerPrefix):]
+	}
+	return strings.TrimSpace(token)
+}
+
+// fail logs why a request could not be identified and writes the status and error response
+func fail(ctx context.Context, w http.ResponseWriter, req *http.Request, status int, err error) {
+	log.Event(ctx, "identifying caller failed", log.WARN, log.Error(err), log.Data{"status": status})
+	dphttp.DrainBody(req)
+	if err := apierrors.NewResponse(err).Write(w, status); err != nil {
+		log.Event(ctx, "writing error response failed", log.ERROR, log.Error(err))
+	}
+}
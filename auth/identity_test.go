@@ -0,0 +1,118 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/auth/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ctx       = context.Background()
+	publisher = &auth.Identity{ID: "publisher@ons.gov.uk", Role: auth.RolePublisher}
+	viewer    = &auth.Identity{ID: "viewer@ons.gov.uk", Role: auth.RoleViewer}
+	service   = &auth.Identity{ID: "dp-import-tracker", Service: true, Role: auth.RolePublisher}
+)
+
+// serve passes the request through the middleware, returning the response and the identity the handler was called with
+func serve(client auth.IdentityClient, req *http.Request) (*httptest.ResponseRecorder, *auth.Identity, bool) {
+	var identity *auth.Identity
+	called := false
+	handler := auth.Middleware(client)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		identity = auth.FromContext(req.Context())
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, identity, called
+}
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given the middleware with a fake identity client", t, func() {
+		client := auth.NewFakeIdentityClient(map[string]*auth.Identity{
+			"publisher-token": publisher,
+			"service-token":   service,
+		})
+
+		Convey("When a request without a token is made", func() {
+			w, identity, called := serve(client, httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil))
+
+			Convey("Then the handler is called without an identity", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(called, ShouldBeTrue)
+				So(identity, ShouldBeNil)
+			})
+		})
+
+		Convey("When a request with a Florence token header is made", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+			req.Header.Set("X-Florence-Token", "publisher-token")
+			_, identity, _ := serve(client, req)
+
+			Convey("Then the handler is called with the identity of the user", func() {
+				So(identity, ShouldEqual, publisher)
+			})
+		})
+
+		Convey("When a request with a Florence token cookie is made", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: "publisher-token"})
+			_, identity, _ := serve(client, req)
+
+			Convey("Then the handler is called with the identity of the user", func() {
+				So(identity, ShouldEqual, publisher)
+			})
+		})
+
+		Convey("When a request with a service token is made", func() {
+			for _, header := range []string{"Bearer service-token", "bearer service-token", "service-token"} {
+				req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+				req.Header.Set("Authorization", header)
+				_, identity, _ := serve(client, req)
+
+				Convey("Then the handler is called with the identity of the service for "+header, func() {
+					So(identity, ShouldEqual, service)
+				})
+			}
+		})
+
+		Convey("When a request with an unrecognised token is made", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+			req.Header.Set("X-Florence-Token", "unknown")
+			w, identity, called := serve(client, req)
+
+			Convey("Then the handler is called without an identity, leaving the permission checks to refuse it", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(called, ShouldBeTrue)
+				So(identity, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given an identity client that fails", t, func() {
+		client := &mock.IdentityClientMock{
+			IdentifyFunc: func(ctx context.Context, florenceToken, serviceToken string) (*auth.Identity, error) {
+				return nil, errors.New("zebedee is unavailable")
+			},
+		}
+
+		Convey("When a request with a token is made for published content", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+			req.Header.Set("X-Florence-Token", "publisher-token")
+			w, identity, called := serve(client, req)
+
+			Convey("Then the handler is called without an identity, so that the content can still be read", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(called, ShouldBeTrue)
+				So(identity, ShouldBeNil)
+				So(client.IdentifyCalls()[0].FlorenceToken, ShouldEqual, "publisher-token")
+			})
+		})
+	})
+}
@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+)
+
+//go:generate moq -out mock/identityClient.go -pkg mock . IdentityClient
+
+// IdentityClient defines the required methods to identify the user or service that a request was made by. An
+// unrecognised token returns apierrors.ErrUnauthorised.
+type IdentityClient interface {
+	Identify(ctx context.Context, florenceToken, serviceToken string) (*Identity, error)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+)
+
+// Role is the level of access a caller has to unpublished content
+type Role string
+
+// The roles a caller can have
+const (
+	RolePublisher Role = "publisher"
+	RoleViewer    Role = "viewer"
+)
+
+// Permission is an action a caller may need to be allowed to perform
+type Permission string
+
+// The permissions required by the API. Reading published content requires no permission.
+const (
+	PermissionRead Permission = "read"
+	PermissionEdit Permission = "edit"
+)
+
+// rolePermissions lists the permissions each role is granted
+var rolePermissions = map[Role][]Permission{
+	RolePublisher: {PermissionRead, PermissionEdit},
+	RoleViewer:    {PermissionRead},
+}
+
+// HasPermission reports whether the role is granted the permission
+func (r Role) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error unless the caller identified in the context has the permission
+func Check(ctx context.Context, permission Permission) error {
+	identity := FromContext(ctx)
+	if identity == nil {
+		return apierrors.ErrUnauthorised
+	}
+	if !identity.Role.HasPermission(permission) {
+		return apierrors.ErrForbidden
+	}
+	return nil
+}
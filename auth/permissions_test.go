@@ -0,0 +1,44 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheck(t *testing.T) {
+	Convey("Given an unauthenticated request", t, func() {
+		Convey("Then every permission is refused as unauthorised", func() {
+			So(auth.Check(ctx, auth.PermissionRead), ShouldEqual, apierrors.ErrUnauthorised)
+			So(auth.Check(ctx, auth.PermissionEdit), ShouldEqual, apierrors.ErrUnauthorised)
+		})
+	})
+
+	Convey("Given a request from a publisher", t, func() {
+		ctx := auth.WithIdentity(ctx, publisher)
+
+		Convey("Then it can read and edit content", func() {
+			So(auth.Check(ctx, auth.PermissionRead), ShouldBeNil)
+			So(auth.Check(ctx, auth.PermissionEdit), ShouldBeNil)
+		})
+	})
+
+	Convey("Given a request from a viewer", t, func() {
+		ctx := auth.WithIdentity(ctx, viewer)
+
+		Convey("Then it can read but not edit content", func() {
+			So(auth.Check(ctx, auth.PermissionRead), ShouldBeNil)
+			So(auth.Check(ctx, auth.PermissionEdit), ShouldEqual, apierrors.ErrForbidden)
+		})
+	})
+
+	Convey("Given a request from a caller without a role", t, func() {
+		ctx := auth.WithIdentity(ctx, &auth.Identity{ID: "someone@ons.gov.uk"})
+
+		Convey("Then it is forbidden from reading content", func() {
+			So(auth.Check(ctx, auth.PermissionRead), ShouldEqual, apierrors.ErrForbidden)
+		})
+	})
+}
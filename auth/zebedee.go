@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-api-clients-go/health"
+	clientsidentity "github.com/ONSdigital/dp-api-clients-go/identity"
+	"github.com/ONSdigital/dp-api-clients-go/zebedee"
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// ZebedeeClient identifies callers using the Zebedee identity and permission endpoints
+type ZebedeeClient struct {
+	identity *clientsidentity.Client
+	zebedee  *zebedee.Client
+}
+
+// permissionResponse is the permission definition Zebedee returns for a user
+type permissionResponse struct {
+	Email  string `json:"email"`
+	Admin  bool   `json:"admin"`
+	Editor bool   `json:"editor"`
+}
+
+// NewZebedeeClient returns a client for the Zebedee instance at the URL. Its requests are traced, and carry the
+// trace context so that Zebedee's spans join the trace of the request being authorised.
+func NewZebedeeClient(zebedeeURL string) *ZebedeeClient {
+	hcClient := health.NewClientWithClienter("zebedee", zebedeeURL, newTracedClient())
+	return &ZebedeeClient{
+		identity: clientsidentity.NewWithHealthClient(hcClient),
+		zebedee:  zebedee.NewWithHealthClient(hcClient),
+	}
+}
+
+// newTracedClient returns a copy of the default dp-net client whose requests are sent through a tracing transport
+func newTracedClient() dphttp.Clienter {
+	client := *dphttp.DefaultClient
+	httpClient := *client.HTTPClient
+	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport)
+	client.HTTPClient = &httpClient
+	return &client
+}
+
+// Identify checks the token with Zebedee. Services are trusted to publish content, whereas users are publishers
+// only if Zebedee lists them as an editor or admin.
+func (c *ZebedeeClient) Identify(ctx context.Context, florenceToken, serviceToken string) (*Identity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, _, authFailure, err := c.identity.CheckRequest(req, florenceToken, serviceToken)
+	if err != nil {
+		return nil, err
+	}
+	if authFailure != nil {
+		return nil, apierrors.ErrUnauthorised
+	}
+
+	identity := &Identity{ID: dprequest.Caller(ctx)}
+	if florenceToken == "" {
+		identity.Service = true
+		identity.Role = RolePublisher
+		return identity, nil
+	}
+
+	body, err := c.zebedee.Get(ctx, florenceToken, "/permission?email="+url.QueryEscape(identity.ID))
+	if err != nil {
+		return nil, err
+	}
+	var permissions permissionResponse
+	if err := json.Unmarshal(body, &permissions); err != nil {
+		return nil, err
+	}
+
+	identity.Role = RoleViewer
+	if permissions.Admin || permissions.Editor {
+		identity.Role = RolePublisher
+	}
+	return identity, nil
+}
+
+// Checker checks the health of Zebedee
+func (c *ZebedeeClient) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	return c.zebedee.Checker(ctx, state)
+}
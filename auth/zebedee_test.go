@@ -0,0 +1,107 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/auth"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newZebedee returns a server that identifies the publisher, viewer and service tokens as Zebedee would
+func newZebedee() *httptest.Server {
+	identities := map[string]string{
+		"publisher-token": "publisher@ons.gov.uk",
+		"viewer-token":    "viewer@ons.gov.uk",
+		"Bearer service":  "dp-import-tracker",
+	}
+	permissions := map[string]string{
+		"publisher@ons.gov.uk": `{"email":"publisher@ons.gov.uk","admin":false,"editor":true}`,
+		"viewer@ons.gov.uk":    `{"email":"viewer@ons.gov.uk","admin":false,"editor":false}`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/identity":
+			token := req.Header.Get("X-Florence-Token")
+			if token == "" {
+				token = req.Header.Get("Authorization")
+			}
+			id, ok := identities[token]
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"identifier":"` + id + `"}`))
+		case "/permission":
+			body, ok := permissions[req.URL.Query().Get("email")]
+			if !ok || req.Header.Get("X-Florence-Token") == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestZebedeeClient(t *testing.T) {
+	Convey("Given a client for Zebedee", t, func() {
+		zebedee := newZebedee()
+		defer zebedee.Close()
+		client := auth.NewZebedeeClient(zebedee.URL)
+
+		Convey("When an editor's token is identified", func() {
+			identity, err := client.Identify(ctx, "publisher-token", "")
+
+			Convey("Then they are a publisher", func() {
+				So(err, ShouldBeNil)
+				So(identity, ShouldResemble, &auth.Identity{ID: "publisher@ons.gov.uk", Role: auth.RolePublisher})
+			})
+		})
+
+		Convey("When a token of a user who is not an editor is identified", func() {
+			identity, err := client.Identify(ctx, "viewer-token", "")
+
+			Convey("Then they are a viewer", func() {
+				So(err, ShouldBeNil)
+				So(identity, ShouldResemble, &auth.Identity{ID: "viewer@ons.gov.uk", Role: auth.RoleViewer})
+			})
+		})
+
+		Convey("When a service token is identified", func() {
+			identity, err := client.Identify(ctx, "", "service")
+
+			Convey("Then the service is a publisher", func() {
+				So(err, ShouldBeNil)
+				So(identity, ShouldResemble, &auth.Identity{ID: "dp-import-tracker", Service: true, Role: auth.RolePublisher})
+			})
+		})
+
+		Convey("When an unrecognised token is identified", func() {
+			_, err := client.Identify(ctx, "unknown", "")
+
+			Convey("Then the request is unauthorised", func() {
+				So(err, ShouldEqual, apierrors.ErrUnauthorised)
+			})
+		})
+	})
+
+	Convey("Given a client for Zebedee that cannot be reached", t, func() {
+		zebedee := newZebedee()
+		zebedee.Close()
+		client := auth.NewZebedeeClient(zebedee.URL)
+
+		Convey("When a token is identified", func() {
+			_, err := client.Identify(ctx, "publisher-token", "")
+
+			Convey("Then an error other than unauthorised is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err, ShouldNotEqual, apierrors.ErrUnauthorised)
+			})
+		})
+	})
+}
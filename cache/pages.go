@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// metricsName is the name the cache is given in the cache metrics
+const metricsName = "pages"
+
+// errLoadFailed is returned to requests that were waiting for a read of a page that did not complete
+var errLoadFailed = errors.New("reading page for cache failed")
+
+// Pages is a least recently used cache of published pages and their translations, read through from the content
+// store. Pages that do not exist are cached too, so that requests for missing translations do not each reach the
+// store. When the cache holds more than its maximum number of entries or bytes of page data, the least recently
+// read pages are evicted. Entries expire after the TTL, which bounds how long another instance of the service can
+// return a page after it has been published by this one.
+//
+// Concurrent misses for the same page share a single read from the store, so that a hot page that has just been
+// published or evicted does not send a burst of reads to the store. The shared read is not cancelled when the
+// request that started it ends, as others may be waiting for it, but is bounded by the read timeout instead.
+type Pages struct {
+	mutex      sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	ttl        time.Duration
+	timeout    time.Duration
+	bytes      int64
+	evictions  int
+	recent     *list.List
+	entries    map[key]*list.Element
+	loads      map[key]*load
+}
+
+// key identifies a page, or its translation into a language
+type key struct {
+	uri  string
+	lang models.Language
+}
+
+// entry is a cached result of reading a page from the store
+type entry struct {
+	key     key
+	page    *models.Page
+	err     error
+	size    int64
+	expires time.Time
+}
+
+// load is a read of a page from the store that is in progress, which other requests for the page wait for
+type load struct {
+	done        chan struct{}
+	page        *models.Page
+	err         error
+	panic       interface{}
+	invalidated bool
+}
+
+// Stats describes the contents of the cache
+type Stats struct {
+	Entries   int
+	Bytes     int64
+	Evictions int
+}
+
+// NewPages returns a cache holding at most maxEntries pages and maxBytes of page data, each for at most the TTL.
+// Pages missing from the cache are read within the timeout. Nothing is cached if maxEntries is not positive.
+func NewPages(maxEntries int, maxBytes int64, ttl, timeout time.Duration) *Pages {
+	return &Pages{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ttl:        ttl,
+		timeout:    timeout,
+		recent:     list.New(),
+		entries:    make(map[key]*list.Element),
+		loads:      make(map[key]*load),
+	}
+}
+
+// Get returns the page at the URI in the language, reading it with read if it is not cached. Pages returned from
+// the cache are shared between requests, so must not be modified.
+func (c *Pages) Get(ctx context.Context, uri string, lang models.Language, read func(ctx context.Context) (*models.Page, error)) (*models.Page, error) {
+	if c.maxEntries <= 0 {
+		return read(ctx)
+	}
+	k := key{uri: uri, lang: lang}
+
+	c.mutex.Lock()
+	if element, ok := c.entries[k]; ok {
+		e := element.Value.(*entry)
+		if time.Now().Before(e.expires) {
+			c.recent.MoveToFront(element)
+			c.mutex.Unlock()
+			metrics.ObserveCache(metricsName, true)
+			return e.page, e.err
+		}
+		c.remove(element)
+	}
+	metrics.ObserveCache(metricsName, false)
+
+	// wait for a read of the page that is already in progress rather than reading it again
+	if l, ok := c.loads[k]; ok {
+		c.mutex.Unlock()
+		select {
+		case <-l.done:
+			return l.page, l.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	l := &load{done: make(chan struct{}), err: errLoadFailed}
+	c.loads[k] = l
+	c.mutex.Unlock()
+
+	go c.load(ctx, k, l, read)
+	select {
+	case <-l.done:
+		// the read panicking is reported to the request that started it, as it would be had it read the page itself
+		if l.panic != nil {
+			panic(l.panic)
+		}
+		return l.page, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// load reads the page for the requests waiting for it, with the values of the context of the request that started
+// the read, such as its trace, but not its cancellation
+func (c *Pages) load(ctx context.Context, k key, l *load, read func(ctx context.Context) (*models.Page, error)) {
+	// the waiting requests are released even if the read panics, with an error rather than an empty page
+	defer func() {
+		l.panic = recover()
+		c.finish(k, l)
+	}()
+
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, c.timeout)
+	defer cancel()
+	l.page, l.err = read(ctx)
+}
+
+// finish removes the read of a page from those in progress, caching its result, and releases the requests that
+// were waiting for it
+func (c *Pages) finish(k key, l *load) {
+	c.mutex.Lock()
+	if c.loads[k] == l {
+		delete(c.loads, k)
+	}
+	// a page invalidated while it was being read may have been read as it was before it was published
+	if !l.invalidated && cacheable(l.err) {
+		c.add(k, l.page, l.err)
+	}
+	c.mutex.Unlock()
+	close(l.done)
+}
+
+// Invalidate removes the page at the URI from the cache in every language, so that it is read from the store again
+// when it is next requested. Reads of the page already in progress are not cached.
+func (c *Pages) Invalidate(uri string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, lang := range []models.Language{models.LanguageEnglish, models.LanguageWelsh} {
+		k := key{uri: uri, lang: lang}
+		if element, ok := c.entries[k]; ok {
+			c.remove(element)
+		}
+		if l, ok := c.loads[k]; ok {
+			l.invalidated = true
+			delete(c.loads, k)
+		}
+	}
+}
+
+// Stats returns the number of pages cached, the bytes of page data they hold and how many have been evicted
+func (c *Pages) Stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return Stats{Entries: c.recent.Len(), Bytes: c.bytes, Evictions: c.evictions}
+}
+
+// Checker reports the cache as healthy, giving its size in the message, as it has no external dependencies
+func (c *Pages) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	stats := c.Stats()
+	message := fmt.Sprintf("page cache is OK: %d pages, %d bytes, %d evicted", stats.Entries, stats.Bytes, stats.Evictions)
+	return state.Update(healthcheck.StatusOK, message, 0)
+}
+
+// add caches the result of reading the page, evicting the least recently read pages until the cache is within its
+// limits. The caller must hold the lock.
+func (c *Pages) add(k key, page *models.Page, err error) {
+	e := &entry{key: k, page: page, err: err, size: int64(len(k.uri)), expires: time.Now().Add(c.ttl)}
+	if page != nil {
+		e.size += int64(len(page.Data))
+	}
+	if c.maxBytes > 0 && e.size > c.maxBytes {
+		return
+	}
+
+	if element, ok := c.entries[k]; ok {
+		c.remove(element)
+	}
+	c.entries[k] = c.recent.PushFront(e)
+	c.bytes += e.size
+
+	for c.recent.Len() > c.maxEntries || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.recent.Back())
+		c.evictions++
+		metrics.ObserveCacheEviction(metricsName)
+	}
+	metrics.SetCacheSize(metricsName, c.recent.Len(), c.bytes)
+}
+
+// remove removes the entry from the cache. The caller must hold the lock.
+func (c *Pages) remove(element *list.Element) {
+	e := c.recent.Remove(element).(*entry)
+	delete(c.entries, e.key)
+	c.bytes -= e.size
+	metrics.SetCacheSize(metricsName, c.recent.Len(), c.bytes)
+}
+
+// detachedContext has the values of the context it wraps, but is never cancelled and has no deadline
+type detachedContext struct {
+	context.Context
+}
+
+// Deadline returns no deadline
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done returns nil, as the context is never cancelled
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err returns nil, as the context is never cancelled
+func (detachedContext) Err() error {
+	return nil
+}
+
+// cacheable returns true if the result of reading a page with the error can be cached. Pages that do not exist are
+// cached until they are published, but other errors are not, so that the read is tried again.
+func cacheable(err error) bool {
+	return err == nil || err == apierrors.ErrPageNotFound || err == apierrors.ErrTranslationNotFound
+}
@@ -0,0 +1,310 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// reader counts the reads of pages that miss the cache
+type reader struct {
+	reads int32
+	page  *models.Page
+	err   error
+}
+
+func (r *reader) read(ctx context.Context) (*models.Page, error) {
+	atomic.AddInt32(&r.reads, 1)
+	return r.page, r.err
+}
+
+func (r *reader) count() int {
+	return int(atomic.LoadInt32(&r.reads))
+}
+
+func testPage(uri, title string) *models.Page {
+	return &models.Page{URI: uri, Type: models.PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","description":{"title":"` + title + `"}}`)}
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an empty cache", t, func() {
+		pages := NewPages(10, 0, time.Minute, time.Second)
+		r := &reader{page: testPage("/aboutus", "About us")}
+
+		Convey("When a page is read twice", func() {
+			first, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			So(err, ShouldBeNil)
+			second, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			So(err, ShouldBeNil)
+
+			Convey("Then it is only read from the store once", func() {
+				So(r.count(), ShouldEqual, 1)
+				So(first, ShouldEqual, r.page)
+				So(second, ShouldEqual, r.page)
+				So(pages.Stats(), ShouldResemble, Stats{Entries: 1, Bytes: int64(len("/aboutus") + len(r.page.Data))})
+			})
+
+			Convey("Then its translation is read separately", func() {
+				_, err := pages.Get(ctx, "/aboutus", models.LanguageWelsh, r.read)
+				So(err, ShouldBeNil)
+				So(r.count(), ShouldEqual, 2)
+			})
+
+			Convey("And it is invalidated", func() {
+				pages.Invalidate("/aboutus")
+
+				Convey("Then it is read from the store again", func() {
+					_, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+					So(err, ShouldBeNil)
+					So(r.count(), ShouldEqual, 2)
+					So(pages.Stats().Entries, ShouldEqual, 1)
+				})
+			})
+		})
+
+		Convey("When a page that does not exist is read twice", func() {
+			r.page, r.err = nil, apierrors.ErrTranslationNotFound
+			_, err1 := pages.Get(ctx, "/aboutus", models.LanguageWelsh, r.read)
+			_, err2 := pages.Get(ctx, "/aboutus", models.LanguageWelsh, r.read)
+
+			Convey("Then it is only looked for in the store once", func() {
+				So(err1, ShouldEqual, apierrors.ErrTranslationNotFound)
+				So(err2, ShouldEqual, apierrors.ErrTranslationNotFound)
+				So(r.count(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When reading a page fails", func() {
+			r.page, r.err = nil, errors.New("store unavailable")
+			_, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			So(err, ShouldEqual, r.err)
+
+			Convey("Then the failure is not cached", func() {
+				r.page, r.err = testPage("/aboutus", "About us"), nil
+				page, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+				So(err, ShouldBeNil)
+				So(page, ShouldEqual, r.page)
+				So(r.count(), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a cache whose entries have expired", t, func() {
+		pages := NewPages(10, 0, -time.Second, time.Second)
+		r := &reader{page: testPage("/aboutus", "About us")}
+		_, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+		So(err, ShouldBeNil)
+
+		Convey("Then pages are read from the store again", func() {
+			_, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			So(err, ShouldBeNil)
+			So(r.count(), ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given a cache that is disabled", t, func() {
+		pages := NewPages(0, 0, time.Minute, time.Second)
+		r := &reader{page: testPage("/aboutus", "About us")}
+
+		Convey("Then every read goes to the store", func() {
+			_, _ = pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			_, _ = pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			So(r.count(), ShouldEqual, 2)
+			So(pages.Stats().Entries, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestEviction(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a cache that is full", t, func() {
+		pages := NewPages(2, 0, time.Minute, time.Second)
+		readers := map[string]*reader{}
+		get := func(uri string) {
+			if readers[uri] == nil {
+				readers[uri] = &reader{page: testPage(uri, uri)}
+			}
+			_, err := pages.Get(ctx, uri, models.LanguageEnglish, readers[uri].read)
+			So(err, ShouldBeNil)
+		}
+		get("/a")
+		get("/b")
+
+		Convey("When the oldest page is read again and another is added", func() {
+			get("/a")
+			get("/c")
+
+			Convey("Then the least recently read page is evicted", func() {
+				So(pages.Stats().Entries, ShouldEqual, 2)
+				So(pages.Stats().Evictions, ShouldEqual, 1)
+				get("/a")
+				get("/b")
+				So(readers["/a"].count(), ShouldEqual, 1)
+				So(readers["/b"].count(), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a cache limited in bytes", t, func() {
+		small := testPage("/a", "a")
+		size := int64(len(small.URI) + len(small.Data))
+		pages := NewPages(10, 2*size, time.Minute, time.Second)
+		for _, uri := range []string{"/a", "/b", "/c"} {
+			r := &reader{page: testPage(uri, uri[1:])}
+			_, err := pages.Get(ctx, uri, models.LanguageEnglish, r.read)
+			So(err, ShouldBeNil)
+		}
+
+		Convey("Then pages are evicted to keep it within its size", func() {
+			So(pages.Stats(), ShouldResemble, Stats{Entries: 2, Bytes: 2 * size, Evictions: 1})
+		})
+
+		Convey("Then a page larger than the cache is not cached", func() {
+			r := &reader{page: testPage("/large", strings.Repeat("a", int(2*size)))}
+			_, err := pages.Get(ctx, "/large", models.LanguageEnglish, r.read)
+			So(err, ShouldBeNil)
+			So(pages.Stats().Entries, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestConcurrentMisses(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a page that is slow to read", t, func() {
+		pages := NewPages(10, 0, time.Minute, time.Second)
+		release := make(chan struct{})
+		var reads int32
+		read := func(ctx context.Context) (*models.Page, error) {
+			atomic.AddInt32(&reads, 1)
+			<-release
+			return testPage("/aboutus", "About us"), nil
+		}
+
+		Convey("When it is requested many times at once", func() {
+			var wg sync.WaitGroup
+			results := make([]*models.Page, 10)
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], _ = pages.Get(ctx, "/aboutus", models.LanguageEnglish, read)
+				}(i)
+			}
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			Convey("Then it is read from the store once, and every request is given it", func() {
+				So(atomic.LoadInt32(&reads), ShouldEqual, 1)
+				for _, page := range results {
+					So(page, ShouldNotBeNil)
+					So(page, ShouldEqual, results[0])
+				}
+			})
+		})
+
+		Convey("When it is invalidated while it is being read", func() {
+			done := make(chan struct{})
+			go func() {
+				_, _ = pages.Get(ctx, "/aboutus", models.LanguageEnglish, read)
+				close(done)
+			}()
+			for atomic.LoadInt32(&reads) == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			pages.Invalidate("/aboutus")
+			close(release)
+			<-done
+
+			Convey("Then the page that was read is not cached", func() {
+				So(pages.Stats().Entries, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the request that started reading it is cancelled while another waits for it", func() {
+			first, cancel := context.WithCancel(ctx)
+			var firstErr error
+			done := make(chan struct{})
+			go func() {
+				_, firstErr = pages.Get(first, "/aboutus", models.LanguageEnglish, read)
+				close(done)
+			}()
+			for atomic.LoadInt32(&reads) == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			waited := make(chan *models.Page)
+			go func() {
+				page, _ := pages.Get(ctx, "/aboutus", models.LanguageEnglish, read)
+				waited <- page
+			}()
+			cancel()
+			<-done
+			close(release)
+
+			Convey("Then only the cancelled request fails, and the page is read once and cached", func() {
+				So(firstErr, ShouldEqual, context.Canceled)
+				So(<-waited, ShouldNotBeNil)
+				So(atomic.LoadInt32(&reads), ShouldEqual, 1)
+				So(pages.Stats().Entries, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a page that is read more slowly than the read timeout", t, func() {
+		pages := NewPages(10, 0, time.Minute, 10*time.Millisecond)
+
+		Convey("Then the read is cancelled after the timeout", func() {
+			_, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, func(ctx context.Context) (*models.Page, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a page whose read panics", t, func() {
+		pages := NewPages(10, 0, time.Minute, time.Second)
+
+		Convey("Then the panic is not cached, and later reads succeed", func() {
+			So(func() {
+				_, _ = pages.Get(ctx, "/aboutus", models.LanguageEnglish, func(ctx context.Context) (*models.Page, error) {
+					panic("read failed")
+				})
+			}, ShouldPanic)
+			r := &reader{page: testPage("/aboutus", "About us")}
+			page, err := pages.Get(ctx, "/aboutus", models.LanguageEnglish, r.read)
+			So(err, ShouldBeNil)
+			So(page, ShouldEqual, r.page)
+		})
+	})
+}
+
+func TestChecker(t *testing.T) {
+	Convey("Given a cache holding a page", t, func() {
+		pages := NewPages(10, 0, time.Minute, time.Second)
+		r := &reader{page: testPage("/aboutus", "About us")}
+		_, err := pages.Get(context.Background(), "/aboutus", models.LanguageEnglish, r.read)
+		So(err, ShouldBeNil)
+
+		Convey("Then it is healthy, giving its size", func() {
+			state := healthcheck.NewCheckState("page cache")
+			So(pages.Checker(context.Background(), state), ShouldBeNil)
+			So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+			So(state.Message(), ShouldContainSubstring, "1 pages")
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/event"
+)
+
+// EventProducer defines the required methods to notify other services that content has been published or deleted
+type EventProducer interface {
+	ContentPublished(ctx context.Context, e *event.ContentPublished) error
+	ContentDeleted(ctx context.Context, e *event.ContentDeleted) error
+}
+
+// Producer removes each page from the cache as the event for its publication or deletion is sent. Every change to
+// published content sends an event once it has been stored, whether it is made by a request or a scheduled publish,
+// so the cache never returns a page that this instance has since changed.
+type Producer struct {
+	EventProducer
+	pages *Pages
+}
+
+// NewProducer returns a producer that invalidates the cached pages of the events it sends
+func NewProducer(producer EventProducer, pages *Pages) *Producer {
+	return &Producer{EventProducer: producer, pages: pages}
+}
+
+// ContentPublished removes the published page from the cache, and sends a content published event
+func (p *Producer) ContentPublished(ctx context.Context, e *event.ContentPublished) error {
+	p.pages.Invalidate(e.URI)
+	return p.EventProducer.ContentPublished(ctx, e)
+}
+
+// ContentDeleted removes the deleted page from the cache, and sends a content deleted event
+func (p *Producer) ContentDeleted(ctx context.Context, e *event.ContentDeleted) error {
+	p.pages.Invalidate(e.URI)
+	return p.EventProducer.ContentDeleted(ctx, e)
+}
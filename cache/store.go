@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// Store is a content store that reads published pages and their translations through the cache. Every other
+// operation goes straight to the underlying store.
+type Store struct {
+	api.ContentStore
+	pages *Pages
+}
+
+// NewStore returns a content store that reads pages from the store through the cache
+func NewStore(store api.ContentStore, pages *Pages) *Store {
+	return &Store{ContentStore: store, pages: pages}
+}
+
+// GetPage returns the page stored against the provided URI, from the cache if it holds it
+func (s *Store) GetPage(ctx context.Context, uri string) (*models.Page, error) {
+	return s.pages.Get(ctx, uri, models.LanguageEnglish, func(ctx context.Context) (*models.Page, error) {
+		return s.ContentStore.GetPage(ctx, uri)
+	})
+}
+
+// GetTranslation returns the translation of the page at the provided URI into the language, from the cache if it
+// holds it
+func (s *Store) GetTranslation(ctx context.Context, uri string, lang models.Language) (*models.Page, error) {
+	return s.pages.Get(ctx, uri, lang, func(ctx context.Context) (*models.Page, error) {
+		return s.ContentStore.GetTranslation(ctx, uri, lang)
+	})
+}
+
+// ReplacePage replaces the page if its ETag is unchanged. A page that was changed by another instance of the service
+// is removed from the cache, so that the caller is given the version that is now current.
+func (s *Store) ReplacePage(ctx context.Context, page *models.Page, etag string) error {
+	err := s.ContentStore.ReplacePage(ctx, page, etag)
+	if err == apierrors.ErrEditConflict {
+		s.pages.Invalidate(page.URI)
+	}
+	return err
+}
+
+// ReplaceTranslation replaces the translation if its ETag is unchanged. A translation that was changed by another
+// instance of the service is removed from the cache, so that the caller is given the version that is now current.
+func (s *Store) ReplaceTranslation(ctx context.Context, lang models.Language, page *models.Page, etag string) error {
+	err := s.ContentStore.ReplaceTranslation(ctx, lang, page, etag)
+	if err == apierrors.ErrEditConflict {
+		s.pages.Invalidate(page.URI)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/api/mock"
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store of a page and its translation, read through the cache", t, func() {
+		english, welsh := testPage("/aboutus", "About us"), testPage("/aboutus", "Amdanom ni")
+		contentStore := &mock.ContentStoreMock{
+			GetPageFunc: func(ctx context.Context, uri string) (*models.Page, error) {
+				return english, nil
+			},
+			GetTranslationFunc: func(ctx context.Context, uri string, lang models.Language) (*models.Page, error) {
+				return welsh, nil
+			},
+			ReplacePageFunc: func(ctx context.Context, page *models.Page, etag string) error {
+				return apierrors.ErrEditConflict
+			},
+			DeletePageFunc: func(ctx context.Context, uri string) error {
+				return nil
+			},
+		}
+		store := NewStore(contentStore, NewPages(10, 0, time.Minute, time.Second))
+
+		Convey("When the page and its translation are each read twice", func() {
+			for i := 0; i < 2; i++ {
+				page, err := store.GetPage(ctx, "/aboutus")
+				So(err, ShouldBeNil)
+				So(page, ShouldEqual, english)
+				translation, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(translation, ShouldEqual, welsh)
+			}
+
+			Convey("Then each is only read from the store once", func() {
+				So(len(contentStore.GetPageCalls()), ShouldEqual, 1)
+				So(len(contentStore.GetTranslationCalls()), ShouldEqual, 1)
+			})
+
+			Convey("And replacing the page conflicts with a change made elsewhere", func() {
+				err := store.ReplacePage(ctx, testPage("/aboutus", "About the ONS"), english.ETag())
+				So(err, ShouldEqual, apierrors.ErrEditConflict)
+
+				Convey("Then the page is read from the store again", func() {
+					_, err := store.GetPage(ctx, "/aboutus")
+					So(err, ShouldBeNil)
+					So(len(contentStore.GetPageCalls()), ShouldEqual, 2)
+				})
+			})
+
+			Convey("Then other operations go straight to the store", func() {
+				So(store.DeletePage(ctx, "/aboutus"), ShouldBeNil)
+				So(len(contentStore.DeletePageCalls()), ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestProducer(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a cached page and its translation", t, func() {
+		pages := NewPages(10, 0, time.Minute, time.Second)
+		r := &reader{page: testPage("/aboutus", "About us")}
+		for _, lang := range []models.Language{models.LanguageEnglish, models.LanguageWelsh} {
+			_, err := pages.Get(ctx, "/aboutus", lang, r.read)
+			So(err, ShouldBeNil)
+		}
+		eventProducer := &mock.EventProducerMock{
+			ContentPublishedFunc: func(ctx context.Context, e *event.ContentPublished) error { return nil },
+			ContentDeletedFunc:   func(ctx context.Context, e *event.ContentDeleted) error { return nil },
+		}
+		producer := NewProducer(eventProducer, pages)
+
+		Convey("When the page is published", func() {
+			err := producer.ContentPublished(ctx, &event.ContentPublished{URI: "/aboutus", Lang: models.LanguageEnglish})
+
+			Convey("Then the event is sent and the page is removed from the cache in every language", func() {
+				So(err, ShouldBeNil)
+				So(len(eventProducer.ContentPublishedCalls()), ShouldEqual, 1)
+				So(pages.Stats().Entries, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When another page is deleted", func() {
+			err := producer.ContentDeleted(ctx, &event.ContentDeleted{URI: "/economy", Lang: models.LanguageEnglish})
+
+			Convey("Then the event is sent and the page stays cached", func() {
+				So(err, ShouldBeNil)
+				So(len(eventProducer.ContentDeletedCalls()), ShouldEqual, 1)
+				So(pages.Stats().Entries, ShouldEqual, 2)
+			})
+		})
+	})
+}
@@ -12,6 +12,53 @@ type Config struct {
 	GracefulShutdownTimeout    time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
 	HealthCheckInterval        time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
 	HealthCheckCriticalTimeout time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	PublishWarmUpPeriod        time.Duration `envconfig:"PUBLISH_WARM_UP_PERIOD"`
+	PublishRetryInterval       time.Duration `envconfig:"PUBLISH_RETRY_INTERVAL"`
+	ZebedeeURL                 string        `envconfig:"ZEBEDEE_URL"`
+	IdentityCacheSize          int           `envconfig:"IDENTITY_CACHE_SIZE"`
+	IdentityCacheTTL           time.Duration `envconfig:"IDENTITY_CACHE_TTL"`
+	ResolveMaxDepth            int           `envconfig:"RESOLVE_MAX_DEPTH"`
+	DefaultLimit               int           `envconfig:"DEFAULT_LIMIT"`
+	DefaultMaxLimit            int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
+	MoveMaxPages               int           `envconfig:"MOVE_MAX_PAGES"`
+	RedirectsMaxRows           int           `envconfig:"REDIRECTS_MAX_ROWS"`
+	RedirectsMaxBytes          int64         `envconfig:"REDIRECTS_MAX_BYTES"`
+	DownloadCacheSize          int           `envconfig:"DOWNLOAD_CACHE_SIZE"`
+	PageCacheSize              int           `envconfig:"PAGE_CACHE_SIZE"`
+	PageCacheMaxBytes          int64         `envconfig:"PAGE_CACHE_MAX_BYTES"`
+	PageCacheTTL               time.Duration `envconfig:"PAGE_CACHE_TTL"`
+	CacheMaxAge                time.Duration `envconfig:"CACHE_MAX_AGE"`
+	CacheReleaseMaxAge         time.Duration `envconfig:"CACHE_RELEASE_MAX_AGE"`
+	AccessLogEnabled           bool          `envconfig:"ACCESS_LOG_ENABLED"`
+	OTExporter                 string        `envconfig:"OTEL_TRACES_EXPORTER"`
+	OTExporterOTLPEndpoint     string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTServiceName              string        `envconfig:"OTEL_SERVICE_NAME"`
+	MongoConfig                MongoConfig
+	KafkaConfig                KafkaConfig
+}
+
+// MongoConfig contains the config required to connect to MongoDB
+type MongoConfig struct {
+	URI                    string        `envconfig:"MONGODB_URI"                    json:"-"`
+	Database               string        `envconfig:"MONGODB_DATABASE"`
+	PagesCollection        string        `envconfig:"MONGODB_PAGES_COLLECTION"`
+	CollectionsCollection  string        `envconfig:"MONGODB_COLLECTIONS_COLLECTION"`
+	DraftsCollection       string        `envconfig:"MONGODB_DRAFTS_COLLECTION"`
+	VersionsCollection     string        `envconfig:"MONGODB_VERSIONS_COLLECTION"`
+	AuditCollection        string        `envconfig:"MONGODB_AUDIT_COLLECTION"`
+	TranslationsCollection string        `envconfig:"MONGODB_TRANSLATIONS_COLLECTION"`
+	HierarchyCollection    string        `envconfig:"MONGODB_HIERARCHY_COLLECTION"`
+	RedirectsCollection    string        `envconfig:"MONGODB_REDIRECTS_COLLECTION"`
+	ConnectTimeout         time.Duration `envconfig:"MONGODB_CONNECT_TIMEOUT"`
+	QueryTimeout           time.Duration `envconfig:"MONGODB_QUERY_TIMEOUT"`
+}
+
+// KafkaConfig contains the config required to send events to Kafka
+type KafkaConfig struct {
+	Brokers               []string `envconfig:"KAFKA_ADDR"                     json:"-"`
+	Version               string   `envconfig:"KAFKA_VERSION"`
+	ContentPublishedTopic string   `envconfig:"KAFKA_CONTENT_PUBLISHED_TOPIC"`
+	ContentDeletedTopic   string   `envconfig:"KAFKA_CONTENT_DELETED_TOPIC"`
 }
 
 var cfg *Config
@@ -28,6 +75,47 @@ func Get() (*Config, error) {
 		GracefulShutdownTimeout:    5 * time.Second,
 		HealthCheckInterval:        30 * time.Second,
 		HealthCheckCriticalTimeout: 90 * time.Second,
+		PublishWarmUpPeriod:        5 * time.Second,
+		PublishRetryInterval:       10 * time.Second,
+		ZebedeeURL:                 "http://localhost:8082",
+		IdentityCacheSize:          10000,
+		IdentityCacheTTL:           30 * time.Second,
+		ResolveMaxDepth:            3,
+		DefaultLimit:               20,
+		DefaultMaxLimit:            1000,
+		MoveMaxPages:               500,
+		RedirectsMaxRows:           5000,
+		RedirectsMaxBytes:          1 << 20,
+		DownloadCacheSize:          500,
+		PageCacheSize:              10000,
+		PageCacheMaxBytes:          256 << 20,
+		PageCacheTTL:               30 * time.Second,
+		CacheMaxAge:                10 * time.Minute,
+		CacheReleaseMaxAge:         time.Minute,
+		AccessLogEnabled:           true,
+		OTExporter:                 "none",
+		OTExporterOTLPEndpoint:     "localhost:4318",
+		OTServiceName:              "dp-content-api",
+		MongoConfig: MongoConfig{
+			URI:                    "mongodb://localhost:27017",
+			Database:               "content",
+			PagesCollection:        "pages",
+			CollectionsCollection:  "collections",
+			DraftsCollection:       "drafts",
+			VersionsCollection:     "versions",
+			AuditCollection:        "audit",
+			TranslationsCollection: "translations",
+			HierarchyCollection:    "hierarchy",
+			RedirectsCollection:    "redirects",
+			ConnectTimeout:         5 * time.Second,
+			QueryTimeout:           15 * time.Second,
+		},
+		KafkaConfig: KafkaConfig{
+			Brokers:               []string{"localhost:9092"},
+			Version:               "1.0.2",
+			ContentPublishedTopic: "content-published",
+			ContentDeletedTopic:   "content-deleted",
+		},
 	}
 
 	return cfg, envconfig.Process("", cfg)
@@ -28,6 +28,47 @@ func TestConfig(t *testing.T) {
 					GracefulShutdownTimeout:    5 * time.Second,
 					HealthCheckInterval:        30 * time.Second,
 					HealthCheckCriticalTimeout: 90 * time.Second,
+					PublishWarmUpPeriod:        5 * time.Second,
+					PublishRetryInterval:       10 * time.Second,
+					ZebedeeURL:                 "http://localhost:8082",
+					IdentityCacheSize:          10000,
+					IdentityCacheTTL:           30 * time.Second,
+					ResolveMaxDepth:            3,
+					DefaultLimit:               20,
+					DefaultMaxLimit:            1000,
+					MoveMaxPages:               500,
+					RedirectsMaxRows:           5000,
+					RedirectsMaxBytes:          1 << 20,
+					DownloadCacheSize:          500,
+					PageCacheSize:              10000,
+					PageCacheMaxBytes:          256 << 20,
+					PageCacheTTL:               30 * time.Second,
+					CacheMaxAge:                10 * time.Minute,
+					CacheReleaseMaxAge:         time.Minute,
+					AccessLogEnabled:           true,
+					OTExporter:                 "none",
+					OTExporterOTLPEndpoint:     "localhost:4318",
+					OTServiceName:              "dp-content-api",
+					MongoConfig: MongoConfig{
+						URI:                    "mongodb://localhost:27017",
+						Database:               "content",
+						PagesCollection:        "pages",
+						CollectionsCollection:  "collections",
+						DraftsCollection:       "drafts",
+						VersionsCollection:     "versions",
+						AuditCollection:        "audit",
+						TranslationsCollection: "translations",
+						HierarchyCollection:    "hierarchy",
+						RedirectsCollection:    "redirects",
+						ConnectTimeout:         5 * time.Second,
+						QueryTimeout:           15 * time.Second,
+					},
+					KafkaConfig: KafkaConfig{
+						Brokers:               []string{"localhost:9092"},
+						Version:               "1.0.2",
+						ContentPublishedTopic: "content-published",
+						ContentDeletedTopic:   "content-deleted",
+					},
 				})
 			})
 
@@ -0,0 +1,85 @@
+package download
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache keeps generated downloads so that each is only rendered once for each publication of a timeseries. A
+// cached download is stale once its timeseries has been republished, as the last updated time it was generated
+// for no longer matches. When the cache is full, the download that was cached first is evicted.
+type Cache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[cacheKey]*cacheEntry
+	order      []cacheKey
+}
+
+// cacheKey identifies the download of a timeseries in a format
+type cacheKey struct {
+	uri    string
+	format Format
+}
+
+// cacheEntry is a generated download and the last updated time of the timeseries it was generated from
+type cacheEntry struct {
+	lastUpdated time.Time
+	content     []byte
+}
+
+// NewCache returns a cache holding at most maxEntries downloads. Nothing is cached if maxEntries is not positive.
+func NewCache(maxEntries int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		entries:    make(map[cacheKey]*cacheEntry),
+	}
+}
+
+// Get returns the download of the timeseries at the URI in the format, if it was generated from the timeseries as
+// last updated at the time given
+func (c *Cache) Get(uri string, format Format, lastUpdated time.Time) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[cacheKey{uri: uri, format: format}]
+	if !ok || !entry.lastUpdated.Equal(lastUpdated) {
+		return nil, false
+	}
+	return entry.content, true
+}
+
+// Add caches the download of the timeseries at the URI in the format, generated from the timeseries as last
+// updated at the time given. It replaces any download cached for an earlier publication.
+func (c *Cache) Add(uri string, format Format, lastUpdated time.Time, content []byte) {
+	if c.maxEntries <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := cacheKey{uri: uri, format: format}
+	if _, ok := c.entries[key]; !ok {
+		if len(c.order) >= c.maxEntries {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = &cacheEntry{lastUpdated: lastUpdated, content: content}
+}
+
+// Invalidate removes the downloads of the timeseries at the URI in every format
+func (c *Cache) Invalidate(uri string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	order := c.order[:0]
+	for _, key := range c.order {
+		if key.uri == uri {
+			delete(c.entries, key)
+			continue
+		}
+		order = append(order, key)
+	}
+	c.order = order
+}
@@ -0,0 +1,63 @@
+package download
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCache(t *testing.T) {
+	published := time.Date(2021, 3, 17, 7, 0, 0, 0, time.UTC)
+
+	Convey("Given a cache holding two downloads", t, func() {
+		c := NewCache(2)
+		c.Add("/d7g7", FormatCSV, published, []byte("csv"))
+		c.Add("/d7g7", FormatXLSX, published, []byte("xlsx"))
+
+		Convey("Then a download is returned for the publication it was generated from", func() {
+			content, ok := c.Get("/d7g7", FormatCSV, published)
+			So(ok, ShouldBeTrue)
+			So(string(content), ShouldEqual, "csv")
+		})
+
+		Convey("Then a download is stale once the timeseries has been republished", func() {
+			_, ok := c.Get("/d7g7", FormatCSV, published.Add(time.Hour))
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("When another download is cached", func() {
+			c.Add("/l55o", FormatCSV, published, []byte("csv"))
+
+			Convey("Then the download cached first is evicted", func() {
+				_, ok := c.Get("/d7g7", FormatCSV, published)
+				So(ok, ShouldBeFalse)
+				_, ok = c.Get("/l55o", FormatCSV, published)
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the downloads of the timeseries are invalidated", func() {
+			c.Invalidate("/d7g7")
+
+			Convey("Then none are returned, and there is room for others", func() {
+				_, ok := c.Get("/d7g7", FormatXLSX, published)
+				So(ok, ShouldBeFalse)
+				c.Add("/l55o", FormatCSV, published, []byte("csv"))
+				c.Add("/l55o", FormatXLSX, published, []byte("xlsx"))
+				_, ok = c.Get("/l55o", FormatCSV, published)
+				So(ok, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a cache with no room", t, func() {
+		c := NewCache(0)
+		c.Add("/d7g7", FormatCSV, published, []byte("csv"))
+
+		Convey("Then nothing is cached", func() {
+			_, ok := c.Get("/d7g7", FormatCSV, published)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
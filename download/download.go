@@ -0,0 +1,122 @@
+package download
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// Format is a file format that timeseries can be downloaded in
+type Format string
+
+// The formats that timeseries can be downloaded in
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatJSON Format = "json"
+)
+
+// contentTypes are the media types of each format
+var contentTypes = map[Format]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatJSON: "application/json; charset=utf-8",
+}
+
+// releaseDateLayout is the layout of the release date in the metadata of CSV and XLSX downloads, as on the website
+const releaseDateLayout = "02-01-2006"
+
+// ParseFormat returns the format with the name, defaulting to JSON if the name is empty
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return FormatJSON, nil
+	}
+	format := Format(strings.ToLower(name))
+	if _, ok := contentTypes[format]; !ok {
+		return "", apierrors.ErrInvalidDownloadFormat
+	}
+	return format, nil
+}
+
+// ContentType returns the media type of files in the format
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Filename returns the name to save the download of the timeseries as, e.g. d7g7-mm23.csv
+func Filename(d *models.TimeseriesDownload, format Format) string {
+	name := d.CDID
+	if d.DatasetID != "" {
+		name += "-" + d.DatasetID
+	}
+	return strings.ToLower(name) + "." + string(format)
+}
+
+// Render generates the download of the timeseries in the format
+func Render(d *models.TimeseriesDownload, format Format) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		return renderCSV(d)
+	case FormatXLSX:
+		return renderXLSX(d)
+	case FormatJSON:
+		return json.Marshal(d)
+	}
+	return nil, apierrors.ErrInvalidDownloadFormat
+}
+
+// renderCSV writes the metadata rows of the timeseries followed by a row for each observation
+func renderCSV(d *models.TimeseriesDownload) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(metadataRows(d)); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(valueRows(d)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// metadataRows are the label and value of each item of metadata given at the top of a CSV or XLSX download
+func metadataRows(d *models.TimeseriesDownload) [][]string {
+	releaseDate := ""
+	if d.ReleaseDate != nil {
+		releaseDate = d.ReleaseDate.UTC().Format(releaseDateLayout)
+	}
+	rows := [][]string{
+		{"Title", d.Title},
+		{"CDID", d.CDID},
+		{"Source dataset ID", d.DatasetID},
+		{"PreUnit", d.PreUnit},
+		{"Unit", d.Unit},
+		{"Release date", releaseDate},
+		{"Next release", d.NextRelease},
+	}
+	if len(d.Notes) == 0 {
+		return append(rows, []string{"Important notes", ""})
+	}
+	for i, note := range d.Notes {
+		label := ""
+		if i == 0 {
+			label = "Important notes"
+		}
+		rows = append(rows, []string{label, note})
+	}
+	return rows
+}
+
+// valueRows are the date and value of each observation, with the years first, then quarters, then months
+func valueRows(d *models.TimeseriesDownload) [][]string {
+	var rows [][]string
+	for _, values := range [][]models.TimeseriesValue{d.Years, d.Quarters, d.Months} {
+		for _, value := range values {
+			rows = append(rows, []string{value.Date, value.Value})
+		}
+	}
+	return rows
+}
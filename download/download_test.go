@@ -0,0 +1,95 @@
+package download
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testTimeseries() *models.TimeseriesDownload {
+	releaseDate := time.Date(2021, 3, 17, 7, 0, 0, 0, time.UTC)
+	return &models.TimeseriesDownload{
+		Title:       "CPI ANNUAL RATE 00: ALL ITEMS 2015=100",
+		CDID:        "D7G7",
+		DatasetID:   "MM23",
+		Unit:        "%",
+		ReleaseDate: &releaseDate,
+		NextRelease: "21 April 2021",
+		Notes:       []string{"Figures are provisional", "Series rebased in 2015"},
+		Years:       []models.TimeseriesValue{{Date: "2020", Value: "0.9"}},
+		Quarters:    []models.TimeseriesValue{{Date: "2020 Q4", Value: "0.5"}},
+		Months:      []models.TimeseriesValue{{Date: "2021 FEB", Value: "x"}},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	Convey("Then each format is parsed, regardless of case, defaulting to JSON", t, func() {
+		for name, expected := range map[string]Format{"csv": FormatCSV, "XLSX": FormatXLSX, "json": FormatJSON, "": FormatJSON} {
+			format, err := ParseFormat(name)
+			So(err, ShouldBeNil)
+			So(format, ShouldEqual, expected)
+		}
+		_, err := ParseFormat("xls")
+		So(err, ShouldEqual, apierrors.ErrInvalidDownloadFormat)
+	})
+}
+
+func TestRender(t *testing.T) {
+	Convey("Given a timeseries", t, func() {
+		timeseries := testTimeseries()
+
+		Convey("Then its CSV download has the metadata followed by years, quarters and months", func() {
+			csv, err := Render(timeseries, FormatCSV)
+			So(err, ShouldBeNil)
+			So(string(csv), ShouldEqual, `Title,CPI ANNUAL RATE 00: ALL ITEMS 2015=100
+CDID,D7G7
+Source dataset ID,MM23
+PreUnit,
+Unit,%
+Release date,17-03-2021
+Next release,21 April 2021
+Important notes,Figures are provisional
+,Series rebased in 2015
+2020,0.9
+2020 Q4,0.5
+2021 FEB,x
+`)
+			So(Filename(timeseries, FormatCSV), ShouldEqual, "d7g7-mm23.csv")
+		})
+
+		Convey("Then its XLSX download is a workbook with the same rows, with numbers as numbers", func() {
+			xlsx, err := Render(timeseries, FormatXLSX)
+			So(err, ShouldBeNil)
+
+			z, err := zip.NewReader(bytes.NewReader(xlsx), int64(len(xlsx)))
+			So(err, ShouldBeNil)
+			parts := make(map[string]string)
+			for _, f := range z.File {
+				r, err := f.Open()
+				So(err, ShouldBeNil)
+				content, err := ioutil.ReadAll(r)
+				So(err, ShouldBeNil)
+				parts[f.Name] = string(content)
+			}
+			So(parts, ShouldContainKey, "[Content_Types].xml")
+			So(parts, ShouldContainKey, "xl/workbook.xml")
+			sheet := parts["xl/worksheets/sheet1.xml"]
+			So(sheet, ShouldContainSubstring, `<c r="A1" t="inlineStr"><is><t>Title</t></is></c>`)
+			So(sheet, ShouldContainSubstring, `<c r="B10"><v>0.9</v></c>`)
+			So(sheet, ShouldContainSubstring, `<c r="B12" t="inlineStr"><is><t>x</t></is></c>`)
+		})
+
+		Convey("Then its JSON download has its metadata and observations", func() {
+			json, err := Render(timeseries, FormatJSON)
+			So(err, ShouldBeNil)
+			So(string(json), ShouldContainSubstring, `"cdid":"D7G7","dataset_id":"MM23"`)
+			So(string(json), ShouldContainSubstring, `"years":[{"date":"2020","value":"0.9"}]`)
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package download
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// The parts of a workbook with a single worksheet, other than the worksheet itself. They are the least that
+// spreadsheet applications require to open an XLSX file.
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="data" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+)
+
+// renderXLSX writes a workbook with the same rows as the CSV download in a single worksheet. Observation values
+// that are numbers are written as numbers, so that they can be charted and summed.
+func renderXLSX(d *models.TimeseriesDownload) ([]byte, error) {
+	var sheet bytes.Buffer
+	sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	row := 1
+	for _, cells := range metadataRows(d) {
+		writeRow(&sheet, row, cells, false)
+		row++
+	}
+	for _, cells := range valueRows(d) {
+		writeRow(&sheet, row, cells, true)
+		row++
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(xlsxContentTypes)},
+		{"_rels/.rels", []byte(xlsxRels)},
+		{"xl/workbook.xml", []byte(xlsxWorkbook)},
+		{"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+		{"xl/worksheets/sheet1.xml", sheet.Bytes()},
+	}
+	for _, part := range parts {
+		f, err := z.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := z.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeRow writes the cells of a row of the worksheet, in columns A and B. If numeric is true, the value in
+// column B is written as a number when it is one.
+func writeRow(sheet *bytes.Buffer, row int, cells []string, numeric bool) {
+	fmt.Fprintf(sheet, `<row r="%d">`, row)
+	for i, cell := range cells {
+		ref := fmt.Sprintf("%c%d", 'A'+i, row)
+		if numeric && i == 1 && isNumber(cell) {
+			fmt.Fprintf(sheet, `<c r="%s"><v>%s</v></c>`, ref, cell)
+			continue
+		}
+		fmt.Fprintf(sheet, `<c r="%s" t="inlineStr"><is><t>`, ref)
+		xml.EscapeText(sheet, []byte(cell))
+		sheet.WriteString(`</t></is></c>`)
+	}
+	sheet.WriteString(`</row>`)
+}
+
+// isNumber returns true if the value can be written as a number in a worksheet
+func isNumber(value string) bool {
+	f, err := strconv.ParseFloat(value, 64)
+	return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,101 @@
+package event
+
+import (
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/linkedin/goavro/v2"
+)
+
+// ContentPublished is sent when a page, or a translation of it, is published either directly or as part of a collection
+type ContentPublished struct {
+	URI          string
+	Type         models.PageType
+	CollectionID string
+	Lang         models.Language
+	Timestamp    time.Time
+}
+
+// ContentDeleted is sent when a page, or a translation of it, is deleted
+type ContentDeleted struct {
+	URI          string
+	Type         models.PageType
+	CollectionID string
+	Lang         models.Language
+	Timestamp    time.Time
+}
+
+// CollectionPublished returns a content published event for every page in a published collection
+func CollectionPublished(collection *models.Collection) []*ContentPublished {
+	var timestamp time.Time
+	if collection.PublishedAt != nil {
+		timestamp = *collection.PublishedAt
+	}
+
+	events := make([]*ContentPublished, len(collection.Items))
+	for i, item := range collection.Items {
+		events[i] = &ContentPublished{
+			URI:          item.URI,
+			Type:         item.Type,
+			CollectionID: collection.ID,
+			Lang:         models.LanguageEnglish,
+			Timestamp:    timestamp,
+		}
+	}
+	return events
+}
+
+// Marshal encodes the event as Avro
+func (e *ContentPublished) Marshal() ([]byte, error) {
+	return marshal(ContentPublishedCodec, e.URI, e.Type, e.CollectionID, e.Lang, e.Timestamp)
+}
+
+// Marshal encodes the event as Avro
+func (e *ContentDeleted) Marshal() ([]byte, error) {
+	return marshal(ContentDeletedCodec, e.URI, e.Type, e.CollectionID, e.Lang, e.Timestamp)
+}
+
+// UnmarshalContentPublished decodes a content published event from Avro
+func UnmarshalContentPublished(data []byte) (*ContentPublished, error) {
+	uri, pageType, collectionID, lang, timestamp, err := unmarshal(ContentPublishedCodec, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ContentPublished{URI: uri, Type: pageType, CollectionID: collectionID, Lang: lang, Timestamp: timestamp}, nil
+}
+
+// UnmarshalContentDeleted decodes a content deleted event from Avro
+func UnmarshalContentDeleted(data []byte) (*ContentDeleted, error) {
+	uri, pageType, collectionID, lang, timestamp, err := unmarshal(ContentDeletedCodec, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ContentDeleted{URI: uri, Type: pageType, CollectionID: collectionID, Lang: lang, Timestamp: timestamp}, nil
+}
+
+// marshal encodes the fields shared by every content event
+func marshal(codec *goavro.Codec, uri string, pageType models.PageType, collectionID string, lang models.Language, timestamp time.Time) ([]byte, error) {
+	return codec.BinaryFromNative(nil, map[string]interface{}{
+		"uri":           uri,
+		"type":          string(pageType),
+		"collection_id": collectionID,
+		"lang":          string(lang),
+		"timestamp":     timestamp.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// unmarshal decodes the fields shared by every content event
+func unmarshal(codec *goavro.Codec, data []byte) (string, models.PageType, string, models.Language, time.Time, error) {
+	native, _, err := codec.NativeFromBinary(data)
+	if err != nil {
+		return "", "", "", "", time.Time{}, err
+	}
+	fields := native.(map[string]interface{})
+
+	timestamp, err := time.Parse(time.RFC3339Nano, fields["timestamp"].(string))
+	if err != nil {
+		return "", "", "", "", time.Time{}, err
+	}
+	lang := models.Language(fields["lang"].(string))
+	return fields["uri"].(string), models.PageType(fields["type"].(string)), fields["collection_id"].(string), lang, timestamp, nil
+}
@@ -0,0 +1,87 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testTime = time.Date(2021, time.March, 24, 7, 0, 0, 0, time.UTC)
+
+func TestContentPublished(t *testing.T) {
+	Convey("Given a content published event for a Welsh translation", t, func() {
+		e := &event.ContentPublished{
+			URI:          "/economy/inflationandpriceindices",
+			Type:         models.PageTypeStaticPage,
+			CollectionID: "123",
+			Lang:         models.LanguageWelsh,
+			Timestamp:    testTime,
+		}
+
+		Convey("When it is marshalled and unmarshalled", func() {
+			data, err := e.Marshal()
+			So(err, ShouldBeNil)
+			decoded, err := event.UnmarshalContentPublished(data)
+
+			Convey("Then the same event is returned", func() {
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, e)
+			})
+		})
+	})
+
+	Convey("When invalid Avro is unmarshalled", t, func() {
+		_, err := event.UnmarshalContentPublished([]byte{0xff})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestContentDeleted(t *testing.T) {
+	Convey("Given a content deleted event without a collection", t, func() {
+		e := &event.ContentDeleted{
+			URI:       "/economy",
+			Type:      models.PageTypeStaticPage,
+			Lang:      models.LanguageEnglish,
+			Timestamp: testTime,
+		}
+
+		Convey("When it is marshalled and unmarshalled", func() {
+			data, err := e.Marshal()
+			So(err, ShouldBeNil)
+			decoded, err := event.UnmarshalContentDeleted(data)
+
+			Convey("Then the same event is returned", func() {
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, e)
+			})
+		})
+	})
+}
+
+func TestCollectionPublished(t *testing.T) {
+	Convey("Given a published collection containing two pages", t, func() {
+		collection := &models.Collection{
+			ID:          "123",
+			State:       models.CollectionStatePublished,
+			PublishedAt: &testTime,
+			Items: []models.CollectionItem{
+				{URI: "/economy", Type: models.PageTypeStaticPage},
+				{URI: "/economy/bulletin", Type: models.PageTypeBulletin},
+			},
+		}
+
+		Convey("Then an event is returned for each page", func() {
+			events := event.CollectionPublished(collection)
+			So(events, ShouldResemble, []*event.ContentPublished{
+				{URI: "/economy", Type: models.PageTypeStaticPage, CollectionID: "123", Lang: models.LanguageEnglish, Timestamp: testTime},
+				{URI: "/economy/bulletin", Type: models.PageTypeBulletin, CollectionID: "123", Lang: models.LanguageEnglish, Timestamp: testTime},
+			})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+//go:generate moq -out mock/sender.go -pkg mock . Sender
+
+// Sender defines the required methods from the Kafka producer that events are sent with
+type Sender interface {
+	Send(ctx context.Context, topic string, message []byte) error
+}
+
+// Producer encodes content events and sends them to their Kafka topics
+type Producer struct {
+	sender                Sender
+	contentPublishedTopic string
+	contentDeletedTopic   string
+}
+
+// NewProducer returns a producer that sends events to the provided topics
+func NewProducer(sender Sender, contentPublishedTopic, contentDeletedTopic string) *Producer {
+	return &Producer{
+		sender:                sender,
+		contentPublishedTopic: contentPublishedTopic,
+		contentDeletedTopic:   contentDeletedTopic,
+	}
+}
+
+// ContentPublished sends a content published event
+func (p *Producer) ContentPublished(ctx context.Context, e *ContentPublished) error {
+	message, err := e.Marshal()
+	if err != nil {
+		return err
+	}
+
+	log.Event(ctx, "sending content published event", log.INFO, log.Data{"uri": e.URI, "collection_id": e.CollectionID})
+	return p.send(ctx, p.contentPublishedTopic, message)
+}
+
+// ContentDeleted sends a content deleted event
+func (p *Producer) ContentDeleted(ctx context.Context, e *ContentDeleted) error {
+	message, err := e.Marshal()
+	if err != nil {
+		return err
+	}
+
+	log.Event(ctx, "sending content deleted event", log.INFO, log.Data{"uri": e.URI})
+	return p.send(ctx, p.contentDeletedTopic, message)
+}
+
+// send sends the message to the topic within a span, so that the time taken to hand it to Kafka is part of the trace
+// of the request or publish that it was sent for
+func (p *Producer) send(ctx context.Context, topic string, message []byte) (err error) {
+	ctx, span := tracing.Start(ctx, topic+" send",
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKey.String(topic),
+		semconv.MessagingDestinationKindTopic,
+		semconv.MessagingMessagePayloadSizeBytesKey.Int(len(message)),
+	)
+	defer func() { tracing.End(span, err) }()
+	return p.sender.Send(ctx, topic, message)
+}
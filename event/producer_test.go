@@ -0,0 +1,60 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/event/mock"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ctx = context.Background()
+
+func TestProducer(t *testing.T) {
+	Convey("Given a producer", t, func() {
+		sender := &mock.SenderMock{
+			SendFunc: func(ctx context.Context, topic string, message []byte) error { return nil },
+		}
+		p := event.NewProducer(sender, "content-published", "content-deleted")
+
+		Convey("When a content published event is sent", func() {
+			e := &event.ContentPublished{URI: "/economy", Type: models.PageTypeStaticPage, Timestamp: testTime}
+			err := p.ContentPublished(ctx, e)
+
+			Convey("Then it is encoded and sent to the content published topic", func() {
+				So(err, ShouldBeNil)
+				So(sender.SendCalls(), ShouldHaveLength, 1)
+				So(sender.SendCalls()[0].Topic, ShouldEqual, "content-published")
+				decoded, err := event.UnmarshalContentPublished(sender.SendCalls()[0].Message)
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, e)
+			})
+		})
+
+		Convey("When a content deleted event is sent", func() {
+			e := &event.ContentDeleted{URI: "/economy", Type: models.PageTypeStaticPage, Timestamp: testTime}
+			err := p.ContentDeleted(ctx, e)
+
+			Convey("Then it is encoded and sent to the content deleted topic", func() {
+				So(err, ShouldBeNil)
+				So(sender.SendCalls(), ShouldHaveLength, 1)
+				So(sender.SendCalls()[0].Topic, ShouldEqual, "content-deleted")
+				decoded, err := event.UnmarshalContentDeleted(sender.SendCalls()[0].Message)
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, e)
+			})
+		})
+
+		Convey("When the event cannot be sent", func() {
+			errSend := errors.New("kafka is unavailable")
+			sender.SendFunc = func(ctx context.Context, topic string, message []byte) error { return errSend }
+
+			Convey("Then the error is returned", func() {
+				So(p.ContentPublished(ctx, &event.ContentPublished{URI: "/economy"}), ShouldEqual, errSend)
+			})
+		})
+	})
+}
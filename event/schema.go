@@ -0,0 +1,45 @@
+package event
+
+import (
+	"github.com/linkedin/goavro/v2"
+)
+
+// contentPublishedSchema is the Avro schema of the event sent when a page is published
+var contentPublishedSchema = `{
+  "type": "record",
+  "name": "content_published",
+  "fields": [
+    {"name": "uri", "type": "string"},
+    {"name": "type", "type": "string"},
+    {"name": "collection_id", "type": "string", "default": ""},
+    {"name": "lang", "type": "string", "default": "en"},
+    {"name": "timestamp", "type": "string"}
+  ]
+}`
+
+// contentDeletedSchema is the Avro schema of the event sent when a page is deleted
+var contentDeletedSchema = `{
+  "type": "record",
+  "name": "content_deleted",
+  "fields": [
+    {"name": "uri", "type": "string"},
+    {"name": "type", "type": "string"},
+    {"name": "collection_id", "type": "string", "default": ""},
+    {"name": "lang", "type": "string", "default": "en"},
+    {"name": "timestamp", "type": "string"}
+  ]
+}`
+
+// The codecs used to encode and decode events
+var (
+	ContentPublishedCodec = mustCodec(contentPublishedSchema)
+	ContentDeletedCodec   = mustCodec(contentDeletedSchema)
+)
+
+func mustCodec(schema string) *goavro.Codec {
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
@@ -0,0 +1,81 @@
+Feature: Audit
+  Scenario: Changes to published content are audited
+    Given I am a publisher
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Match" header to the ETag of the page at "/aboutus"
+    And I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    And I set the "If-Match" header to the ETag of the page at "/aboutus"
+    And I DELETE "/v1/content/aboutus"
+    Then the HTTP status code should be "204"
+    And the following changes should have been audited:
+      | user                 | action | uri      | collection_id |
+      | publisher@ons.gov.uk | create | /aboutus |               |
+      | publisher@ons.gov.uk | update | /aboutus |               |
+      | publisher@ons.gov.uk | delete | /aboutus |               |
+
+  Scenario: Changes made by a service are audited
+    Given I am authorised
+    When I POST "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "201"
+    And the following changes should have been audited:
+      | user            | action | uri      | collection_id |
+      | dp-test-service | create | /aboutus |               |
+
+  Scenario: Changes to drafts and their publication are audited
+    Given the following collection exists:
+      """
+      {"id": "123", "name": "Inflation"}
+      """
+    And I am a publisher
+    When I PUT "/v1/collections/123/content/economy/inflationandpriceindices"
+      """
+      {"type": "static_page", "description": {"title": "Inflation and price indices"}}
+      """
+    And the draft at "/economy/inflationandpriceindices" in collection "123" is "reviewed"
+    And I set the "If-Match" header to the ETag of collection "123"
+    And I POST "/v1/collections/123/publish"
+      """
+      """
+    Then the HTTP status code should be "200"
+    And the following changes should have been audited:
+      | user                 | action       | uri                                 | collection_id |
+      | publisher@ons.gov.uk | update_draft | /economy/inflationandpriceindices   | 123           |
+      | publisher@ons.gov.uk | publish      | /economy/inflationandpriceindices   | 123           |
+
+  Scenario: Rejected changes are not audited
+    Given I am a viewer
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "403"
+    And the following changes should have been audited:
+      | user | action | uri | collection_id |
+
+  Scenario: Listing the audit records of a page
+    Given I am a publisher
+    And I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I PUT "/v1/content/economy"
+      """
+      {"type": "static_page", "description": {"title": "Economy"}}
+      """
+    When I GET "/v1/audit?uri=/economy"
+    Then the HTTP status code should be "200"
+    And the response header "Content-Type" should be "application/json; charset=utf-8"
+
+  Scenario: Listing the audit records with an invalid time range
+    Given I am a viewer
+    When I GET "/v1/audit?from=yesterday"
+    Then the HTTP status code should be "400"
@@ -0,0 +1,80 @@
+Feature: Authorisation
+  Scenario: Reading published content without authenticating
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+
+  Scenario: Reading published content with a token that is not recognised
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "X-Florence-Token" header to "expired-token"
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+
+  Scenario: Editing content without authenticating
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "401"
+    And there should be no stored page at "/aboutus"
+
+  Scenario: Editing content with a token that is not recognised
+    Given I set the "X-Florence-Token" header to "expired-token"
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "401"
+
+  Scenario: Editing content as a viewer
+    Given I am a viewer
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "403"
+    And there should be no stored page at "/aboutus"
+
+  Scenario: Editing content as a service
+    Given I am authorised
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "201"
+
+  Scenario: Reading a draft as a viewer
+    Given I am a viewer
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    When I GET "/v1/collections/123/content/aboutus"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Reading a draft without authenticating
+    Given the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    When I set the "Collection-Id" header to "123"
+    And I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "401"
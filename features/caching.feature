@@ -0,0 +1,57 @@
+Feature: Caching
+  Scenario: Reading a page that can be cached
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+    And the response header "Cache-Control" should be "public, max-age=600"
+
+  Scenario: Reading a page that has not been modified since the client read it
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Modified-Since" header to "Fri, 01 Jan 2100 00:00:00 GMT"
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "304"
+
+  Scenario: Reading a page that has been modified since the client read it
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Modified-Since" header to "Mon, 01 Jan 2001 00:00:00 GMT"
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+
+  Scenario: Reading a page after it has been replaced
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I GET "/v1/content/aboutus"
+    And I set the "If-Match" header to the ETag of the page at "/aboutus"
+    And I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+    And I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
+
+  Scenario: Reading a page after it has been deleted
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I GET "/v1/content/aboutus"
+    And I DELETE "/v1/content/aboutus"
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "404"
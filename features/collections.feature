@@ -0,0 +1,106 @@
+Feature: Collections
+  Scenario: Creating a collection
+    Given I am a publisher
+    When I POST "/v1/collections"
+      """
+      {"name": "March 2021 inflation"}
+      """
+    Then the HTTP status code should be "201"
+
+  Scenario: Drafting a page in a collection
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    When I PUT "/v1/collections/123/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    Then the HTTP status code should be "200"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About us"}}
+      """
+    And no content events should have been sent
+
+  Scenario: Reading a draft with the collection ID header
+    Given I am a publisher
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    When I set the "Collection-Id" header to "123"
+    And I GET "/v1/content/aboutus"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Publishing a reviewed collection
+    Given I am a publisher
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    And the draft at "/aboutus" in collection "123" is "reviewed"
+    And I set the "If-Match" header to the ETag of collection "123"
+    When I POST "/v1/collections/123/publish"
+      """
+      """
+    Then the HTTP status code should be "200"
+    And the collection "123" should be "published"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
+    And a content published event should have been sent for "/aboutus"
+
+  Scenario: Publishing a collection that has not been reviewed
+    Given I am a publisher
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    And I set the "If-Match" header to the ETag of collection "123"
+    When I POST "/v1/collections/123/publish"
+      """
+      """
+    Then the HTTP status code should be "409"
+    And the collection "123" should be "in_progress"
+    And there should be no stored page at "/aboutus"
+
+  Scenario: Publishing a collection without giving the version that was reviewed
+    Given I am a publisher
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    And the draft at "/aboutus" in collection "123" is "reviewed"
+    When I POST "/v1/collections/123/publish"
+      """
+      """
+    Then the HTTP status code should be "428"
+    And the collection "123" should be "in_progress"
+    And there should be no stored page at "/aboutus"
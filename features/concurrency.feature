@@ -0,0 +1,58 @@
+Feature: Concurrent edits
+  Scenario: Saving a draft that was changed after it was read
+    Given I am a publisher
+    And the following collection exists:
+      """
+      {"id": "123", "name": "March 2021 inflation"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Match" header to the ETag of the draft at "/aboutus" in collection "123"
+    And I PUT "/v1/collections/123/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    When I PUT "/v1/collections/123/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the Office for National Statistics"}}
+      """
+    Then the HTTP status code should be "412"
+    And I should receive the following JSON response:
+      """
+      {"errors": [{"code": "PreconditionFailed", "description": "If-Match does not match the ETag of the current version"}]}
+      """
+
+  Scenario: Replacing a page without giving the version being replaced
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    Then the HTTP status code should be "428"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About us"}}
+      """
+
+  Scenario: Replacing a page with the version being replaced
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Match" header to the ETag of the page at "/aboutus"
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    Then the HTTP status code should be "200"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
@@ -0,0 +1,114 @@
+Feature: Content
+  Scenario: Creating a page
+    Given I am a publisher
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "201"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About us"}}
+      """
+    And a content published event should have been sent for "/aboutus"
+
+  Scenario: Reading a page
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I GET "/v1/content/aboutus"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About us"}}
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Reading a page that does not exist
+    When I GET "/v1/content/economy/doesnotexist"
+    Then I should receive the following JSON response:
+      """
+      {"errors": [{"code": "PageNotFound", "description": "page not found"}]}
+      """
+    And the HTTP status code should be "404"
+
+  Scenario: Replacing a page
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Match" header to the ETag of the page at "/aboutus"
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    Then the HTTP status code should be "200"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
+
+  Scenario: Creating a page that already exists
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I POST "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    Then the HTTP status code should be "409"
+    And the stored page at "/aboutus" should equal:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About us"}}
+      """
+    And no content events should have been sent
+
+  Scenario: Creating a page that does not match its declared type
+    Given I am a publisher
+    When I PUT "/v1/content/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021"
+      """
+      {"type": "bulletin", "description": {"title": "Consumer price inflation, UK: February 2021"}, "sections": [{"markdown": "Main points"}]}
+      """
+    Then I should receive the following JSON response:
+      """
+      {
+        "errors": [
+          {"code": "ValidationFailed", "field": "description.releaseDate", "description": "is required"},
+          {"code": "ValidationFailed", "field": "sections[0].title", "description": "is required"}
+        ]
+      }
+      """
+    And the HTTP status code should be "400"
+    And there should be no stored page at "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021"
+
+  Scenario: Deleting a page
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I DELETE "/v1/content/aboutus"
+    Then the HTTP status code should be "204"
+    And there should be no stored page at "/aboutus"
+    And a content deleted event should have been sent for "/aboutus"
+
+  Scenario: Reading a previous version of a corrected page
+    Given I am a publisher
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "If-Match" header to the ETag of the page at "/aboutus"
+    And I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    When I GET "/v1/content/aboutus/previous/v1"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About us"}}
+      """
+    And the HTTP status code should be "200"
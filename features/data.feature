@@ -0,0 +1,46 @@
+Feature: Legacy Zebedee data endpoint
+  Scenario: Reading a page in the Zebedee format
+    Given the following page exists at "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021":
+      """
+      {
+        "type": "bulletin",
+        "description": {"title": "Consumer price inflation, UK: February 2021", "releaseDate": "2021-03-24T07:00:00Z"},
+        "relatedData": [{"uri": "/economy/inflationandpriceindices/datasets/consumerpriceinflation"}]
+      }
+      """
+    And the following page exists at "/economy/inflationandpriceindices/datasets/consumerpriceinflation":
+      """
+      {"type": "dataset_landing_page", "description": {"title": "Consumer price inflation", "releaseDate": "2021-03-24T07:00:00Z"}}
+      """
+    When I GET "/data?uri=/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021&resolveReferences"
+    Then I should receive the following JSON response:
+      """
+      {
+        "type": "bulletin",
+        "uri": "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021",
+        "description": {"title": "Consumer price inflation, UK: February 2021", "releaseDate": "2021-03-24T07:00:00.000Z"},
+        "relatedData": [{"uri": "/economy/inflationandpriceindices/datasets/consumerpriceinflation", "title": "Consumer price inflation"}]
+      }
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Reading a draft from a collection in the Zebedee format
+    Given the following collection exists:
+      """
+      {"id": "123", "name": "About us"}
+      """
+    And the following draft exists at "/aboutus" in collection "123":
+      """
+      {"type": "static_page", "description": {"title": "About the ONS"}}
+      """
+    And I am a viewer
+    When I GET "/data/123?uri=/aboutus"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "About the ONS"}}
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Reading a page that does not exist
+    When I GET "/data?uri=/aboutus"
+    Then the HTTP status code should be "404"
@@ -0,0 +1,48 @@
+Feature: Page hierarchy
+  Background:
+    Given the following page exists at "/economy":
+      """
+      {"type": "static_page", "description": {"title": "Economy"}}
+      """
+    And the following page exists at "/economy/inflation":
+      """
+      {"type": "static_page", "description": {"title": "Inflation"}}
+      """
+    And the following page exists at "/economy/inflation/cpi":
+      """
+      {"type": "static_page", "description": {"title": "Consumer price inflation"}}
+      """
+
+  Scenario: Reading the breadcrumb of a page
+    When I GET "/v1/content-actions/breadcrumb/economy/inflation/cpi"
+    Then I should receive the following JSON response:
+      """
+      {
+        "count": 2,
+        "items": [
+          {"uri": "/economy", "type": "static_page", "title": "Economy"},
+          {"uri": "/economy/inflation", "type": "static_page", "title": "Inflation"}
+        ]
+      }
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Moving a subtree of pages
+    Given I am a publisher
+    When I POST "/v1/content-actions/move/economy/inflation"
+      """
+      {"destination": "/economy/prices"}
+      """
+    Then the HTTP status code should be "200"
+    And the stored page at "/economy/prices/cpi" should equal:
+      """
+      {"type": "static_page", "uri": "/economy/prices/cpi", "description": {"title": "Consumer price inflation"}}
+      """
+    And there should be no stored page at "/economy/inflation"
+    And a content deleted event should have been sent for "/economy/inflation/cpi"
+    And a content published event should have been sent for "/economy/prices/cpi"
+    When I GET "/v1/content-actions/children/economy"
+    Then I should receive the following JSON response:
+      """
+      {"count": 1, "items": [{"uri": "/economy/prices", "type": "static_page", "title": "Inflation"}]}
+      """
@@ -0,0 +1,9 @@
+Feature: Metrics
+  Scenario: Reading the metrics of the service
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I GET "/v1/content/aboutus"
+    When I GET "/metrics"
+    Then the HTTP status code should be "200"
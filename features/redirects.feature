@@ -0,0 +1,48 @@
+Feature: Redirects
+  Background:
+    Given the following page exists at "/economy/inflation":
+      """
+      {"type": "static_page", "description": {"title": "Inflation"}}
+      """
+
+  Scenario: Requesting a URI that redirects to a page
+    Given I am a publisher
+    And I PUT "/v1/redirects/inflation"
+      """
+      {"to": "/economy/inflation"}
+      """
+    And the HTTP status code should be "201"
+    When I GET "/v1/content/inflation?lang=cy"
+    Then the HTTP status code should be "301"
+    And the response header "Location" should be "/v1/content/economy/inflation?lang=cy"
+
+  Scenario: Requesting the old URI of a moved page
+    Given I am a publisher
+    And I POST "/v1/content-actions/move/economy/inflation"
+      """
+      {"destination": "/economy/prices"}
+      """
+    When I GET "/v1/content/economy/inflation"
+    Then the HTTP status code should be "301"
+    And the response header "Location" should be "/v1/content/economy/prices"
+
+  Scenario: Creating a redirect from a URI that has a page
+    Given I am a publisher
+    When I PUT "/v1/redirects/economy/inflation"
+      """
+      {"to": "/economy"}
+      """
+    Then the HTTP status code should be "409"
+
+  Scenario: Uploading redirects as CSV
+    Given I am a publisher
+    When I POST "/v1/redirects"
+      """
+      from,to
+      /inflation,/economy/inflation
+      /cpi,/inflation
+      """
+    Then the HTTP status code should be "200"
+    When I GET "/v1/content/cpi"
+    Then the HTTP status code should be "301"
+    And the response header "Location" should be "/v1/content/economy/inflation"
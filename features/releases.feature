@@ -0,0 +1,91 @@
+Feature: Release calendar
+  Background:
+    Given the following page exists at "/releases/cpi":
+      """
+      {"type": "release", "description": {"title": "Consumer price inflation", "releaseDate": "2021-04-21T06:00:00Z", "finalised": true}}
+      """
+    And the following page exists at "/releases/gdp":
+      """
+      {"type": "release", "description": {"title": "GDP", "releaseDate": "2021-05-12T06:00:00Z", "provisionalDate": "May 2021"}}
+      """
+
+  Scenario: Listing upcoming releases
+    When I GET "/v1/releases?from=2021-05-01T00:00:00Z"
+    Then I should receive the following JSON response:
+      """
+      {
+        "count": 1,
+        "offset": 0,
+        "limit": 20,
+        "total_count": 1,
+        "items": [
+          {
+            "uri": "/releases/gdp",
+            "title": "GDP",
+            "status": "provisional",
+            "release_date": "2021-05-12T06:00:00Z",
+            "provisional_date": "May 2021",
+            "national_statistic": false
+          }
+        ]
+      }
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Postponing a release
+    Given I am a publisher
+    And I set the "If-Match" header to the ETag of the page at "/releases/cpi"
+    When I POST "/v1/content-actions/postpone/releases/cpi"
+      """
+      {"release_date": "2021-04-28T06:00:00Z", "reason": "To allow further quality assurance"}
+      """
+    Then the HTTP status code should be "200"
+    And the stored page at "/releases/cpi" should equal:
+      """
+      {
+        "type": "release",
+        "uri": "/releases/cpi",
+        "description": {"title": "Consumer price inflation", "releaseDate": "2021-04-28T06:00:00Z", "finalised": true},
+        "dateChanges": [{"previousDate": "2021-04-21T06:00:00Z", "changeNotice": "To allow further quality assurance"}]
+      }
+      """
+    And a content published event should have been sent for "/releases/cpi"
+
+  Scenario: Cancelling a release
+    Given I am a publisher
+    And I set the "If-Match" header to the ETag of the page at "/releases/gdp"
+    When I POST "/v1/content-actions/cancel/releases/gdp"
+      """
+      {"reason": "Merged into the quarterly national accounts release"}
+      """
+    Then the HTTP status code should be "200"
+    When I GET "/v1/releases?status=cancelled"
+    Then I should receive the following JSON response:
+      """
+      {
+        "count": 1,
+        "offset": 0,
+        "limit": 20,
+        "total_count": 1,
+        "items": [
+          {
+            "uri": "/releases/gdp",
+            "title": "GDP",
+            "status": "cancelled",
+            "release_date": "2021-05-12T06:00:00Z",
+            "provisional_date": "May 2021",
+            "national_statistic": false,
+            "cancellation_notice": ["Merged into the quarterly national accounts release"]
+          }
+        ]
+      }
+      """
+
+  Scenario: Postponing a release without a reason
+    Given I am a publisher
+    And I set the "If-Match" header to the ETag of the page at "/releases/cpi"
+    When I POST "/v1/content-actions/postpone/releases/cpi"
+      """
+      {"release_date": "2021-04-28T06:00:00Z"}
+      """
+    Then the HTTP status code should be "400"
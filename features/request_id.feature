@@ -0,0 +1,10 @@
+Feature: Request IDs
+  Scenario: Reading a page with a request ID
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "X-Request-Id" header to "c2e8a7f4b1d94e01"
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+    And the response header "X-Request-Id" should be "c2e8a7f4b1d94e01"
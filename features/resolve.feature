@@ -0,0 +1,37 @@
+Feature: Resolving references
+  Background:
+    Given the following page exists at "/methodology":
+      """
+      {"type": "static_methodology", "description": {"title": "Methodology"}, "links": [{"uri": "/aboutus"}, {"uri": "/economy"}]}
+      """
+    And the following page exists at "/aboutus":
+      """
+      {"type": "static_methodology", "description": {"title": "About us"}, "links": [{"uri": "/methodology"}]}
+      """
+
+  Scenario: Reading a page with its references resolved
+    When I GET "/v1/content/methodology?resolve=true&depth=2"
+    Then I should receive the following JSON response:
+      """
+      {
+        "type": "static_methodology",
+        "uri": "/methodology",
+        "description": {"title": "Methodology"},
+        "links": [
+          {
+            "uri": "/aboutus",
+            "resolved": {
+              "type": "static_methodology",
+              "title": "About us",
+              "links": [{"uri": "/methodology", "resolved": {"type": "static_methodology", "title": "Methodology", "cycle": true}}]
+            }
+          },
+          {"uri": "/economy", "resolved": {"error": "page not found"}}
+        ]
+      }
+      """
+    And the HTTP status code should be "200"
+
+  Scenario: Resolving references beyond the maximum depth
+    When I GET "/v1/content/methodology?resolve=true&depth=10"
+    Then the HTTP status code should be "400"
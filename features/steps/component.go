@@ -0,0 +1,309 @@
+package steps
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/mongo"
+	"github.com/ONSdigital/dp-content-api/service"
+	"github.com/ONSdigital/dp-content-api/service/mock"
+
+	componenttest "github.com/ONSdigital/dp-component-test"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Component runs the service against a store, a recording Kafka producer and a fake identity client, which the steps
+// seed and inspect through the hooks below. The store is in memory, unless a MongoDB feature is given to run the
+// service against the MongoDB store.
+type Component struct {
+	componenttest.ErrorFeature
+	svcList        *service.ExternalServiceList
+	svc            *service.Service
+	errorChan      chan error
+	mongoFeature   *componenttest.MongoFeature
+	Config         *config.Config
+	ContentStore   service.MongoDB
+	KafkaProducer  *mock.KafkaProducerMock
+	IdentityClient *auth.FakeIdentityClient
+	Spans          *tracetest.InMemoryExporter
+	HTTPServer     *http.Server
+	ServiceRunning bool
+	apiFeature     *componenttest.APIFeature
+}
+
+func NewComponent(mongoFeature *componenttest.MongoFeature) (*Component, error) {
+
+	c := &Component{
+		HTTPServer:     &http.Server{},
+		errorChan:      make(chan error),
+		mongoFeature:   mongoFeature,
+		ServiceRunning: false,
+		KafkaProducer:  newKafkaProducer(),
+		IdentityClient: newIdentityClient(),
+		Spans:          tracetest.NewInMemoryExporter(),
+	}
+
+	var err error
+
+	c.Config, err = config.Get()
+	if err != nil {
+		return nil, err
+	}
+	if mongoFeature != nil {
+		cfg := *c.Config
+		cfg.MongoConfig.Database = mongoFeature.Database.Name()
+		c.Config = &cfg
+	}
+
+	initMock := &mock.InitialiserMock{
+		DoGetHealthCheckFunc:    c.DoGetHealthcheckOk,
+		DoGetHTTPServerFunc:     c.DoGetHTTPServer,
+		DoGetMongoDBFunc:        c.DoGetMongoDB,
+		DoGetKafkaProducerFunc:  c.DoGetKafkaProducer,
+		DoGetIdentityClientFunc: c.DoGetIdentityClient,
+		DoGetTracerProviderFunc: c.DoGetTracerProvider,
+	}
+
+	c.svcList = service.NewServiceList(initMock)
+
+	c.apiFeature = componenttest.NewAPIFeature(c.InitialiseService)
+
+	return c, nil
+}
+
+func (c *Component) Reset() error {
+	c.apiFeature.Reset()
+	c.KafkaProducer = newKafkaProducer()
+	c.IdentityClient = newIdentityClient()
+	c.Spans = tracetest.NewInMemoryExporter()
+
+	var err error
+	c.ContentStore, err = c.newStore()
+	return err
+}
+
+// newStore returns an empty store for a scenario, dropping everything stored in MongoDB by earlier scenarios when
+// the service is run against it
+func (c *Component) newStore() (service.MongoDB, error) {
+	if c.mongoFeature == nil {
+		return memory.New(), nil
+	}
+	if err := c.mongoFeature.Reset(); err != nil {
+		return nil, err
+	}
+	return mongo.New(context.Background(), c.Config.MongoConfig)
+}
+
+func (c *Component) Close() error {
+	if c.svc != nil && c.ServiceRunning {
+		c.svc.Close(context.Background())
+		c.ServiceRunning = false
+		return nil
+	}
+	// the store is closed by the service if it was run, and otherwise must be closed here
+	if c.ContentStore != nil {
+		return c.ContentStore.Close(context.Background())
+	}
+	return nil
+}
+
+func (c *Component) InitialiseService() (http.Handler, error) {
+	var err error
+	c.svc, err = service.Run(context.Background(), c.Config, c.svcList, "1", "", "", c.errorChan)
+	if err != nil {
+		return nil, err
+	}
+
+	// spans recorded while the service started, such as reloading the schedule, are not part of any request
+	c.Spans.Reset()
+
+	c.ServiceRunning = true
+	return c.HTTPServer.Handler, nil
+}
+
+// SeedPage stores a page at the URI in the same form as if it had been PUT to the API
+func (c *Component) SeedPage(uri string, body []byte) error {
+	page, err := newPage(uri, body)
+	if err != nil {
+		return err
+	}
+	_, err = c.ContentStore.UpsertPage(context.Background(), page)
+	return err
+}
+
+// SeedTranslation stores the Welsh translation of the page at the URI in the same form as if it had been PUT to the API
+func (c *Component) SeedTranslation(uri string, body []byte) error {
+	page, err := models.NewTranslation(models.CleanURI(uri), models.LanguageWelsh, body, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	_, err = c.ContentStore.UpsertTranslation(context.Background(), models.LanguageWelsh, page)
+	return err
+}
+
+// SeedCollection stores a collection
+func (c *Component) SeedCollection(collection *models.Collection) error {
+	if collection.State == "" {
+		collection.State = models.CollectionStateInProgress
+	}
+	return c.ContentStore.CreateCollection(context.Background(), collection)
+}
+
+// SeedDraftPage stores a draft of the page at the URI in a collection
+func (c *Component) SeedDraftPage(collectionID, uri string, body []byte) error {
+	page, err := newPage(uri, body)
+	if err != nil {
+		return err
+	}
+	return c.ContentStore.UpsertDraftPage(context.Background(), collectionID, page)
+}
+
+// StoredPage returns the published page stored at the URI
+func (c *Component) StoredPage(uri string) (*models.Page, error) {
+	return c.ContentStore.GetPage(context.Background(), models.CleanURI(uri))
+}
+
+// StoredTranslation returns the Welsh translation of the page stored at the URI
+func (c *Component) StoredTranslation(uri string) (*models.Page, error) {
+	return c.ContentStore.GetTranslation(context.Background(), models.CleanURI(uri), models.LanguageWelsh)
+}
+
+// StoredDraftPage returns the draft of the page at the URI stored in the collection
+func (c *Component) StoredDraftPage(collectionID, uri string) (*models.Page, error) {
+	return c.ContentStore.GetDraftPage(context.Background(), collectionID, models.CleanURI(uri))
+}
+
+// StoredCollection returns the stored collection
+func (c *Component) StoredCollection(id string) (*models.Collection, error) {
+	return c.ContentStore.GetCollection(context.Background(), id)
+}
+
+// AuditRecords returns every audit record made, oldest first
+func (c *Component) AuditRecords() ([]*models.AuditRecord, error) {
+	records, _, err := c.ContentStore.GetAuditRecords(context.Background(), models.AuditFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	return records, nil
+}
+
+// SentContentPublishedEvents returns every content published event sent to Kafka
+func (c *Component) SentContentPublishedEvents() ([]*event.ContentPublished, error) {
+	var events []*event.ContentPublished
+	for _, message := range c.sentMessages(c.Config.KafkaConfig.ContentPublishedTopic) {
+		e, err := event.UnmarshalContentPublished(message)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// SentContentDeletedEvents returns every content deleted event sent to Kafka
+func (c *Component) SentContentDeletedEvents() ([]*event.ContentDeleted, error) {
+	var events []*event.ContentDeleted
+	for _, message := range c.sentMessages(c.Config.KafkaConfig.ContentDeletedTopic) {
+		e, err := event.UnmarshalContentDeleted(message)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func (c *Component) sentMessages(topic string) [][]byte {
+	var messages [][]byte
+	for _, call := range c.KafkaProducer.SendCalls() {
+		if call.Topic == topic {
+			messages = append(messages, call.Message)
+		}
+	}
+	return messages
+}
+
+func (c *Component) DoGetHealthcheckOk(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
+	return &mock.HealthCheckerMock{
+		AddCheckFunc: func(name string, checker healthcheck.Checker) error { return nil },
+		StartFunc:    func(ctx context.Context) {},
+		StopFunc:     func() {},
+	}, nil
+}
+
+func (c *Component) DoGetHTTPServer(bindAddr string, router http.Handler) service.HTTPServer {
+	c.HTTPServer.Addr = bindAddr
+	c.HTTPServer.Handler = router
+	return c.HTTPServer
+}
+
+func (c *Component) DoGetMongoDB(ctx context.Context, cfg *config.Config) (service.MongoDB, error) {
+	return c.ContentStore, nil
+}
+
+func (c *Component) DoGetKafkaProducer(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+	return c.KafkaProducer, nil
+}
+
+func (c *Component) DoGetIdentityClient(cfg *config.Config) service.IdentityClient {
+	return c.IdentityClient
+}
+
+// DoGetTracerProvider returns a provider that records every span in memory as soon as it ends, so that the steps
+// can inspect the spans of a request once it has been answered
+func (c *Component) DoGetTracerProvider(ctx context.Context, cfg *config.Config) (service.TracerProvider, error) {
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(c.Spans)), nil
+}
+
+// newKafkaProducer returns a producer that accepts every message, recording it in its calls
+func newKafkaProducer() *mock.KafkaProducerMock {
+	return &mock.KafkaProducerMock{
+		SendFunc:    func(ctx context.Context, topic string, message []byte) error { return nil },
+		CheckerFunc: func(ctx context.Context, state *healthcheck.CheckState) error { return nil },
+		CloseFunc:   func(ctx context.Context) error { return nil },
+	}
+}
+
+// newIdentityClient returns an identity client that recognises the tokens of a publisher, a viewer and the
+// service token set by the "I am authorised" step
+func newIdentityClient() *auth.FakeIdentityClient {
+	return auth.NewFakeIdentityClient(map[string]*auth.Identity{
+		publisherToken:  {ID: "publisher@ons.gov.uk", Role: auth.RolePublisher},
+		viewerToken:     {ID: "viewer@ons.gov.uk", Role: auth.RoleViewer},
+		"SomeFakeToken": {ID: "dp-test-service", Service: true, Role: auth.RolePublisher},
+	})
+}
+
+// newPage returns the page for a body, stamped with the URI it is stored at as the API does
+func newPage(uri string, body []byte) (*models.Page, error) {
+	uri = models.CleanURI(uri)
+	content, err := models.ParseContent(body)
+	if err != nil {
+		return nil, err
+	}
+	data, err := models.UpdateContent(body, func(content models.Content) error {
+		content.Base().URI = uri
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page{
+		URI:         uri,
+		Type:        content.Base().Type,
+		Data:        data,
+		LastUpdated: time.Now().UTC(),
+	}, nil
+}
@@ -1,23 +1,223 @@
 package steps
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
 	"github.com/cucumber/godog"
 	"github.com/stretchr/testify/assert"
-	"io/ioutil"
-	"strings"
+)
+
+// The Florence tokens the fake identity client recognises
+const (
+	publisherToken = "publisher-token"
+	viewerToken    = "viewer-token"
 )
 
 func (c *Component) RegisterSteps(ctx *godog.ScenarioContext) {
 	c.apiFeature.RegisterSteps(ctx)
 
-	ctx.Step(`^I should receive a hello-world response$`, c.iShouldReceiveAHelloworldResponse)
+	ctx.Step(`^I am a publisher$`, c.iAmAPublisher)
+	ctx.Step(`^I am a viewer$`, c.iAmAViewer)
+
+	ctx.Step(`^the following page exists at "([^"]*)":$`, c.theFollowingPageExistsAt)
+	ctx.Step(`^the following Welsh translation exists at "([^"]*)":$`, c.theFollowingWelshTranslationExistsAt)
+	ctx.Step(`^the following collection exists:$`, c.theFollowingCollectionExists)
+	ctx.Step(`^the following draft exists at "([^"]*)" in collection "([^"]*)":$`, c.theFollowingDraftExistsAtInCollection)
+	ctx.Step(`^the draft at "([^"]*)" in collection "([^"]*)" is "([^"]*)"$`, c.theDraftAtInCollectionIs)
+	ctx.Step(`^I set the "If-Match" header to the ETag of the page at "([^"]*)"$`, c.iSetTheIfMatchHeaderToTheETagOfThePageAt)
+	ctx.Step(`^I set the "If-Match" header to the ETag of the draft at "([^"]*)" in collection "([^"]*)"$`, c.iSetTheIfMatchHeaderToTheETagOfTheDraftAtInCollection)
+	ctx.Step(`^I set the "If-Match" header to the ETag of collection "([^"]*)"$`, c.iSetTheIfMatchHeaderToTheETagOfCollection)
+	ctx.Step(`^the stored page at "([^"]*)" should equal:$`, c.theStoredPageAtShouldEqual)
+	ctx.Step(`^there should be no stored page at "([^"]*)"$`, c.thereShouldBeNoStoredPageAt)
+	ctx.Step(`^the stored Welsh translation at "([^"]*)" should equal:$`, c.theStoredWelshTranslationAtShouldEqual)
+	ctx.Step(`^the collection "([^"]*)" should be "([^"]*)"$`, c.theCollectionShouldBe)
+	ctx.Step(`^a content published event should have been sent for "([^"]*)"$`, c.aContentPublishedEventShouldHaveBeenSentFor)
+	ctx.Step(`^a content deleted event should have been sent for "([^"]*)"$`, c.aContentDeletedEventShouldHaveBeenSentFor)
+	ctx.Step(`^no content events should have been sent$`, c.noContentEventsShouldHaveBeenSent)
+	ctx.Step(`^the following changes should have been audited:$`, c.theFollowingChangesShouldHaveBeenAudited)
+	ctx.Step(`^a span named "([^"]*)" should have been recorded$`, c.aSpanNamedShouldHaveBeenRecorded)
+	ctx.Step(`^every span should be in the trace "([^"]*)"$`, c.everySpanShouldBeInTheTrace)
+}
+
+func (c *Component) iAmAPublisher() error {
+	return c.apiFeature.ISetTheHeaderTo("X-Florence-Token", publisherToken)
+}
+
+func (c *Component) iAmAViewer() error {
+	return c.apiFeature.ISetTheHeaderTo("X-Florence-Token", viewerToken)
+}
+
+func (c *Component) theFollowingPageExistsAt(uri string, body *godog.DocString) error {
+	return c.SeedPage(uri, []byte(body.Content))
+}
+
+func (c *Component) theFollowingWelshTranslationExistsAt(uri string, body *godog.DocString) error {
+	return c.SeedTranslation(uri, []byte(body.Content))
+}
+
+func (c *Component) theFollowingCollectionExists(body *godog.DocString) error {
+	var collection models.Collection
+	if err := json.Unmarshal([]byte(body.Content), &collection); err != nil {
+		return err
+	}
+	return c.SeedCollection(&collection)
+}
+
+func (c *Component) theFollowingDraftExistsAtInCollection(uri, collectionID string, body *godog.DocString) error {
+	return c.SeedDraftPage(collectionID, uri, []byte(body.Content))
+}
+
+func (c *Component) theDraftAtInCollectionIs(uri, collectionID, state string) error {
+	return c.ContentStore.UpdateItemState(context.Background(), collectionID, models.CleanURI(uri), models.ItemState(state))
+}
+
+func (c *Component) iSetTheIfMatchHeaderToTheETagOfThePageAt(uri string) error {
+	page, err := c.StoredPage(uri)
+	if err != nil {
+		return err
+	}
+	return c.apiFeature.ISetTheHeaderTo("If-Match", page.ETag())
+}
+
+func (c *Component) iSetTheIfMatchHeaderToTheETagOfTheDraftAtInCollection(uri, collectionID string) error {
+	page, err := c.StoredDraftPage(collectionID, uri)
+	if err != nil {
+		return err
+	}
+	return c.apiFeature.ISetTheHeaderTo("If-Match", page.ETag())
+}
+
+func (c *Component) iSetTheIfMatchHeaderToTheETagOfCollection(id string) error {
+	collection, err := c.StoredCollection(id)
+	if err != nil {
+		return err
+	}
+	return c.apiFeature.ISetTheHeaderTo("If-Match", collection.ETag())
+}
+
+func (c *Component) theStoredPageAtShouldEqual(uri string, expected *godog.DocString) error {
+	page, err := c.StoredPage(uri)
+	if err != nil {
+		return err
+	}
+
+	assert.JSONEq(c, expected.Content, string(page.Data))
+	return c.StepError()
+}
+
+func (c *Component) theStoredWelshTranslationAtShouldEqual(uri string, expected *godog.DocString) error {
+	page, err := c.StoredTranslation(uri)
+	if err != nil {
+		return err
+	}
+
+	assert.JSONEq(c, expected.Content, string(page.Data))
+	return c.StepError()
+}
+
+func (c *Component) thereShouldBeNoStoredPageAt(uri string) error {
+	_, err := c.StoredPage(uri)
+	if err != apierrors.ErrPageNotFound {
+		return fmt.Errorf("expected no page at %s, got error: %v", uri, err)
+	}
+	return nil
+}
+
+func (c *Component) theCollectionShouldBe(id, state string) error {
+	collection, err := c.StoredCollection(id)
+	if err != nil {
+		return err
+	}
+
+	assert.Equal(c, state, string(collection.State))
+	return c.StepError()
+}
+
+func (c *Component) aContentPublishedEventShouldHaveBeenSentFor(uri string) error {
+	events, err := c.SentContentPublishedEvents()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if e.URI == uri {
+			return nil
+		}
+	}
+	return fmt.Errorf("no content published event was sent for %s", uri)
+}
+
+func (c *Component) aSpanNamedShouldHaveBeenRecorded(name string) error {
+	for _, span := range c.Spans.GetSpans() {
+		if span.Name == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("no span named %s was recorded", name)
+}
+
+func (c *Component) everySpanShouldBeInTheTrace(traceID string) error {
+	spans := c.Spans.GetSpans()
+	if len(spans) == 0 {
+		return fmt.Errorf("no spans were recorded")
+	}
+	for _, span := range spans {
+		if id := span.SpanContext.TraceID().String(); id != traceID {
+			return fmt.Errorf("span %s is in the trace %s, not %s", span.Name, id, traceID)
+		}
+	}
+	return nil
+}
+
+func (c *Component) aContentDeletedEventShouldHaveBeenSentFor(uri string) error {
+	events, err := c.SentContentDeletedEvents()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if e.URI == uri {
+			return nil
+		}
+	}
+	return fmt.Errorf("no content deleted event was sent for %s", uri)
+}
+
+func (c *Component) noContentEventsShouldHaveBeenSent() error {
+	assert.Empty(c, c.KafkaProducer.SendCalls())
+	return c.StepError()
 }
 
-func (c *Component) iShouldReceiveAHelloworldResponse() error {
-	responseBody := c.apiFeature.HttpResponse.Body
-	body, _ := ioutil.ReadAll(responseBody)
+// theFollowingChangesShouldHaveBeenAudited compares the audit records made, oldest first, with a table
+// of the user, action, uri and collection_id of each
+func (c *Component) theFollowingChangesShouldHaveBeenAudited(table *godog.Table) error {
+	records, err := c.AuditRecords()
+	if err != nil {
+		return err
+	}
 
-	assert.Equal(c, `{"message":"Hello, World!"}`, strings.TrimSpace(string(body)))
+	var expected, actual []map[string]string
+	header := table.Rows[0].Cells
+	for _, row := range table.Rows[1:] {
+		fields := make(map[string]string)
+		for i, cell := range row.Cells {
+			fields[header[i].Value] = cell.Value
+		}
+		expected = append(expected, fields)
+	}
+	for _, record := range records {
+		actual = append(actual, map[string]string{
+			"user":          record.User,
+			"action":        string(record.Action),
+			"uri":           record.URI,
+			"collection_id": record.CollectionID,
+		})
+	}
 
+	assert.Equal(c, expected, actual)
 	return c.StepError()
 }
@@ -0,0 +1,58 @@
+Feature: Timeseries data
+  Background:
+    Given the following page exists at "/economy/inflation/timeseries/d7g7/mm23":
+      """
+      {
+        "type": "timeseries",
+        "description": {"title": "CPI annual rate", "cdid": "D7G7", "datasetId": "MM23", "unit": "%", "releaseDate": "2021-03-17T07:00:00Z"},
+        "years": [{"date": "2020", "value": "0.9"}],
+        "months": [{"date": "2021 FEB", "value": "0.4"}]
+      }
+      """
+
+  Scenario: Downloading the data of a timeseries as CSV
+    When I GET "/v1/timeseries/d7g7/data?format=csv"
+    Then I should receive the following response:
+      """
+      Title,CPI annual rate
+      CDID,D7G7
+      Source dataset ID,MM23
+      PreUnit,
+      Unit,%
+      Release date,17-03-2021
+      Next release,
+      Important notes,
+      2020,0.9
+      2021 FEB,0.4
+
+      """
+    And the HTTP status code should be "200"
+    And the response header "Content-Type" should be "text/csv; charset=utf-8"
+
+  Scenario: Downloading the data of a timeseries after it is republished
+    Given I GET "/v1/timeseries/d7g7/data?format=json"
+    And I am a publisher
+    And I set the "If-Match" header to the ETag of the page at "/economy/inflation/timeseries/d7g7/mm23"
+    And I PUT "/v1/content/economy/inflation/timeseries/d7g7/mm23"
+      """
+      {
+        "type": "timeseries",
+        "description": {"title": "CPI annual rate", "cdid": "D7G7", "datasetId": "MM23", "unit": "%", "releaseDate": "2021-04-21T06:00:00Z"},
+        "years": [{"date": "2020", "value": "0.9"}],
+        "months": [{"date": "2021 FEB", "value": "0.4"}, {"date": "2021 MAR", "value": "0.7"}]
+      }
+      """
+    When I GET "/v1/timeseries/d7g7/data?format=json"
+    Then I should receive the following JSON response:
+      """
+      {
+        "title": "CPI annual rate",
+        "cdid": "D7G7",
+        "dataset_id": "MM23",
+        "unit": "%",
+        "release_date": "2021-04-21T06:00:00Z",
+        "years": [{"date": "2020", "value": "0.9"}],
+        "quarters": [],
+        "months": [{"date": "2021 FEB", "value": "0.4"}, {"date": "2021 MAR", "value": "0.7"}]
+      }
+      """
@@ -0,0 +1,30 @@
+Feature: Tracing
+  Scenario: Reading a page is traced through to the content store
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+    And a span named "/v1/content/{uri:.*}" should have been recorded
+    And a span named "ContentStore.GetPage" should have been recorded
+
+  Scenario: Continuing the trace of the caller
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And I set the "traceparent" header to "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+    When I GET "/v1/content/aboutus"
+    Then the HTTP status code should be "200"
+    And every span should be in the trace "4bf92f3577b34da6a3ce929d0e0e4736"
+
+  Scenario: Publishing a page is traced through to the event sent for it
+    Given I am a publisher
+    When I PUT "/v1/content/aboutus"
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    Then the HTTP status code should be "201"
+    And a span named "ContentStore.CreatePage" should have been recorded
+    And a span named "content-published send" should have been recorded
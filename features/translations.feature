@@ -0,0 +1,60 @@
+Feature: Welsh translations
+  Background:
+    Given the following page exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "About us"}}
+      """
+    And the following page exists at "/economy":
+      """
+      {"type": "static_page", "description": {"title": "Economy"}}
+      """
+    And the following Welsh translation exists at "/aboutus":
+      """
+      {"type": "static_page", "description": {"title": "Amdanom ni"}}
+      """
+
+  Scenario: Reading a page in the language preferred by the Accept-Language header
+    Given I set the "Accept-Language" header to "cy-GB,en;q=0.8"
+    When I GET "/v1/content/aboutus"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/aboutus", "description": {"title": "Amdanom ni", "language": "cy"}}
+      """
+    And the HTTP status code should be "200"
+    And the response header "Content-Language" should be "cy"
+
+  Scenario: Reading a page that has not been translated into Welsh
+    When I GET "/v1/content/economy?lang=cy"
+    Then I should receive the following JSON response:
+      """
+      {"type": "static_page", "uri": "/economy", "description": {"title": "Economy"}}
+      """
+    And the HTTP status code should be "200"
+    And the response header "Content-Language" should be "en"
+    And the response header "X-Language-Fallback" should be "true"
+
+  Scenario: Translating a page into Welsh
+    Given I am a publisher
+    When I PUT "/v1/content/economy?lang=cy"
+      """
+      {"type": "static_page", "description": {"title": "Economi"}}
+      """
+    Then the HTTP status code should be "201"
+    And the stored Welsh translation at "/economy" should equal:
+      """
+      {"type": "static_page", "uri": "/economy", "description": {"title": "Economi", "language": "cy"}}
+      """
+    And the stored page at "/economy" should equal:
+      """
+      {"type": "static_page", "uri": "/economy", "description": {"title": "Economy"}}
+      """
+    And a content published event should have been sent for "/economy"
+
+  Scenario: Listing the pages that have not been translated into Welsh
+    Given I am a viewer
+    When I GET "/v1/translations/missing?lang=cy"
+    Then I should receive the following JSON response:
+      """
+      {"count": 1, "items": [{"uri": "/economy", "type": "static_page", "title": "Economy"}]}
+      """
+    And the HTTP status code should be "200"
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/importer"
+	"github.com/ONSdigital/dp-content-api/mongo"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+// importCommand is the argument that runs the importer instead of the service
+const importCommand = "import"
+
+// runImport imports a snapshot of Zebedee's master directory into the configured store, writing a report
+// of the import. It is run with: dp-content-api import [flags] <directory>
+func runImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet(importCommand, flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "validate every page and report what would be imported, without writing to the store")
+	checkpointPath := flags.String("checkpoint", "", "file to record progress in, so that an interrupted import can be resumed")
+	checkpointInterval := flags.Int("checkpoint-interval", 1000, "number of files to process between checkpoints")
+	reportPath := flags.String("report", "import-report.json", "file to write the report of the import to")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s [flags] <directory>\n", serviceName, importCommand)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("the directory to import is required")
+	}
+	dir := flags.Arg(0)
+
+	// stop at the next file on interrupt, so that the progress made is checkpointed
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+
+	imp := &importer.Importer{
+		DryRun:             *dryRun,
+		CheckpointPath:     *checkpointPath,
+		CheckpointInterval: *checkpointInterval,
+	}
+	if !*dryRun {
+		cfg, err := config.Get()
+		if err != nil {
+			return errors.Wrap(err, "error getting configuration")
+		}
+		store, err := mongo.New(ctx, cfg.MongoConfig)
+		if err != nil {
+			return errors.Wrap(err, "connecting to mongodb failed")
+		}
+		defer store.Close(ctx)
+		imp.Store = store
+	}
+
+	log.Event(ctx, "importing content", log.INFO, log.Data{"directory": dir, "dry_run": *dryRun})
+	report, err := imp.Run(ctx, dir)
+	if report != nil {
+		if werr := writeReport(*reportPath, report); werr != nil {
+			log.Event(ctx, "writing import report failed", log.ERROR, log.Error(werr), log.Data{"report": *reportPath})
+		}
+		log.Event(ctx, "import finished", log.INFO, log.Data{
+			"report":   *reportPath,
+			"imported": report.Imported,
+			"skipped":  report.Skipped,
+			"failed":   len(report.Failed),
+		})
+	}
+	return errors.Wrap(err, "import failed")
+}
+
+// writeReport writes the report of an import to the file at the path
+func writeReport(path string, report *importer.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkpoint records the progress of an import, so that an interrupted import can be resumed
+type checkpoint struct {
+	// LastPath is the path, relative to the content directory, of the last file to be processed
+	LastPath string  `json:"last_path"`
+	Report   *Report `json:"report"`
+}
+
+// readCheckpoint reads the checkpoint from the file at the path, returning nil if there is no such file
+func readCheckpoint(path string) (*checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var c checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// write saves the checkpoint to the file at the path. The file is replaced in a single rename, so that an
+// import interrupted while writing a checkpoint still has the previous one.
+func (c *checkpoint) write(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// processed returns true if the file at the relative path was processed before the checkpoint was made
+func (c *checkpoint) processed(path string) bool {
+	return c != nil && !walkedBefore(c.LastPath, path)
+}
+
+// walkedBefore returns true if the directory walk visits the relative path a before b. The walk visits the
+// entries of each directory in lexical order, so paths are compared one element at a time rather than as strings,
+// e.g. "a/b" is visited before "a-b" even though "a-b" is the lesser string.
+func walkedBefore(a, b string) bool {
+	as := strings.Split(filepath.ToSlash(a), "/")
+	bs := strings.Split(filepath.ToSlash(b), "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != bs[i] {
+			return as[i] < bs[i]
+		}
+	}
+	return len(as) < len(bs)
+}
@@ -0,0 +1,251 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gofrs/uuid"
+)
+
+// dataFile is the name of the file that Zebedee stores each page in, in a directory named after the page URI
+const dataFile = "data.json"
+
+// translationFiles are the names of the files that Zebedee stores translations of each page in, alongside its data.json
+var translationFiles = map[string]models.Language{
+	"data_cy.json": models.LanguageWelsh,
+}
+
+// errNoEnglishPage is reported for a translation of a page that is not in the store
+var errNoEnglishPage = errors.New("the english page has not been imported")
+
+// auditUser is the user that imported pages are audited as being created by
+const auditUser = "dp-content-api import"
+
+// Importer migrates pages from a snapshot of Zebedee's master directory into the content store. Pages that
+// already exist in the store are left as they are, so an import can safely be run again.
+type Importer struct {
+	// Store is the store that pages are imported into. It is not used in a dry run.
+	Store Store
+	// DryRun validates every page and reports what would be imported, without writing to the store
+	DryRun bool
+	// CheckpointPath is the file that progress is recorded in. An import that finds a checkpoint resumes
+	// from it. No checkpoints are made if the path is empty, or in a dry run.
+	CheckpointPath string
+	// CheckpointInterval is the number of files processed between checkpoints
+	CheckpointInterval int
+}
+
+// Run imports every data.json file found in the directory, along with the translations of each page, returning a
+// report of the import. If the import fails part way through, the report covers the files processed before it failed.
+func (i *Importer) Run(ctx context.Context, dir string) (*Report, error) {
+	var resumeFrom *checkpoint
+	if !i.DryRun && i.CheckpointPath != "" {
+		var err error
+		if resumeFrom, err = readCheckpoint(i.CheckpointPath); err != nil {
+			return nil, err
+		}
+	}
+
+	progress := &checkpoint{Report: newReport(i.DryRun)}
+	if resumeFrom != nil {
+		log.Event(ctx, "resuming import from checkpoint", log.INFO, log.Data{"last_path": resumeFrom.LastPath})
+		progress.LastPath = resumeFrom.LastPath
+		progress.Report = resumeFrom.Report
+	}
+
+	processed := 0
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		lang, isTranslation := translationFiles[info.Name()]
+		if info.IsDir() || (info.Name() != dataFile && !isTranslation) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if resumeFrom.processed(rel) {
+			return nil
+		}
+
+		// a page's data.json is walked before its translations, so the page is imported first
+		if isTranslation {
+			err = i.importTranslation(ctx, file, rel, lang, info.ModTime(), progress.Report)
+		} else {
+			err = i.importFile(ctx, file, rel, info.ModTime(), progress.Report)
+		}
+		if err != nil {
+			return err
+		}
+
+		progress.LastPath = rel
+		processed++
+		if i.CheckpointInterval > 0 && processed%i.CheckpointInterval == 0 {
+			return i.checkpoint(ctx, progress)
+		}
+		return nil
+	})
+
+	// record the progress made even if the import failed, so that it can be resumed from the file that failed
+	if cerr := i.checkpoint(ctx, progress); cerr != nil && err == nil {
+		err = cerr
+	}
+	return progress.Report, err
+}
+
+// importFile imports the page in the data.json file at the relative path. Pages that cannot be imported are
+// added to the report, so an error is only returned if the store fails.
+func (i *Importer) importFile(ctx context.Context, file, rel string, modTime time.Time, report *Report) error {
+	uri := models.CleanURI(path.Dir(rel))
+	logData := log.Data{"path": rel, "uri": uri}
+
+	if models.IsVersionURI(uri) {
+		report.skipped(skippedVersion)
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Event(ctx, "reading page failed", log.WARN, log.Error(err), logData)
+		report.failed(rel, uri, err)
+		return nil
+	}
+
+	page, err := models.NewPage(uri, data, modTime.UTC())
+	if err != nil {
+		log.Event(ctx, "page cannot be imported", log.WARN, log.Error(err), logData)
+		report.failed(rel, uri, err)
+		return nil
+	}
+
+	if i.DryRun {
+		report.imported(page)
+		return nil
+	}
+
+	_, err = i.Store.GetPage(ctx, uri)
+	switch err {
+	case nil:
+		report.skipped(skippedExists)
+		return nil
+	case apierrors.ErrPageNotFound:
+	default:
+		return err
+	}
+
+	if err := i.audit(ctx, page, ""); err != nil {
+		return err
+	}
+	if err := i.Store.CreatePage(ctx, page); err != nil {
+		return err
+	}
+	report.imported(page)
+	return nil
+}
+
+// importTranslation imports the translation of a page into the language from the file at the relative path. As
+// with pages, translations that cannot be imported are added to the report.
+func (i *Importer) importTranslation(ctx context.Context, file, rel string, lang models.Language, modTime time.Time, report *Report) error {
+	uri := models.CleanURI(path.Dir(rel))
+	logData := log.Data{"path": rel, "uri": uri, "lang": lang}
+
+	if models.IsVersionURI(uri) {
+		report.skipped(skippedVersion)
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Event(ctx, "reading translation failed", log.WARN, log.Error(err), logData)
+		report.failed(rel, uri, err)
+		return nil
+	}
+
+	page, err := models.NewTranslation(uri, lang, data, modTime.UTC())
+	if err != nil {
+		log.Event(ctx, "translation cannot be imported", log.WARN, log.Error(err), logData)
+		report.failed(rel, uri, err)
+		return nil
+	}
+
+	if i.DryRun {
+		report.importedTranslation(lang)
+		return nil
+	}
+
+	_, err = i.Store.GetTranslation(ctx, uri, lang)
+	switch err {
+	case nil:
+		report.skipped(skippedTranslationExists)
+		return nil
+	case apierrors.ErrTranslationNotFound:
+	default:
+		return err
+	}
+
+	if _, err := i.Store.GetPage(ctx, uri); err != nil {
+		if err != apierrors.ErrPageNotFound {
+			return err
+		}
+		log.Event(ctx, "translation cannot be imported without its english page", log.WARN, logData)
+		report.failed(rel, uri, errNoEnglishPage)
+		return nil
+	}
+
+	if err := i.audit(ctx, page, lang); err != nil {
+		return err
+	}
+	if err := i.Store.CreateTranslation(ctx, lang, page); err != nil {
+		return err
+	}
+	report.importedTranslation(lang)
+	return nil
+}
+
+// audit records the creation of the page, or of its translation into the language, by the import before it is stored
+func (i *Importer) audit(ctx context.Context, page *models.Page, lang models.Language) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	return i.Store.AddAuditRecord(ctx, &models.AuditRecord{
+		ID:        id.String(),
+		User:      auditUser,
+		Service:   true,
+		Action:    models.AuditActionCreate,
+		URI:       page.URI,
+		Lang:      lang,
+		AfterHash: models.HashPage(page),
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// checkpoint saves the progress of the import, unless checkpoints are not being made
+func (i *Importer) checkpoint(ctx context.Context, progress *checkpoint) error {
+	if i.DryRun || i.CheckpointPath == "" {
+		return nil
+	}
+
+	if err := progress.write(i.CheckpointPath); err != nil {
+		log.Event(ctx, "writing checkpoint failed", log.ERROR, log.Error(err), log.Data{"checkpoint": i.CheckpointPath})
+		return err
+	}
+	log.Event(ctx, "import checkpoint saved", log.INFO, log.Data{"last_path": progress.LastPath, "imported": progress.Report.Imported})
+	return nil
+}
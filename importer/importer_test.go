@@ -0,0 +1,215 @@
+package importer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/importer"
+	"github.com/ONSdigital/dp-content-api/importer/mock"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ctx      = context.Background()
+	errStore = errors.New("store is unavailable")
+)
+
+// zebedeeFiles is a snapshot of a Zebedee master directory. Its files are not walked in string order, as
+// "economy/inflation/data.json" is walked before "economy/inflation-and-prices/data.json".
+var zebedeeFiles = map[string]string{
+	"aboutus/data.json":                                  `{"type":"static_page","uri":"/aboutus","description":{"title":"About us"}}`,
+	"aboutus/data_cy.json":                               `{"type":"static_page","uri":"/aboutus","description":{"title":"Amdanom ni"}}`,
+	"aboutus/logo.png":                                   `not a page`,
+	"economy/inflation/data.json":                        `{"type":"static_page","description":{"title":"Inflation"}}`,
+	"economy/inflation/previous/v1/data.json":            `{"type":"static_page","description":{"title":"Inflation and prices"}}`,
+	"economy/inflation-and-prices/data.json":             `{"type":"bulletin","description":{"title":"Consumer price inflation"}}`,
+	"economy/inflation-and-prices/unsupported/data.json": `{"type":"visualisation_page","description":{"title":"Inflation"}}`,
+	"employment/data.json":                               `{"type":"static_page",`,
+	"employment/data_cy.json":                            `{"type":"static_page","description":{"title":"Cyflogaeth"}}`,
+	"releases/cpi/data.json":                             `{"type":"release","description":{"title":"Consumer price inflation","releaseDate":"2021-04-21T06:00:00.000Z"}}`,
+}
+
+// writeZebedeeFiles writes the snapshot of a Zebedee master directory to a temporary directory
+func writeZebedeeFiles(t *testing.T) string {
+	dir := t.TempDir()
+	for name, content := range zebedeeFiles {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		So(os.MkdirAll(filepath.Dir(file), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(file, []byte(content), 0644), ShouldBeNil)
+	}
+	return dir
+}
+
+// failingStore fails to create pages once it has created the provided number of pages
+func failingStore(store *memory.Store, creates int) *mock.StoreMock {
+	return &mock.StoreMock{
+		GetPageFunc: store.GetPage,
+		CreatePageFunc: func(ctx context.Context, page *models.Page) error {
+			if creates == 0 {
+				return errStore
+			}
+			creates--
+			return store.CreatePage(ctx, page)
+		},
+		GetTranslationFunc:    store.GetTranslation,
+		CreateTranslationFunc: store.CreateTranslation,
+		AddAuditRecordFunc:    store.AddAuditRecord,
+	}
+}
+
+func failedPaths(report *importer.Report) []string {
+	paths := []string{}
+	for _, failure := range report.Failed {
+		paths = append(paths, failure.Path)
+	}
+	return paths
+}
+
+func TestImport(t *testing.T) {
+	Convey("Given a snapshot of Zebedee content", t, func() {
+		dir := writeZebedeeFiles(t)
+
+		Convey("When a dry run is made", func() {
+			imp := &importer.Importer{DryRun: true, CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json")}
+			report, err := imp.Run(ctx, dir)
+			So(err, ShouldBeNil)
+
+			Convey("Then the pages that would be imported are reported", func() {
+				So(report.DryRun, ShouldBeTrue)
+				So(report.Imported, ShouldEqual, 3)
+				So(report.Types, ShouldResemble, map[models.PageType]int{models.PageTypeStaticPage: 2, models.PageTypeRelease: 1})
+				So(report.Translations, ShouldResemble, map[models.Language]int{models.LanguageWelsh: 2})
+				So(report.Skipped, ShouldResemble, map[string]int{"previous versions are not imported": 1})
+			})
+
+			Convey("Then every page that fails validation is reported with the reasons why", func() {
+				So(failedPaths(report), ShouldResemble, []string{
+					"economy/inflation-and-prices/data.json",
+					"economy/inflation-and-prices/unsupported/data.json",
+					"employment/data.json",
+				})
+				So(report.Failed[0].URI, ShouldEqual, "/economy/inflation-and-prices")
+				So(report.Failed[0].Errors, ShouldResemble, []string{"description.releaseDate: is required"})
+				So(report.Failed[1].Errors, ShouldResemble, []string{`type: unsupported page type "visualisation_page"`})
+				So(report.Failed[2].Errors, ShouldHaveLength, 1)
+			})
+
+			Convey("Then no checkpoint is made", func() {
+				_, err := os.Stat(imp.CheckpointPath)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When it is imported into a store that already contains one of the pages", func() {
+			store := memory.New()
+			existing := &models.Page{URI: "/aboutus", Type: models.PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","description":{"title":"About the ONS"}}`)}
+			So(store.CreatePage(ctx, existing), ShouldBeNil)
+
+			imp := &importer.Importer{Store: store}
+			report, err := imp.Run(ctx, dir)
+			So(err, ShouldBeNil)
+
+			Convey("Then the other valid pages are stored at the URIs of their directories", func() {
+				So(report.DryRun, ShouldBeFalse)
+				So(report.Imported, ShouldEqual, 2)
+				page, err := store.GetPage(ctx, "/economy/inflation")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/economy/inflation","description":{"title":"Inflation"}}`)
+				_, err = store.GetPage(ctx, "/releases/cpi")
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then the existing page is left as it is", func() {
+				So(report.Skipped["page already exists"], ShouldEqual, 1)
+				page, err := store.GetPage(ctx, "/aboutus")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, string(existing.Data))
+			})
+
+			Convey("Then invalid pages are not stored", func() {
+				So(report.Failed, ShouldHaveLength, 4)
+				_, err := store.GetPage(ctx, "/economy/inflation-and-prices")
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("Then the Welsh translation of the existing page is stored", func() {
+				So(report.Translations, ShouldResemble, map[models.Language]int{models.LanguageWelsh: 1})
+				page, err := store.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/aboutus","description":{"title":"Amdanom ni","language":"cy"}}`)
+			})
+
+			Convey("Then a translation of a page that could not be imported is reported", func() {
+				So(report.Failed[3].Path, ShouldEqual, "employment/data_cy.json")
+				So(report.Failed[3].Errors, ShouldResemble, []string{"the english page has not been imported"})
+			})
+
+			Convey("Then each imported page and translation is audited", func() {
+				records, _, err := store.GetAuditRecords(ctx, models.AuditFilter{})
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 3)
+				So(records[2].Lang, ShouldEqual, models.LanguageWelsh)
+				So(records[0].User, ShouldEqual, "dp-content-api import")
+				So(records[0].Service, ShouldBeTrue)
+				So(records[0].Action, ShouldEqual, models.AuditActionCreate)
+			})
+		})
+
+		Convey("When an import with checkpoints fails part way through", func() {
+			store := memory.New()
+			checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+			imp := &importer.Importer{Store: failingStore(store, 1), CheckpointPath: checkpointPath, CheckpointInterval: 1}
+			report, err := imp.Run(ctx, dir)
+
+			Convey("Then the error and the progress made are returned", func() {
+				So(err, ShouldEqual, errStore)
+				So(report.Imported, ShouldEqual, 1)
+			})
+
+			Convey("And it is run again", func() {
+				imp.Store = store
+				report, err := imp.Run(ctx, dir)
+				So(err, ShouldBeNil)
+
+				Convey("Then it resumes from the file that failed, reporting on the whole import", func() {
+					So(report.Imported, ShouldEqual, 3)
+					So(report.Translations, ShouldResemble, map[models.Language]int{models.LanguageWelsh: 1})
+					So(report.Skipped, ShouldResemble, map[string]int{"previous versions are not imported": 1})
+					So(failedPaths(report), ShouldResemble, []string{
+						"economy/inflation-and-prices/data.json",
+						"economy/inflation-and-prices/unsupported/data.json",
+						"employment/data.json",
+						"employment/data_cy.json",
+					})
+				})
+
+				Convey("Then running it once more imports nothing", func() {
+					before, _, err := store.GetAuditRecords(ctx, models.AuditFilter{})
+					So(err, ShouldBeNil)
+					report, err := imp.Run(ctx, dir)
+					So(err, ShouldBeNil)
+					So(report.Imported, ShouldEqual, 3)
+					after, _, err := store.GetAuditRecords(ctx, models.AuditFilter{})
+					So(err, ShouldBeNil)
+					So(after, ShouldHaveLength, len(before))
+				})
+			})
+		})
+	})
+
+	Convey("Given a directory that does not exist", t, func() {
+		imp := &importer.Importer{DryRun: true}
+
+		Convey("Then the import fails", func() {
+			_, err := imp.Run(ctx, filepath.Join(t.TempDir(), "master"))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
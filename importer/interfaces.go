@@ -0,0 +1,18 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+//go:generate moq -out mock/store.go -pkg mock . Store
+
+// Store defines the required methods from the store that pages and their translations are imported into
+type Store interface {
+	GetPage(ctx context.Context, uri string) (*models.Page, error)
+	CreatePage(ctx context.Context, page *models.Page) error
+	GetTranslation(ctx context.Context, uri string, lang models.Language) (*models.Page, error)
+	CreateTranslation(ctx context.Context, lang models.Language, page *models.Page) error
+	AddAuditRecord(ctx context.Context, record *models.AuditRecord) error
+}
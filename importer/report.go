@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// Reasons that a file is skipped rather than imported
+const (
+	skippedExists            = "page already exists"
+	skippedTranslationExists = "translation already exists"
+	skippedVersion           = "previous versions are not imported"
+)
+
+// Report summarises an import. In a dry run, the pages counted as imported are those that would have been.
+// Translations are counted separately from the English pages they translate.
+type Report struct {
+	DryRun       bool                    `json:"dry_run"`
+	Imported     int                     `json:"imported"`
+	Types        map[models.PageType]int `json:"types"`
+	Translations map[models.Language]int `json:"translations"`
+	Skipped      map[string]int          `json:"skipped"`
+	Failed       []Failure               `json:"failed"`
+}
+
+// Failure is a data.json, or translation, file that could not be imported, with the reasons why
+type Failure struct {
+	Path   string   `json:"path"`
+	URI    string   `json:"uri"`
+	Errors []string `json:"errors"`
+}
+
+// newReport returns an empty report
+func newReport(dryRun bool) *Report {
+	return &Report{
+		DryRun:       dryRun,
+		Types:        make(map[models.PageType]int),
+		Translations: make(map[models.Language]int),
+		Skipped:      make(map[string]int),
+		Failed:       []Failure{},
+	}
+}
+
+// imported counts a page that was imported
+func (r *Report) imported(page *models.Page) {
+	r.Imported++
+	r.Types[page.Type]++
+}
+
+// importedTranslation counts a translation into the language that was imported
+func (r *Report) importedTranslation(lang models.Language) {
+	// checkpoints made before translations were imported have no count of them
+	if r.Translations == nil {
+		r.Translations = make(map[models.Language]int)
+	}
+	r.Translations[lang]++
+}
+
+// skipped counts a file that was skipped for the reason given
+func (r *Report) skipped(reason string) {
+	r.Skipped[reason]++
+}
+
+// failed records a file that could not be imported. Each validation failure is listed separately.
+func (r *Report) failed(path, uri string, err error) {
+	failure := Failure{Path: path, URI: uri}
+	if errs, ok := err.(models.ValidationErrors); ok {
+		for _, e := range errs {
+			failure.Errors = append(failure.Errors, e.Field+": "+e.Description)
+		}
+	} else {
+		failure.Errors = []string{err.Error()}
+	}
+	r.Failed = append(r.Failed, failure)
+}
@@ -0,0 +1,118 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/Shopify/sarama"
+)
+
+// ErrProducerClosed is returned when a message is sent after the producer has been closed
+var ErrProducerClosed = errors.New("kafka producer is closed")
+
+// Producer sends messages to Kafka topics asynchronously. Failures to deliver a message are logged.
+type Producer struct {
+	client   sarama.Client
+	producer sarama.AsyncProducer
+	topics   []string
+
+	mutex  sync.RWMutex
+	closed bool
+	errors sync.WaitGroup
+}
+
+// NewProducer connects to the Kafka brokers and returns a producer for the provided topics
+func NewProducer(ctx context.Context, brokers []string, version string, topics ...string) (*Producer, error) {
+	cfg := sarama.NewConfig()
+	v, err := sarama.ParseKafkaVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Version = v
+	cfg.Producer.Return.Errors = true
+
+	client, err := sarama.NewClient(brokers, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	p := &Producer{
+		client:   client,
+		producer: producer,
+		topics:   topics,
+	}
+
+	p.errors.Add(1)
+	go p.logErrors(ctx)
+
+	log.Event(ctx, "kafka producer created", log.INFO, log.Data{"brokers": brokers, "topics": topics})
+	return p, nil
+}
+
+// Send queues a message for delivery to the topic
+func (p *Producer) Send(ctx context.Context, topic string, message []byte) error {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.closed {
+		return ErrProducerClosed
+	}
+
+	select {
+	case p.producer.Input() <- &sarama.ProducerMessage{Topic: topic, Value: sarama.ByteEncoder(message)}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Checker checks that the topics can be reached on the Kafka brokers
+func (p *Producer) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	if err := p.client.RefreshMetadata(p.topics...); err != nil {
+		log.Event(ctx, "kafka producer health check failed", log.ERROR, log.Error(err))
+		return state.Update(healthcheck.StatusCritical, "kafka producer is unavailable", 0)
+	}
+	return state.Update(healthcheck.StatusOK, "kafka producer is OK", 0)
+}
+
+// Close flushes any queued messages and disconnects from the Kafka brokers
+func (p *Producer) Close(ctx context.Context) error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mutex.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		p.producer.AsyncClose()
+		p.errors.Wait()
+		done <- p.client.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logErrors logs every message that could not be delivered, until the producer is closed
+func (p *Producer) logErrors(ctx context.Context) {
+	defer p.errors.Done()
+	for err := range p.producer.Errors() {
+		log.Event(ctx, "failed to send kafka message", log.ERROR, log.Error(err.Err), log.Data{"topic": err.Msg.Topic})
+	}
+}
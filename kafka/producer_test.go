@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ctx = context.Background()
+
+func TestSend(t *testing.T) {
+	Convey("Given a producer", t, func() {
+		cfg := mocks.NewTestConfig()
+		cfg.Producer.Return.Successes = true
+		asyncProducer := mocks.NewAsyncProducer(t, cfg)
+		defer asyncProducer.Close()
+		p := &Producer{producer: asyncProducer}
+
+		Convey("When a message is sent", func() {
+			asyncProducer.ExpectInputAndSucceed()
+			err := p.Send(ctx, "content-published", []byte("message"))
+
+			Convey("Then it is delivered to the topic", func() {
+				So(err, ShouldBeNil)
+				msg := <-asyncProducer.Successes()
+				So(msg.Topic, ShouldEqual, "content-published")
+				So(msg.Value, ShouldResemble, sarama.ByteEncoder("message"))
+			})
+		})
+
+		Convey("When a message is sent after the producer is closed", func() {
+			p.closed = true
+			err := p.Send(ctx, "content-published", []byte("message"))
+
+			Convey("Then it is rejected", func() {
+				So(err, ShouldEqual, ErrProducerClosed)
+			})
+		})
+	})
+}
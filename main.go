@@ -33,7 +33,17 @@ func main() {
 	log.Namespace = serviceName
 	ctx := context.Background()
 
-	if err := run(ctx); err != nil {
+	// the service is run unless the import or migrate command is given
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == importCommand:
+		err = runImport(ctx, os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == migrateCommand:
+		err = runMigrate(ctx)
+	default:
+		err = run(ctx)
+	}
+	if err != nil {
 		log.Event(nil, "fatal runtime error", log.Error(err), log.FATAL)
 		os.Exit(1)
 	}
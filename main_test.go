@@ -1,30 +1,43 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 	"testing"
 
 	componenttest "github.com/ONSdigital/dp-component-test"
+	"github.com/ONSdigital/dp-content-api/config"
 	"github.com/ONSdigital/dp-content-api/features/steps"
 	"github.com/cucumber/godog"
 	"github.com/cucumber/godog/colors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var componentFlag = flag.Bool("component", false, "perform component tests")
+var (
+	componentFlag = flag.Bool("component", false, "perform component tests")
+	mongoFlag     = flag.Bool("mongo", false, "perform component tests against the MongoDB replica set at MONGODB_URI, rather than an in-memory store")
+)
+
+// componentTestDatabase is the database that component tests against MongoDB are run in. It is dropped before every
+// scenario, so it is never the database of the service itself.
+const componentTestDatabase = "content-component-test"
 
 type ComponentTest struct {
 	MongoFeature *componenttest.MongoFeature
 }
 
 func (f *ComponentTest) InitializeScenario(ctx *godog.ScenarioContext) {
-	component, err := steps.NewComponent()
+	component, err := steps.NewComponent(f.MongoFeature)
 	if err != nil {
 		panic(err)
 	}
 
 	ctx.BeforeScenario(func(*godog.Scenario) {
-		component.Reset()
+		if err := component.Reset(); err != nil {
+			panic(err)
+		}
 	})
 
 	ctx.AfterScenario(func(*godog.Scenario, error) {
@@ -49,6 +62,13 @@ func TestComponent(t *testing.T) {
 		}
 
 		f := &ComponentTest{}
+		if *mongoFlag {
+			var err error
+			if f.MongoFeature, err = newMongoFeature(); err != nil {
+				t.Fatalf("connecting to MongoDB failed: %v", err)
+			}
+			defer f.MongoFeature.Client.Disconnect(context.Background())
+		}
 
 		status = godog.TestSuite{
 			Name:                 "feature_tests",
@@ -64,3 +84,26 @@ func TestComponent(t *testing.T) {
 		t.Skip("component flag required to run component tests")
 	}
 }
+
+// newMongoFeature connects to the MongoDB replica set that the component tests are run against. The in-memory server
+// that the component test library can start is not used, as it cannot run as a replica set, which the store needs for
+// its transactions.
+func newMongoFeature() (*componenttest.MongoFeature, error) {
+	cfg, err := config.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.MongoConfig.ConnectTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoConfig.URI))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, err
+	}
+	return &componenttest.MongoFeature{Client: *client, Database: client.Database(componentTestDatabase)}, nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// AddAuditRecord stores a record of a change to content
+func (s *Store) AddAuditRecord(ctx context.Context, record *models.AuditRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c := *record
+	s.audit = append(s.audit, &c)
+	return nil
+}
+
+// GetAuditRecords returns the page of audit records that match the filter, most recent first, along with the total
+// number of records that match it
+func (s *Store) GetAuditRecords(ctx context.Context, filter models.AuditFilter) ([]*models.AuditRecord, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	records := []*models.AuditRecord{}
+	for i := len(s.audit) - 1; i >= 0; i-- {
+		if filter.Matches(s.audit[i]) {
+			c := *s.audit[i]
+			records = append(records, &c)
+		}
+	}
+
+	total := len(records)
+	if filter.Offset >= total {
+		return []*models.AuditRecord{}, total, nil
+	}
+	records = records[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(records) {
+		records = records[:filter.Limit]
+	}
+	return records, total, nil
+}
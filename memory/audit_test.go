@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAudit(t *testing.T) {
+	Convey("Given a store containing audit records for two pages", t, func() {
+		s := New()
+		first := &models.AuditRecord{ID: "1", User: "publisher@ons.gov.uk", Action: models.AuditActionCreate, URI: "/economy", Timestamp: time.Date(2021, 3, 17, 9, 0, 0, 0, time.UTC)}
+		second := &models.AuditRecord{ID: "2", User: "publisher@ons.gov.uk", Action: models.AuditActionCreate, URI: "/employmentandlabourmarket", Timestamp: time.Date(2021, 3, 17, 10, 0, 0, 0, time.UTC)}
+		third := &models.AuditRecord{ID: "3", User: "another@ons.gov.uk", Action: models.AuditActionUpdate, URI: "/economy", Timestamp: time.Date(2021, 3, 17, 11, 0, 0, 0, time.UTC)}
+		for _, record := range []*models.AuditRecord{first, second, third} {
+			So(s.AddAuditRecord(ctx, record), ShouldBeNil)
+		}
+
+		Convey("Then every record is returned, most recent first", func() {
+			records, _, err := s.GetAuditRecords(ctx, models.AuditFilter{})
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*models.AuditRecord{third, second, first})
+		})
+
+		Convey("Then the records can be filtered", func() {
+			records, _, err := s.GetAuditRecords(ctx, models.AuditFilter{URI: "/economy", To: second.Timestamp})
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*models.AuditRecord{first})
+		})
+
+		Convey("Then a page of the records can be returned, with the total number that match", func() {
+			records, total, err := s.GetAuditRecords(ctx, models.AuditFilter{Offset: 1, Limit: 1})
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []*models.AuditRecord{second})
+			So(total, ShouldEqual, 3)
+
+			records, total, err = s.GetAuditRecords(ctx, models.AuditFilter{Offset: 3})
+			So(err, ShouldBeNil)
+			So(records, ShouldBeEmpty)
+			So(total, ShouldEqual, 3)
+		})
+
+		Convey("Then no records are returned when none match", func() {
+			records, _, err := s.GetAuditRecords(ctx, models.AuditFilter{User: "viewer@ons.gov.uk"})
+			So(err, ShouldBeNil)
+			So(records, ShouldBeEmpty)
+		})
+
+		Convey("Then modifying a returned record does not change the stored record", func() {
+			records, _, err := s.GetAuditRecords(ctx, models.AuditFilter{})
+			So(err, ShouldBeNil)
+			records[0].URI = "/changed"
+
+			records, _, err = s.GetAuditRecords(ctx, models.AuditFilter{})
+			So(err, ShouldBeNil)
+			So(records[0].URI, ShouldEqual, "/economy")
+		})
+	})
+}
@@ -0,0 +1,233 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// CreateCollection stores a new collection
+func (s *Store) CreateCollection(ctx context.Context, collection *models.Collection) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.collections[collection.ID] = copyCollection(collection)
+	s.drafts[collection.ID] = make(map[string]*models.Page)
+	return nil
+}
+
+// GetCollection returns the collection with the provided ID
+func (s *Store) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	collection, ok := s.collections[id]
+	if !ok {
+		return nil, apierrors.ErrCollectionNotFound
+	}
+	return copyCollection(collection), nil
+}
+
+// GetCollections returns every collection, ordered by ID
+func (s *Store) GetCollections(ctx context.Context) ([]*models.Collection, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	collections := make([]*models.Collection, 0, len(s.collections))
+	for _, collection := range s.collections {
+		collections = append(collections, copyCollection(collection))
+	}
+	sort.Slice(collections, func(i, j int) bool { return collections[i].ID < collections[j].ID })
+	return collections, nil
+}
+
+// GetScheduledCollections returns every collection that is waiting to be published at a publish date, ordered by ID
+func (s *Store) GetScheduledCollections(ctx context.Context) ([]*models.Collection, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var collections []*models.Collection
+	for _, collection := range s.collections {
+		if collection.State == models.CollectionStateInProgress && collection.PublishDate != nil {
+			collections = append(collections, copyCollection(collection))
+		}
+	}
+	sort.Slice(collections, func(i, j int) bool { return collections[i].ID < collections[j].ID })
+	return collections, nil
+}
+
+// DeleteCollection removes a collection and all of its draft pages
+func (s *Store) DeleteCollection(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.collections[id]; !ok {
+		return apierrors.ErrCollectionNotFound
+	}
+	delete(s.collections, id)
+	delete(s.drafts, id)
+	return nil
+}
+
+// GetDraftPage returns the draft of the page at the provided URI from a collection
+func (s *Store) GetDraftPage(ctx context.Context, collectionID, uri string) (*models.Page, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, ok := s.collections[collectionID]; !ok {
+		return nil, apierrors.ErrCollectionNotFound
+	}
+	page, ok := s.drafts[collectionID][uri]
+	if !ok {
+		return nil, apierrors.ErrCollectionItemNotFound
+	}
+	return copyPage(page), nil
+}
+
+// UpsertDraftPage adds or replaces a draft page in a collection, marking it as in progress
+func (s *Store) UpsertDraftPage(ctx context.Context, collectionID string, page *models.Page) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection, err := s.editableCollection(collectionID)
+	if err != nil {
+		return err
+	}
+	s.storeDraftPage(collection, page)
+	return nil
+}
+
+// ReplaceDraftPage replaces a draft page in a collection only if the stored draft still has the ETag, so that
+// changes made since it was read are not overwritten. The draft is marked as in progress again.
+func (s *Store) ReplaceDraftPage(ctx context.Context, collectionID string, page *models.Page, etag string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection, err := s.editableCollection(collectionID)
+	if err != nil {
+		return err
+	}
+	existing, ok := s.drafts[collectionID][page.URI]
+	if !ok {
+		return apierrors.ErrCollectionItemNotFound
+	}
+	if existing.ETag() != etag {
+		return apierrors.ErrEditConflict
+	}
+	s.storeDraftPage(collection, page)
+	return nil
+}
+
+// storeDraftPage adds or replaces the draft page in the collection, marking it as in progress. The caller must hold
+// the write lock.
+func (s *Store) storeDraftPage(collection *models.Collection, page *models.Page) {
+	item := models.CollectionItem{URI: page.URI, Type: page.Type, State: models.ItemStateInProgress}
+	if existing := collection.Item(page.URI); existing != nil {
+		*existing = item
+	} else {
+		collection.Items = append(collection.Items, item)
+	}
+	collection.LastUpdated = page.LastUpdated
+	s.drafts[collection.ID][page.URI] = copyPage(page)
+}
+
+// DeleteDraftPage removes a draft page from a collection
+func (s *Store) DeleteDraftPage(ctx context.Context, collectionID, uri string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection, err := s.editableCollection(collectionID)
+	if err != nil {
+		return err
+	}
+	if collection.Item(uri) == nil {
+		return apierrors.ErrCollectionItemNotFound
+	}
+
+	items := collection.Items[:0]
+	for _, item := range collection.Items {
+		if item.URI != uri {
+			items = append(items, item)
+		}
+	}
+	collection.Items = items
+	collection.LastUpdated = time.Now().UTC()
+	delete(s.drafts[collectionID], uri)
+	return nil
+}
+
+// UpdateItemState sets the review state of a draft page in a collection
+func (s *Store) UpdateItemState(ctx context.Context, collectionID, uri string, state models.ItemState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection, err := s.editableCollection(collectionID)
+	if err != nil {
+		return err
+	}
+	item := collection.Item(uri)
+	if item == nil {
+		return apierrors.ErrCollectionItemNotFound
+	}
+	item.State = state
+	collection.LastUpdated = time.Now().UTC()
+	return nil
+}
+
+// PublishCollection makes every draft page in a collection live, marking the collection as published.
+// Either all of the pages are published, or none of them are.
+func (s *Store) PublishCollection(ctx context.Context, collectionID string, publishedAt time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection, err := s.editableCollection(collectionID)
+	if err != nil {
+		return err
+	}
+	if !collection.IsPublishable() {
+		return apierrors.ErrCollectionNotPublishable
+	}
+
+	for _, draft := range s.drafts[collectionID] {
+		page := copyPage(draft)
+		page.LastUpdated = publishedAt
+		s.replacePage(page)
+	}
+
+	collection.State = models.CollectionStatePublished
+	collection.PublishedAt = &publishedAt
+	collection.LastUpdated = publishedAt
+	delete(s.drafts, collectionID)
+	return nil
+}
+
+// editableCollection returns the stored collection, failing if it does not exist or
+// has already been published. The caller must hold the write lock.
+func (s *Store) editableCollection(id string) (*models.Collection, error) {
+	collection, ok := s.collections[id]
+	if !ok {
+		return nil, apierrors.ErrCollectionNotFound
+	}
+	if collection.State == models.CollectionStatePublished {
+		return nil, apierrors.ErrCollectionPublished
+	}
+	return collection, nil
+}
+
+// copyCollection returns a deep copy of a collection so that callers cannot modify stored state
+func copyCollection(collection *models.Collection) *models.Collection {
+	c := *collection
+	c.Items = append([]models.CollectionItem{}, collection.Items...)
+	if collection.PublishDate != nil {
+		publishDate := *collection.PublishDate
+		c.PublishDate = &publishDate
+	}
+	if collection.PublishedAt != nil {
+		publishedAt := *collection.PublishedAt
+		c.PublishedAt = &publishedAt
+	}
+	return &c
+}
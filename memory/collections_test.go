@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testCollection(id string) *models.Collection {
+	return &models.Collection{
+		ID:          id,
+		Name:        "March 2021 inflation",
+		State:       models.CollectionStateInProgress,
+		Items:       []models.CollectionItem{},
+		LastUpdated: time.Date(2021, 3, 16, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCollections(t *testing.T) {
+	Convey("Given a store containing a published page and an empty collection", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, testPage("/economy")), ShouldBeNil)
+		So(s.CreateCollection(ctx, testCollection("123")), ShouldBeNil)
+
+		Convey("When a collection that does not exist is requested", func() {
+			_, err := s.GetCollection(ctx, "456")
+
+			Convey("Then a not found error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrCollectionNotFound)
+			})
+		})
+
+		Convey("When a draft is added to the collection", func() {
+			draft := testPage("/economy")
+			draft.Type = models.PageTypeStaticPage
+			draft.Data = []byte(`{"description":{"title":"Economy"}}`)
+			So(s.UpsertDraftPage(ctx, "123", draft), ShouldBeNil)
+
+			Convey("Then the draft is returned from the collection", func() {
+				page, err := s.GetDraftPage(ctx, "123", "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, draft)
+			})
+
+			Convey("Then the published page is unchanged", func() {
+				page, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, testPage("/economy"))
+			})
+
+			Convey("Then the collection lists the page as in progress", func() {
+				collection, err := s.GetCollection(ctx, "123")
+				So(err, ShouldBeNil)
+				So(collection.Items, ShouldResemble, []models.CollectionItem{
+					{URI: "/economy", Type: models.PageTypeStaticPage, State: models.ItemStateInProgress},
+				})
+			})
+
+			Convey("Then the collection cannot be published until the page is reviewed", func() {
+				So(s.PublishCollection(ctx, "123", time.Now()), ShouldEqual, apierrors.ErrCollectionNotPublishable)
+				So(s.UpdateItemState(ctx, "123", "/economy", models.ItemStateComplete), ShouldBeNil)
+				So(s.PublishCollection(ctx, "123", time.Now()), ShouldEqual, apierrors.ErrCollectionNotPublishable)
+			})
+
+			Convey("Then editing the draft again returns it to in progress", func() {
+				So(s.UpdateItemState(ctx, "123", "/economy", models.ItemStateReviewed), ShouldBeNil)
+				So(s.UpsertDraftPage(ctx, "123", draft), ShouldBeNil)
+				collection, err := s.GetCollection(ctx, "123")
+				So(err, ShouldBeNil)
+				So(collection.Items, ShouldHaveLength, 1)
+				So(collection.Items[0].State, ShouldEqual, models.ItemStateInProgress)
+			})
+
+			Convey("Then replacing the draft with its current ETag replaces it", func() {
+				edited := testPage("/economy")
+				edited.Type = models.PageTypeStaticPage
+				edited.Data = []byte(`{"description":{"title":"The economy"}}`)
+				So(s.ReplaceDraftPage(ctx, "123", edited, draft.ETag()), ShouldBeNil)
+
+				page, err := s.GetDraftPage(ctx, "123", "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, edited)
+			})
+
+			Convey("Then replacing the draft with an out of date ETag is a conflict, and the draft is unchanged", func() {
+				edited := testPage("/economy")
+				edited.Type = models.PageTypeStaticPage
+				So(s.ReplaceDraftPage(ctx, "123", edited, testPage("/economy").ETag()), ShouldEqual, apierrors.ErrEditConflict)
+
+				page, err := s.GetDraftPage(ctx, "123", "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, draft)
+			})
+
+			Convey("When the draft is removed", func() {
+				So(s.DeleteDraftPage(ctx, "123", "/economy"), ShouldBeNil)
+
+				Convey("Then it is no longer in the collection", func() {
+					_, err := s.GetDraftPage(ctx, "123", "/economy")
+					So(err, ShouldEqual, apierrors.ErrCollectionItemNotFound)
+					collection, err := s.GetCollection(ctx, "123")
+					So(err, ShouldBeNil)
+					So(collection.Items, ShouldBeEmpty)
+				})
+			})
+
+			Convey("When the page is reviewed and the collection is published", func() {
+				publishedAt := time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC)
+				So(s.UpdateItemState(ctx, "123", "/economy", models.ItemStateReviewed), ShouldBeNil)
+				So(s.PublishCollection(ctx, "123", publishedAt), ShouldBeNil)
+
+				Convey("Then the draft replaces the published page", func() {
+					page, err := s.GetPage(ctx, "/economy")
+					So(err, ShouldBeNil)
+					So(string(page.Data), ShouldEqual, string(draft.Data))
+					So(page.LastUpdated, ShouldEqual, publishedAt)
+				})
+
+				Convey("Then the replaced page is kept as a previous version", func() {
+					version, err := s.GetPageVersion(ctx, "/economy", 1)
+					So(err, ShouldBeNil)
+					So(version.ToPage(), ShouldResemble, testPage("/economy"))
+					So(version.SupersededAt, ShouldEqual, publishedAt)
+				})
+
+				Convey("Then the collection is marked as published", func() {
+					collection, err := s.GetCollection(ctx, "123")
+					So(err, ShouldBeNil)
+					So(collection.State, ShouldEqual, models.CollectionStatePublished)
+					So(*collection.PublishedAt, ShouldEqual, publishedAt)
+				})
+
+				Convey("Then the collection can no longer be edited", func() {
+					So(s.UpsertDraftPage(ctx, "123", draft), ShouldEqual, apierrors.ErrCollectionPublished)
+					So(s.PublishCollection(ctx, "123", publishedAt), ShouldEqual, apierrors.ErrCollectionPublished)
+				})
+			})
+		})
+
+		Convey("When the collection is deleted", func() {
+			So(s.DeleteCollection(ctx, "123"), ShouldBeNil)
+
+			Convey("Then it can no longer be retrieved", func() {
+				_, err := s.GetCollection(ctx, "123")
+				So(err, ShouldEqual, apierrors.ErrCollectionNotFound)
+				collections, err := s.GetCollections(ctx)
+				So(err, ShouldBeNil)
+				So(collections, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// GetBreadcrumb returns a summary of every page above the page at the provided URI in the taxonomy, starting
+// from the root. URIs above the page that have no page of their own are left out.
+func (s *Store) GetBreadcrumb(ctx context.Context, uri string) ([]*models.PageSummary, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, ok := s.pages[uri]; !ok {
+		return nil, apierrors.ErrPageNotFound
+	}
+
+	breadcrumb := []*models.PageSummary{}
+	for _, ancestor := range models.AncestorURIs(uri) {
+		if page, ok := s.pages[ancestor]; ok {
+			summary, err := models.Summarise(page)
+			if err != nil {
+				return nil, err
+			}
+			breadcrumb = append(breadcrumb, summary)
+		}
+	}
+	return breadcrumb, nil
+}
+
+// GetChildren returns a summary of every page directly below the page at the provided URI in the taxonomy, by URI
+func (s *Store) GetChildren(ctx context.Context, uri string) ([]*models.PageSummary, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, ok := s.pages[uri]; !ok {
+		return nil, apierrors.ErrPageNotFound
+	}
+
+	children := []*models.PageSummary{}
+	for child := range s.children[uri] {
+		summary, err := models.Summarise(s.pages[child])
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, summary)
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].URI < children[j].URI
+	})
+	return children, nil
+}
+
+// GetSubtree returns the page at the provided URI and every page below it in the taxonomy, by URI
+func (s *Store) GetSubtree(ctx context.Context, uri string) ([]*models.Page, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, ok := s.pages[uri]; !ok {
+		return nil, apierrors.ErrPageNotFound
+	}
+
+	pages := []*models.Page{}
+	for _, within := range s.subtree(uri) {
+		pages = append(pages, copyPage(s.pages[within]))
+	}
+	return pages, nil
+}
+
+// MovePages moves the page at from and every page below it in the taxonomy to the same place below to, along with
+// their translations and previous versions, and redirects each old URI to the new one. Either every page is moved,
+// or none of them are. Pages cannot be moved while they have drafts in a collection, or onto pages that already exist.
+func (s *Store) MovePages(ctx context.Context, from, to string, movedAt time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.pages[from]; !ok {
+		return apierrors.ErrPageNotFound
+	}
+
+	uris := s.subtree(from)
+	for _, drafts := range s.drafts {
+		for uri := range drafts {
+			if models.IsWithin(uri, from) {
+				return apierrors.ErrPageHasDrafts
+			}
+		}
+	}
+
+	// every page is moved before any are stored, so that a page that cannot be moved leaves the store unchanged
+	moved := make(map[string]*models.Page, len(uris))
+	translations := make(map[models.Language]map[string]*models.Page)
+	for _, uri := range uris {
+		dest := models.MovedURI(uri, from, to)
+		if _, ok := s.pages[dest]; ok || len(s.versions[dest]) > 0 {
+			return apierrors.ErrPageAlreadyExists
+		}
+
+		page, err := s.pages[uri].Move(dest, movedAt)
+		if err != nil {
+			return err
+		}
+		moved[uri] = page
+
+		for lang, pages := range s.translations {
+			if translation, ok := pages[uri]; ok {
+				if translations[lang] == nil {
+					translations[lang] = make(map[string]*models.Page)
+				}
+				if translations[lang][uri], err = translation.Move(dest, movedAt); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, uri := range uris {
+		dest := moved[uri].URI
+		delete(s.pages, uri)
+		s.removeFromHierarchy(uri)
+		s.pages[dest] = moved[uri]
+		s.indexPage(dest)
+		s.storeRedirect(&models.Redirect{From: uri, To: dest, LastUpdated: movedAt})
+
+		for lang, pages := range translations {
+			if translation, ok := pages[uri]; ok {
+				delete(s.translations[lang], uri)
+				s.translations[lang][dest] = translation
+			}
+		}
+
+		if versions, ok := s.versions[uri]; ok {
+			for i, version := range versions {
+				versions[i] = version.Move(dest)
+			}
+			delete(s.versions, uri)
+			s.versions[dest] = versions
+		}
+	}
+	return nil
+}
+
+// subtree returns the URIs of the stored pages at and below the URI, in order. The caller must hold the lock.
+func (s *Store) subtree(uri string) []string {
+	var uris []string
+	for within := range s.pages {
+		if models.IsWithin(within, uri) {
+			uris = append(uris, within)
+		}
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// addToHierarchy indexes the page at the URI as a child of its parent. The caller must hold the write lock.
+func (s *Store) addToHierarchy(uri string) {
+	parent := models.ParentURI(uri)
+	if parent == "" {
+		return
+	}
+	if s.children[parent] == nil {
+		s.children[parent] = make(map[string]bool)
+	}
+	s.children[parent][uri] = true
+}
+
+// removeFromHierarchy removes the page at the URI from the children of its parent. The caller must hold the write lock.
+func (s *Store) removeFromHierarchy(uri string) {
+	delete(s.children[models.ParentURI(uri)], uri)
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHierarchy(t *testing.T) {
+	Convey("Given a store containing a tree of pages, with a URI in it that has no page", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, staticPage("/economy", "Economy")), ShouldBeNil)
+		So(s.CreatePage(ctx, staticPage("/economy/inflation", "Inflation")), ShouldBeNil)
+		So(s.CreatePage(ctx, staticPage("/economy/inflation/bulletins/cpi", "Consumer price inflation")), ShouldBeNil)
+		_, err := s.UpsertPage(ctx, staticPage("/economy/gdp", "GDP"))
+		So(err, ShouldBeNil)
+		So(s.CreatePage(ctx, staticPage("/economyandbusiness", "Economy and business")), ShouldBeNil)
+
+		Convey("Then the breadcrumb of the deepest page contains each page above it", func() {
+			breadcrumb, err := s.GetBreadcrumb(ctx, "/economy/inflation/bulletins/cpi")
+			So(err, ShouldBeNil)
+			So(breadcrumb, ShouldResemble, []*models.PageSummary{
+				{URI: "/economy", Type: models.PageTypeStaticPage, Title: "Economy"},
+				{URI: "/economy/inflation", Type: models.PageTypeStaticPage, Title: "Inflation"},
+			})
+		})
+
+		Convey("Then the children of a page are the pages directly below it", func() {
+			children, err := s.GetChildren(ctx, "/economy")
+			So(err, ShouldBeNil)
+			So(children, ShouldResemble, []*models.PageSummary{
+				{URI: "/economy/gdp", Type: models.PageTypeStaticPage, Title: "GDP"},
+				{URI: "/economy/inflation", Type: models.PageTypeStaticPage, Title: "Inflation"},
+			})
+		})
+
+		Convey("Then the subtree of a page contains it and every page below it", func() {
+			pages, err := s.GetSubtree(ctx, "/economy/inflation")
+			So(err, ShouldBeNil)
+			So(pages, ShouldHaveLength, 2)
+			So(pages[1].URI, ShouldEqual, "/economy/inflation/bulletins/cpi")
+		})
+
+		Convey("Then a page that does not exist has no breadcrumb, children or subtree", func() {
+			_, err := s.GetBreadcrumb(ctx, "/economy/inflation/bulletins")
+			So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			_, err = s.GetChildren(ctx, "/economy/inflation/bulletins")
+			So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			_, err = s.GetSubtree(ctx, "/economy/inflation/bulletins")
+			So(err, ShouldEqual, apierrors.ErrPageNotFound)
+		})
+
+		Convey("When a page is deleted", func() {
+			So(s.DeletePage(ctx, "/economy/gdp"), ShouldBeNil)
+
+			Convey("Then it is no longer a child of its parent", func() {
+				children, err := s.GetChildren(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(children, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a subtree with a translation and a previous version is moved", func() {
+			movedAt := time.Date(2021, 4, 1, 9, 30, 0, 0, time.UTC)
+			So(s.CreateTranslation(ctx, models.LanguageWelsh, staticPage("/economy/inflation", "Chwyddiant")), ShouldBeNil)
+			_, err := s.UpsertPage(ctx, staticPage("/economy/inflation", "Inflation and prices"))
+			So(err, ShouldBeNil)
+			So(s.MovePages(ctx, "/economy/inflation", "/economy/prices", movedAt), ShouldBeNil)
+
+			Convey("Then every page in it is stored below the destination instead", func() {
+				_, err := s.GetPage(ctx, "/economy/inflation")
+				So(err, ShouldEqual, apierrors.ErrPageNotFound)
+				page, err := s.GetPage(ctx, "/economy/prices/bulletins/cpi")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/economy/prices/bulletins/cpi","description":{"title":"Consumer price inflation"}}`)
+				So(page.LastUpdated, ShouldEqual, movedAt)
+			})
+
+			Convey("Then the translation and previous version are moved with it", func() {
+				translation, err := s.GetTranslation(ctx, "/economy/prices", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(string(translation.Data), ShouldContainSubstring, "Chwyddiant")
+				versions, err := s.GetPageVersions(ctx, "/economy/prices")
+				So(err, ShouldBeNil)
+				So(versions, ShouldHaveLength, 1)
+				So(versions[0].URI, ShouldEqual, "/economy/prices/previous/v1")
+			})
+
+			Convey("Then the hierarchy reflects the move", func() {
+				children, err := s.GetChildren(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(children[1].URI, ShouldEqual, "/economy/prices")
+				breadcrumb, err := s.GetBreadcrumb(ctx, "/economy/prices/bulletins/cpi")
+				So(err, ShouldBeNil)
+				So(breadcrumb[1].Title, ShouldEqual, "Inflation and prices")
+			})
+		})
+
+		Convey("When a subtree is moved onto a page that exists", func() {
+			err := s.MovePages(ctx, "/economy/inflation", "/economy/gdp", time.Now())
+
+			Convey("Then it fails and no page is moved", func() {
+				So(err, ShouldEqual, apierrors.ErrPageAlreadyExists)
+				_, err := s.GetPage(ctx, "/economy/inflation/bulletins/cpi")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When a subtree containing a draft in a collection is moved", func() {
+			So(s.CreateCollection(ctx, &models.Collection{ID: "123", State: models.CollectionStateInProgress}), ShouldBeNil)
+			So(s.UpsertDraftPage(ctx, "123", staticPage("/economy/inflation/bulletins/cpi", "CPI")), ShouldBeNil)
+			err := s.MovePages(ctx, "/economy", "/theeconomy", time.Now())
+
+			Convey("Then it fails", func() {
+				So(err, ShouldEqual, apierrors.ErrPageHasDrafts)
+				_, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// GetRedirect returns the redirect from the provided URI
+func (s *Store) GetRedirect(ctx context.Context, from string) (*models.Redirect, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	redirect, ok := s.redirects[from]
+	if !ok {
+		return nil, apierrors.ErrRedirectNotFound
+	}
+	c := *redirect
+	return &c, nil
+}
+
+// GetRedirects returns every redirect, by the URI it is from
+func (s *Store) GetRedirects(ctx context.Context) ([]*models.Redirect, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	redirects := make([]*models.Redirect, 0, len(s.redirects))
+	for _, redirect := range s.redirects {
+		c := *redirect
+		redirects = append(redirects, &c)
+	}
+
+	sort.Slice(redirects, func(i, j int) bool {
+		return redirects[i].From < redirects[j].From
+	})
+	return redirects, nil
+}
+
+// UpsertRedirect creates or replaces the redirect from its URI, returning true if a new redirect was created. A
+// redirect to a URI that is itself redirected is stored as a redirect to the end of the chain, and redirects that
+// led to its URI are changed to lead to the same place. The redirect is updated to where it leads once stored.
+// Redirects that would lead back to their own URI are rejected.
+func (s *Store) UpsertRedirect(ctx context.Context, redirect *models.Redirect) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, exists := s.redirects[redirect.From]
+	if err := s.checkRedirect(redirect); err != nil {
+		return false, err
+	}
+	s.storeRedirect(redirect)
+	return !exists, nil
+}
+
+// UpsertRedirects creates or replaces each of the redirects as UpsertRedirect does. Either every redirect is
+// stored, or none of them are.
+func (s *Store) UpsertRedirects(ctx context.Context, redirects []*models.Redirect) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the redirects are stored on a copy, so that a redirect that cannot be stored leaves the store unchanged
+	stored := s.redirects
+	s.redirects = make(map[string]*models.Redirect, len(stored))
+	for from, redirect := range stored {
+		c := *redirect
+		s.redirects[from] = &c
+	}
+
+	for _, redirect := range redirects {
+		if err := s.checkRedirect(redirect); err != nil {
+			s.redirects = stored
+			return err
+		}
+		s.storeRedirect(redirect)
+	}
+	return nil
+}
+
+// DeleteRedirect removes the redirect from the provided URI
+func (s *Store) DeleteRedirect(ctx context.Context, from string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.redirects[from]; !ok {
+		return apierrors.ErrRedirectNotFound
+	}
+	delete(s.redirects, from)
+	return nil
+}
+
+// checkRedirect follows the redirect to the end of any chain it starts, failing if it would lead back to its own URI
+// or if its URI has a page. The caller must hold the write lock.
+func (s *Store) checkRedirect(redirect *models.Redirect) error {
+	if _, ok := s.pages[redirect.From]; ok {
+		return apierrors.ErrRedirectFromPage
+	}
+	if next, ok := s.redirects[redirect.To]; ok {
+		redirect.To = next.To
+	}
+	if redirect.To == redirect.From {
+		return apierrors.ErrRedirectLoop
+	}
+	return nil
+}
+
+// storeRedirect stores a copy of a checked redirect, changing the redirects that led to its URI to lead to the
+// same place. The caller must hold the write lock.
+func (s *Store) storeRedirect(redirect *models.Redirect) {
+	for _, previous := range s.redirects {
+		if previous.To == redirect.From {
+			previous.To = redirect.To
+			previous.LastUpdated = redirect.LastUpdated
+		}
+	}
+	c := *redirect
+	s.redirects[redirect.From] = &c
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedirects(t *testing.T) {
+	lastUpdated := time.Date(2021, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a store containing a redirect", t, func() {
+		s := New()
+		created, err := s.UpsertRedirect(ctx, &models.Redirect{From: "/inflation", To: "/economy/inflation", LastUpdated: lastUpdated})
+		So(err, ShouldBeNil)
+		So(created, ShouldBeTrue)
+
+		Convey("Then it can be retrieved", func() {
+			redirect, err := s.GetRedirect(ctx, "/inflation")
+			So(err, ShouldBeNil)
+			So(redirect, ShouldResemble, &models.Redirect{From: "/inflation", To: "/economy/inflation", LastUpdated: lastUpdated})
+		})
+
+		Convey("When a redirect is added from where it leads", func() {
+			_, err := s.UpsertRedirect(ctx, &models.Redirect{From: "/economy/inflation", To: "/economy/prices", LastUpdated: lastUpdated})
+			So(err, ShouldBeNil)
+
+			Convey("Then the existing redirect leads to the end of the chain", func() {
+				redirects, err := s.GetRedirects(ctx)
+				So(err, ShouldBeNil)
+				So(redirects, ShouldResemble, []*models.Redirect{
+					{From: "/economy/inflation", To: "/economy/prices", LastUpdated: lastUpdated},
+					{From: "/inflation", To: "/economy/prices", LastUpdated: lastUpdated},
+				})
+			})
+		})
+
+		Convey("When a redirect is added to its URI", func() {
+			redirect := &models.Redirect{From: "/cpi", To: "/inflation", LastUpdated: lastUpdated}
+			_, err := s.UpsertRedirect(ctx, redirect)
+
+			Convey("Then it leads to the end of the chain instead", func() {
+				So(err, ShouldBeNil)
+				So(redirect.To, ShouldEqual, "/economy/inflation")
+			})
+		})
+
+		Convey("When a redirect back to its URI is added", func() {
+			_, err := s.UpsertRedirect(ctx, &models.Redirect{From: "/economy/inflation", To: "/inflation"})
+
+			Convey("Then it is rejected as a loop", func() {
+				So(err, ShouldEqual, apierrors.ErrRedirectLoop)
+			})
+		})
+
+		Convey("When a page is created at its URI", func() {
+			So(s.CreatePage(ctx, staticPage("/inflation", "Inflation")), ShouldBeNil)
+
+			Convey("Then the redirect is removed, as the page answers requests for the URI", func() {
+				_, err := s.GetRedirect(ctx, "/inflation")
+				So(err, ShouldEqual, apierrors.ErrRedirectNotFound)
+			})
+
+			Convey("Then a redirect from the page cannot be added", func() {
+				_, err := s.UpsertRedirect(ctx, &models.Redirect{From: "/inflation", To: "/economy"})
+				So(err, ShouldEqual, apierrors.ErrRedirectFromPage)
+			})
+		})
+
+		Convey("When several redirects are added, one of which loops", func() {
+			err := s.UpsertRedirects(ctx, []*models.Redirect{
+				{From: "/cpi", To: "/inflation"},
+				{From: "/economy/inflation", To: "/cpi"},
+			})
+
+			Convey("Then none of them are stored", func() {
+				So(err, ShouldEqual, apierrors.ErrRedirectLoop)
+				redirects, err := s.GetRedirects(ctx)
+				So(err, ShouldBeNil)
+				So(redirects, ShouldHaveLength, 1)
+				So(redirects[0].To, ShouldEqual, "/economy/inflation")
+			})
+		})
+
+		Convey("When it is deleted", func() {
+			So(s.DeleteRedirect(ctx, "/inflation"), ShouldBeNil)
+
+			Convey("Then it no longer exists", func() {
+				So(s.DeleteRedirect(ctx, "/inflation"), ShouldEqual, apierrors.ErrRedirectNotFound)
+			})
+		})
+	})
+
+	Convey("Given a page that has a redirect to it", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, staticPage("/economy/inflation", "Inflation")), ShouldBeNil)
+		_, err := s.UpsertRedirect(ctx, &models.Redirect{From: "/inflation", To: "/economy/inflation"})
+		So(err, ShouldBeNil)
+
+		Convey("When the page is moved", func() {
+			So(s.MovePages(ctx, "/economy/inflation", "/economy/prices", lastUpdated), ShouldBeNil)
+
+			Convey("Then its old URI, and the redirect to it, lead to its new URI", func() {
+				redirects, err := s.GetRedirects(ctx)
+				So(err, ShouldBeNil)
+				So(redirects, ShouldResemble, []*models.Redirect{
+					{From: "/economy/inflation", To: "/economy/prices", LastUpdated: lastUpdated},
+					{From: "/inflation", To: "/economy/prices", LastUpdated: lastUpdated},
+				})
+			})
+
+			Convey("And moved back again", func() {
+				So(s.MovePages(ctx, "/economy/prices", "/economy/inflation", lastUpdated), ShouldBeNil)
+
+				Convey("Then the redirects lead to where it is, without a loop", func() {
+					redirects, err := s.GetRedirects(ctx)
+					So(err, ShouldBeNil)
+					So(redirects, ShouldResemble, []*models.Redirect{
+						{From: "/economy/prices", To: "/economy/inflation", LastUpdated: lastUpdated},
+						{From: "/inflation", To: "/economy/inflation", LastUpdated: lastUpdated},
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// GetReleases returns the page of releases matching the filter, in order of release date, along with the total
+// number of releases that match it
+func (s *Store) GetReleases(ctx context.Context, filter models.ReleaseFilter) ([]*models.ReleaseSummary, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	releases := []*models.ReleaseSummary{}
+	for _, page := range s.pages {
+		if page.Type != models.PageTypeRelease {
+			continue
+		}
+		release, err := models.SummariseRelease(page)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.Matches(release) {
+			releases = append(releases, release)
+		}
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		if !releases[i].ReleaseDate.Equal(releases[j].ReleaseDate) {
+			return releases[i].ReleaseDate.Before(releases[j].ReleaseDate)
+		}
+		return releases[i].URI < releases[j].URI
+	})
+
+	total := len(releases)
+	if filter.Offset >= total {
+		return []*models.ReleaseSummary{}, total, nil
+	}
+	releases = releases[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(releases) {
+		releases = releases[:filter.Limit]
+	}
+	return releases, total, nil
+}
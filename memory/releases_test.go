@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func releasePage(uri, releaseDate string, finalised bool) *models.Page {
+	return &models.Page{
+		URI:  uri,
+		Type: models.PageTypeRelease,
+		Data: json.RawMessage(fmt.Sprintf(`{"type":"release","description":{"title":"Release","releaseDate":%q,"finalised":%t}}`, releaseDate, finalised)),
+	}
+}
+
+func TestGetReleases(t *testing.T) {
+	Convey("Given a store containing releases and a page that is not a release", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, releasePage("/releases/gdp", "2021-05-12T06:00:00Z", true)), ShouldBeNil)
+		So(s.CreatePage(ctx, releasePage("/releases/cpi", "2021-04-21T06:00:00Z", true)), ShouldBeNil)
+		So(s.CreatePage(ctx, releasePage("/releases/lms", "2021-04-21T06:00:00Z", false)), ShouldBeNil)
+		So(s.CreatePage(ctx, staticPage("/releases", "Release calendar")), ShouldBeNil)
+
+		Convey("Then every release is listed in order of release date", func() {
+			releases, total, err := s.GetReleases(ctx, models.ReleaseFilter{})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 3)
+			So(releases, ShouldHaveLength, 3)
+			So(releases[0].URI, ShouldEqual, "/releases/cpi")
+			So(releases[1].URI, ShouldEqual, "/releases/lms")
+			So(releases[2].URI, ShouldEqual, "/releases/gdp")
+		})
+
+		Convey("Then releases can be filtered by status and date", func() {
+			releases, total, err := s.GetReleases(ctx, models.ReleaseFilter{
+				Status: models.ReleaseStatusConfirmed,
+				To:     time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC),
+			})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 1)
+			So(releases[0].URI, ShouldEqual, "/releases/cpi")
+		})
+
+		Convey("Then a page of the releases is returned with the total number of releases", func() {
+			releases, total, err := s.GetReleases(ctx, models.ReleaseFilter{Offset: 1, Limit: 1})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 3)
+			So(releases, ShouldHaveLength, 1)
+			So(releases[0].URI, ShouldEqual, "/releases/lms")
+
+			releases, total, err = s.GetReleases(ctx, models.ReleaseFilter{Offset: 3, Limit: 1})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 3)
+			So(releases, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// Store is an in-memory content store, intended for use in tests and local development
+type Store struct {
+	mutex        sync.RWMutex
+	pages        map[string]*models.Page
+	collections  map[string]*models.Collection
+	drafts       map[string]map[string]*models.Page
+	versions     map[string][]*models.PageVersion
+	translations map[models.Language]map[string]*models.Page
+	children     map[string]map[string]bool
+	redirects    map[string]*models.Redirect
+	audit        []*models.AuditRecord
+}
+
+// New creates an empty in-memory content store
+func New() *Store {
+	return &Store{
+		pages:        make(map[string]*models.Page),
+		collections:  make(map[string]*models.Collection),
+		drafts:       make(map[string]map[string]*models.Page),
+		versions:     make(map[string][]*models.PageVersion),
+		translations: make(map[models.Language]map[string]*models.Page),
+		children:     make(map[string]map[string]bool),
+		redirects:    make(map[string]*models.Redirect),
+	}
+}
+
+// Checker reports the in-memory store as healthy, as it has no external dependencies
+func (s *Store) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	return state.Update(healthcheck.StatusOK, "in-memory store is OK", 0)
+}
+
+// Close is a no-op, provided so that the in-memory store can stand in for MongoDB
+func (s *Store) Close(ctx context.Context) error {
+	return nil
+}
+
+// GetPage returns the page stored against the provided URI
+func (s *Store) GetPage(ctx context.Context, uri string) (*models.Page, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	page, ok := s.pages[uri]
+	if !ok {
+		return nil, apierrors.ErrPageNotFound
+	}
+	return copyPage(page), nil
+}
+
+// CreatePage stores a new page, failing if a page already exists at its URI
+func (s *Store) CreatePage(ctx context.Context, page *models.Page) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.pages[page.URI]; ok {
+		return apierrors.ErrPageAlreadyExists
+	}
+	s.pages[page.URI] = copyPage(page)
+	s.indexPage(page.URI)
+	return nil
+}
+
+// UpsertPage creates or replaces the page at its URI, returning true if a new page was created.
+// A replaced page is kept as a previous version.
+func (s *Store) UpsertPage(ctx context.Context, page *models.Page) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, exists := s.pages[page.URI]
+	s.replacePage(page)
+	return !exists, nil
+}
+
+// ReplacePage replaces the page at its URI only if the stored page still has the ETag, so that changes made since
+// it was read are not overwritten. The replaced page is kept as a previous version.
+func (s *Store) ReplacePage(ctx context.Context, page *models.Page, etag string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.pages[page.URI]
+	if !ok {
+		return apierrors.ErrPageNotFound
+	}
+	if existing.ETag() != etag {
+		return apierrors.ErrEditConflict
+	}
+	s.replacePage(page)
+	return nil
+}
+
+// DeletePage removes the page stored against the provided URI, along with its translations
+func (s *Store) DeletePage(ctx context.Context, uri string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.pages[uri]; !ok {
+		return apierrors.ErrPageNotFound
+	}
+	delete(s.pages, uri)
+	s.removeFromHierarchy(uri)
+	for _, translations := range s.translations {
+		delete(translations, uri)
+	}
+	return nil
+}
+
+// GetPageVersions returns every previous version of the page at the provided URI, oldest first
+func (s *Store) GetPageVersions(ctx context.Context, uri string) ([]*models.PageVersion, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	versions := s.versions[uri]
+	if _, ok := s.pages[uri]; !ok && len(versions) == 0 {
+		return nil, apierrors.ErrPageNotFound
+	}
+
+	copies := make([]*models.PageVersion, len(versions))
+	for i, version := range versions {
+		copies[i] = copyVersion(version)
+	}
+	return copies, nil
+}
+
+// GetPageVersion returns a previous version of the page at the provided URI
+func (s *Store) GetPageVersion(ctx context.Context, uri string, version int) (*models.PageVersion, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	versions := s.versions[uri]
+	if version < 1 || version > len(versions) {
+		return nil, apierrors.ErrVersionNotFound
+	}
+	return copyVersion(versions[version-1]), nil
+}
+
+// replacePage stores the page, keeping any page it replaces as a previous version. The caller must hold the write lock.
+func (s *Store) replacePage(page *models.Page) {
+	if previous, ok := s.pages[page.URI]; ok {
+		version := models.NewPageVersion(previous, page, len(s.versions[page.URI])+1, page.LastUpdated)
+		s.versions[page.URI] = append(s.versions[page.URI], version)
+	}
+	s.pages[page.URI] = copyPage(page)
+	s.indexPage(page.URI)
+}
+
+// indexPage adds a page that has been stored to the hierarchy, and removes any redirect from its URI as the page
+// now answers requests for it. The caller must hold the write lock.
+func (s *Store) indexPage(uri string) {
+	s.addToHierarchy(uri)
+	delete(s.redirects, uri)
+}
+
+// copyVersion returns a deep copy of a version so that callers cannot modify stored state
+func copyVersion(version *models.PageVersion) *models.PageVersion {
+	c := *version
+	c.Data = append([]byte(nil), version.Data...)
+	return &c
+}
+
+// copyPage returns a deep copy of a page so that callers cannot modify stored state
+func copyPage(page *models.Page) *models.Page {
+	c := *page
+	c.Data = append([]byte(nil), page.Data...)
+	return &c
+}
@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ctx = context.Background()
+
+func testPage(uri string) *models.Page {
+	return &models.Page{
+		URI:         uri,
+		Data:        json.RawMessage(`{"description":{"title":"Inflation"}}`),
+		LastUpdated: time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestStore(t *testing.T) {
+	Convey("Given an empty in-memory store", t, func() {
+		s := New()
+
+		Convey("When a page that does not exist is requested", func() {
+			_, err := s.GetPage(ctx, "/economy")
+
+			Convey("Then a not found error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			})
+		})
+
+		Convey("When a page is created", func() {
+			So(s.CreatePage(ctx, testPage("/economy")), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				page, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, testPage("/economy"))
+			})
+
+			Convey("Then creating it again fails", func() {
+				So(s.CreatePage(ctx, testPage("/economy")), ShouldEqual, apierrors.ErrPageAlreadyExists)
+			})
+
+			Convey("Then modifying the retrieved page does not change the stored page", func() {
+				page, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				page.Data[0] = '['
+				stored, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(stored, ShouldResemble, testPage("/economy"))
+			})
+
+			Convey("Then upserting it replaces the page and reports an update", func() {
+				updated := testPage("/economy")
+				updated.Data = json.RawMessage(`{"description":{"title":"Economy"}}`)
+				created, err := s.UpsertPage(ctx, updated)
+				So(err, ShouldBeNil)
+				So(created, ShouldBeFalse)
+
+				page, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, updated)
+			})
+
+			Convey("Then replacing it with its current ETag replaces the page", func() {
+				updated := testPage("/economy")
+				updated.Data = json.RawMessage(`{"description":{"title":"Economy"}}`)
+				So(s.ReplacePage(ctx, updated, testPage("/economy").ETag()), ShouldBeNil)
+
+				page, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, updated)
+			})
+
+			Convey("Then replacing it with an out of date ETag is a conflict, and the page is unchanged", func() {
+				updated := testPage("/economy")
+				updated.Data = json.RawMessage(`{"description":{"title":"Economy"}}`)
+				So(s.ReplacePage(ctx, updated, models.ETag([]byte(`{}`))), ShouldEqual, apierrors.ErrEditConflict)
+
+				page, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, testPage("/economy"))
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(s.DeletePage(ctx, "/economy"), ShouldBeNil)
+				_, err := s.GetPage(ctx, "/economy")
+				So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			})
+		})
+
+		Convey("When a new page is upserted", func() {
+			created, err := s.UpsertPage(ctx, testPage("/economy"))
+
+			Convey("Then a creation is reported", func() {
+				So(err, ShouldBeNil)
+				So(created, ShouldBeTrue)
+			})
+		})
+
+		Convey("When a page that does not exist is replaced", func() {
+			err := s.ReplacePage(ctx, testPage("/economy"), testPage("/economy").ETag())
+
+			Convey("Then a not found error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			})
+		})
+
+		Convey("When a page that does not exist is deleted", func() {
+			err := s.DeletePage(ctx, "/economy")
+
+			Convey("Then a not found error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrPageNotFound)
+			})
+		})
+	})
+}
+
+func TestVersions(t *testing.T) {
+	Convey("Given a store containing a page", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, testPage("/economy")), ShouldBeNil)
+
+		Convey("Then the page has no previous versions", func() {
+			versions, err := s.GetPageVersions(ctx, "/economy")
+			So(err, ShouldBeNil)
+			So(versions, ShouldBeEmpty)
+		})
+
+		Convey("Then a page that does not exist cannot have its versions listed", func() {
+			_, err := s.GetPageVersions(ctx, "/business")
+			So(err, ShouldEqual, apierrors.ErrPageNotFound)
+		})
+
+		Convey("When the page is replaced twice", func() {
+			for i := 0; i < 2; i++ {
+				updated := testPage("/economy")
+				updated.LastUpdated = updated.LastUpdated.Add(time.Duration(i+1) * time.Hour)
+				_, err := s.UpsertPage(ctx, updated)
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then both previous versions are listed, oldest first", func() {
+				versions, err := s.GetPageVersions(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(versions, ShouldHaveLength, 2)
+				So(versions[0].URI, ShouldEqual, "/economy/previous/v1")
+				So(versions[0].LastUpdated, ShouldEqual, testPage("/economy").LastUpdated)
+				So(versions[1].URI, ShouldEqual, "/economy/previous/v2")
+			})
+
+			Convey("Then a previous version can be read", func() {
+				version, err := s.GetPageVersion(ctx, "/economy", 1)
+				So(err, ShouldBeNil)
+				So(version.ToPage(), ShouldResemble, testPage("/economy"))
+			})
+
+			Convey("Then modifying a retrieved version does not change the stored version", func() {
+				version, err := s.GetPageVersion(ctx, "/economy", 1)
+				So(err, ShouldBeNil)
+				version.Data[0] = '['
+				stored, err := s.GetPageVersion(ctx, "/economy", 1)
+				So(err, ShouldBeNil)
+				So(stored.ToPage(), ShouldResemble, testPage("/economy"))
+			})
+
+			Convey("Then a version that does not exist is not found", func() {
+				_, err := s.GetPageVersion(ctx, "/economy", 3)
+				So(err, ShouldEqual, apierrors.ErrVersionNotFound)
+			})
+
+			Convey("Then the versions remain after the page is deleted", func() {
+				So(s.DeletePage(ctx, "/economy"), ShouldBeNil)
+				versions, err := s.GetPageVersions(ctx, "/economy")
+				So(err, ShouldBeNil)
+				So(versions, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// GetTimeseries returns the timeseries page with the CDID, from the source dataset if one is given.
+// ErrTimeseriesAmbiguous is returned if no dataset is given and the CDID is published in more than one.
+func (s *Store) GetTimeseries(ctx context.Context, cdid, datasetID string) (*models.Page, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var found *models.Page
+	for _, page := range s.pages {
+		if page.Type != models.PageTypeTimeseries {
+			continue
+		}
+		content, err := models.ParseContent(page.Data)
+		if err != nil {
+			return nil, err
+		}
+		description := content.Base().Description
+		if description.CDID != cdid || (datasetID != "" && description.DatasetID != datasetID) {
+			continue
+		}
+		if found != nil {
+			return nil, apierrors.ErrTimeseriesAmbiguous
+		}
+		found = page
+	}
+	if found == nil {
+		return nil, apierrors.ErrTimeseriesNotFound
+	}
+	return copyPage(found), nil
+}
+
+// UpdateTimeseriesValues stores the timeseries page in place of the one at its URI, changing only its observations.
+// The page it replaces is not kept as a previous version, as revisions to data are not corrections. The page is
+// only replaced if the stored page still has the ETag given.
+func (s *Store) UpdateTimeseriesValues(ctx context.Context, page *models.Page, etag string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.pages[page.URI]
+	if !ok || existing.Type != models.PageTypeTimeseries {
+		return apierrors.ErrTimeseriesNotFound
+	}
+	if existing.ETag() != etag {
+		return apierrors.ErrEditConflict
+	}
+	s.pages[page.URI] = copyPage(page)
+	return nil
+}
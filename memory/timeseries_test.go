@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func timeseriesPage(uri, cdid, datasetID, months string) *models.Page {
+	return &models.Page{
+		URI:  uri,
+		Type: models.PageTypeTimeseries,
+		Data: json.RawMessage(fmt.Sprintf(`{"type":"timeseries","description":{"title":"CPI","cdid":%q,"datasetId":%q},"months":%s}`, cdid, datasetID, months)),
+	}
+}
+
+func TestTimeseries(t *testing.T) {
+	Convey("Given a CDID published in two datasets", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, timeseriesPage("/timeseries/d7g7/mm23", "D7G7", "MM23", `[{"date":"2021 FEB","value":"0.4"}]`)), ShouldBeNil)
+		So(s.CreatePage(ctx, timeseriesPage("/timeseries/d7g7/mm22", "D7G7", "MM22", `[]`)), ShouldBeNil)
+		So(s.CreatePage(ctx, staticPage("/timeseries", "Timeseries")), ShouldBeNil)
+
+		Convey("Then the timeseries from a dataset is found by its CDID", func() {
+			page, err := s.GetTimeseries(ctx, "D7G7", "MM23")
+			So(err, ShouldBeNil)
+			So(page.URI, ShouldEqual, "/timeseries/d7g7/mm23")
+		})
+
+		Convey("Then the CDID alone is ambiguous", func() {
+			_, err := s.GetTimeseries(ctx, "D7G7", "")
+			So(err, ShouldEqual, apierrors.ErrTimeseriesAmbiguous)
+		})
+
+		Convey("Then a CDID that is not published is not found", func() {
+			_, err := s.GetTimeseries(ctx, "L55O", "")
+			So(err, ShouldEqual, apierrors.ErrTimeseriesNotFound)
+		})
+
+		Convey("When the values of a timeseries are updated", func() {
+			updated := timeseriesPage("/timeseries/d7g7/mm23", "D7G7", "MM23", `[{"date":"2021 FEB","value":"0.4"},{"date":"2021 MAR","value":"0.7"}]`)
+			previous := timeseriesPage("/timeseries/d7g7/mm23", "D7G7", "MM23", `[{"date":"2021 FEB","value":"0.4"}]`)
+			So(s.UpdateTimeseriesValues(ctx, updated, previous.ETag()), ShouldBeNil)
+
+			Convey("Then the timeseries is replaced without a previous version being kept", func() {
+				page, err := s.GetPage(ctx, "/timeseries/d7g7/mm23")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, string(updated.Data))
+				versions, err := s.GetPageVersions(ctx, "/timeseries/d7g7/mm23")
+				So(err, ShouldBeNil)
+				So(versions, ShouldBeEmpty)
+			})
+
+			Convey("Then another update made to the version it replaced is refused", func() {
+				other := timeseriesPage("/timeseries/d7g7/mm23", "D7G7", "MM23", `[{"date":"2021 FEB","value":"0.5"}]`)
+				So(s.UpdateTimeseriesValues(ctx, other, previous.ETag()), ShouldEqual, apierrors.ErrEditConflict)
+				page, err := s.GetPage(ctx, "/timeseries/d7g7/mm23")
+				So(err, ShouldBeNil)
+				So(string(page.Data), ShouldEqual, string(updated.Data))
+			})
+		})
+
+		Convey("Then the values of a page that is not a timeseries cannot be updated", func() {
+			err := s.UpdateTimeseriesValues(ctx, timeseriesPage("/timeseries", "D7G7", "MM23", `[]`), "")
+			So(err, ShouldEqual, apierrors.ErrTimeseriesNotFound)
+		})
+	})
+}
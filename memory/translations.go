@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+// GetTranslation returns the translation of the page at the provided URI into the language
+func (s *Store) GetTranslation(ctx context.Context, uri string, lang models.Language) (*models.Page, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	page, ok := s.translations[lang][uri]
+	if !ok {
+		return nil, apierrors.ErrTranslationNotFound
+	}
+	return copyPage(page), nil
+}
+
+// CreateTranslation stores a new translation of a page, failing if the page already has a translation into the language
+func (s *Store) CreateTranslation(ctx context.Context, lang models.Language, page *models.Page) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.translations[lang][page.URI]; ok {
+		return apierrors.ErrTranslationAlreadyExists
+	}
+	s.storeTranslation(lang, page)
+	return nil
+}
+
+// UpsertTranslation creates or replaces the translation of a page into the language, returning true if a new
+// translation was created. Previous versions of translations are not kept.
+func (s *Store) UpsertTranslation(ctx context.Context, lang models.Language, page *models.Page) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, exists := s.translations[lang][page.URI]
+	s.storeTranslation(lang, page)
+	return !exists, nil
+}
+
+// ReplaceTranslation replaces the translation of a page into the language only if the stored translation still has
+// the ETag, so that changes made since it was read are not overwritten
+func (s *Store) ReplaceTranslation(ctx context.Context, lang models.Language, page *models.Page, etag string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.translations[lang][page.URI]
+	if !ok {
+		return apierrors.ErrTranslationNotFound
+	}
+	if existing.ETag() != etag {
+		return apierrors.ErrEditConflict
+	}
+	s.storeTranslation(lang, page)
+	return nil
+}
+
+// DeleteTranslation removes the translation of the page at the provided URI into the language
+func (s *Store) DeleteTranslation(ctx context.Context, uri string, lang models.Language) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.translations[lang][uri]; !ok {
+		return apierrors.ErrTranslationNotFound
+	}
+	delete(s.translations[lang], uri)
+	return nil
+}
+
+// GetUntranslatedPages returns a summary of every page that has not been translated into the language, by URI
+func (s *Store) GetUntranslatedPages(ctx context.Context, lang models.Language) ([]*models.PageSummary, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	summaries := []*models.PageSummary{}
+	for uri, page := range s.pages {
+		if _, ok := s.translations[lang][uri]; ok {
+			continue
+		}
+		summary, err := models.Summarise(page)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].URI < summaries[j].URI
+	})
+	return summaries, nil
+}
+
+// storeTranslation stores a copy of the translation. The caller must hold the write lock.
+func (s *Store) storeTranslation(lang models.Language, page *models.Page) {
+	if s.translations[lang] == nil {
+		s.translations[lang] = make(map[string]*models.Page)
+	}
+	s.translations[lang][page.URI] = copyPage(page)
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func staticPage(uri, title string) *models.Page {
+	return &models.Page{
+		URI:  uri,
+		Type: models.PageTypeStaticPage,
+		Data: json.RawMessage(`{"type":"static_page","description":{"title":"` + title + `"}}`),
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	Convey("Given a store containing two English pages", t, func() {
+		s := New()
+		So(s.CreatePage(ctx, staticPage("/aboutus", "About us")), ShouldBeNil)
+		So(s.CreatePage(ctx, staticPage("/economy", "Economy")), ShouldBeNil)
+
+		Convey("Then neither page has been translated", func() {
+			_, err := s.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+			So(err, ShouldEqual, apierrors.ErrTranslationNotFound)
+
+			untranslated, err := s.GetUntranslatedPages(ctx, models.LanguageWelsh)
+			So(err, ShouldBeNil)
+			So(untranslated, ShouldResemble, []*models.PageSummary{
+				{URI: "/aboutus", Type: models.PageTypeStaticPage, Title: "About us"},
+				{URI: "/economy", Type: models.PageTypeStaticPage, Title: "Economy"},
+			})
+		})
+
+		Convey("When one of them is translated into Welsh", func() {
+			So(s.CreateTranslation(ctx, models.LanguageWelsh, staticPage("/aboutus", "Amdanom ni")), ShouldBeNil)
+
+			Convey("Then the translation can be retrieved, leaving the English page as it is", func() {
+				page, err := s.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, staticPage("/aboutus", "Amdanom ni"))
+
+				english, err := s.GetPage(ctx, "/aboutus")
+				So(err, ShouldBeNil)
+				So(english, ShouldResemble, staticPage("/aboutus", "About us"))
+			})
+
+			Convey("Then only the other page is untranslated", func() {
+				untranslated, err := s.GetUntranslatedPages(ctx, models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(untranslated, ShouldHaveLength, 1)
+				So(untranslated[0].URI, ShouldEqual, "/economy")
+			})
+
+			Convey("Then creating it again fails", func() {
+				So(s.CreateTranslation(ctx, models.LanguageWelsh, staticPage("/aboutus", "Amdanom ni")), ShouldEqual, apierrors.ErrTranslationAlreadyExists)
+			})
+
+			Convey("Then upserting it replaces the translation and reports an update", func() {
+				created, err := s.UpsertTranslation(ctx, models.LanguageWelsh, staticPage("/aboutus", "Amdanom"))
+				So(err, ShouldBeNil)
+				So(created, ShouldBeFalse)
+				page, err := s.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, staticPage("/aboutus", "Amdanom"))
+			})
+
+			Convey("Then replacing it with its current ETag replaces the translation", func() {
+				current := staticPage("/aboutus", "Amdanom ni").ETag()
+				So(s.ReplaceTranslation(ctx, models.LanguageWelsh, staticPage("/aboutus", "Amdanom"), current), ShouldBeNil)
+				page, err := s.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldBeNil)
+				So(page, ShouldResemble, staticPage("/aboutus", "Amdanom"))
+			})
+
+			Convey("Then replacing it with an out of date ETag is a conflict", func() {
+				stale := staticPage("/aboutus", "Amdanom").ETag()
+				So(s.ReplaceTranslation(ctx, models.LanguageWelsh, staticPage("/aboutus", "Amdanom"), stale), ShouldEqual, apierrors.ErrEditConflict)
+			})
+
+			Convey("Then the translation can be deleted", func() {
+				So(s.DeleteTranslation(ctx, "/aboutus", models.LanguageWelsh), ShouldBeNil)
+				_, err := s.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldEqual, apierrors.ErrTranslationNotFound)
+				So(s.DeleteTranslation(ctx, "/aboutus", models.LanguageWelsh), ShouldEqual, apierrors.ErrTranslationNotFound)
+			})
+
+			Convey("Then deleting the English page deletes its translation", func() {
+				So(s.DeletePage(ctx, "/aboutus"), ShouldBeNil)
+				_, err := s.GetTranslation(ctx, "/aboutus", models.LanguageWelsh)
+				So(err, ShouldEqual, apierrors.ErrTranslationNotFound)
+			})
+		})
+
+		Convey("When a translation that does not exist is replaced", func() {
+			err := s.ReplaceTranslation(ctx, models.LanguageWelsh, staticPage("/economy", "Economi"), "")
+
+			Convey("Then a not found error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrTranslationNotFound)
+			})
+		})
+
+		Convey("When a translation is upserted", func() {
+			created, err := s.UpsertTranslation(ctx, models.LanguageWelsh, staticPage("/economy", "Economi"))
+
+			Convey("Then a creation is reported", func() {
+				So(err, ShouldBeNil)
+				So(created, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the name of every metric of the service
+const namespace = "content_api"
+
+// The results that operations are labelled with
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// The triggers of collection publishes
+const (
+	TriggerScheduled = "scheduled"
+	TriggerManual    = "manual"
+)
+
+var (
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "The number of HTTP requests handled, by route template, method and status code",
+	}, []string{"route", "method", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "How long HTTP requests took to handle, by route template and method",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	storeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_operation_duration_seconds",
+		Help:      "How long operations on the content store took, by operation and result",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 15},
+	}, []string{"operation", "result"})
+
+	publishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "publish_job_duration_seconds",
+		Help:      "How long publishing a collection took, by what triggered it and its result",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"trigger", "result"})
+
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "The number of lookups in each cache, by whether they were a hit or a miss",
+	}, []string{"cache", "result"})
+
+	cacheHitRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_hit_ratio",
+		Help:      "The proportion of lookups in each cache that were hits, since the service started",
+	}, []string{"cache"})
+
+	cacheEntries = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_entries",
+		Help:      "The number of entries held in each cache",
+	}, []string{"cache"})
+
+	cacheBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cache_bytes",
+		Help:      "The size of the content held in each cache, in bytes",
+	}, []string{"cache"})
+
+	cacheEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_evictions_total",
+		Help:      "The number of entries evicted from each cache to keep it within its size limits",
+	}, []string{"cache"})
+)
+
+// cacheCounts are the hits and lookups of each cache, from which their hit ratios are calculated
+var cacheCounts = struct {
+	sync.Mutex
+	hits    map[string]float64
+	lookups map[string]float64
+}{hits: map[string]float64{}, lookups: map[string]float64{}}
+
+// Handler returns the handler that serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest records a handled HTTP request against the template of the route it matched, e.g.
+// /v1/content/{uri}, so that every URI of a route shares the same series
+func ObserveRequest(template, method string, status int, duration time.Duration) {
+	route := RouteLabel(template)
+	requests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	requestDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+}
+
+// ObserveStoreOperation records how long an operation on the content store took
+func ObserveStoreOperation(operation string, duration time.Duration, err error) {
+	storeDuration.WithLabelValues(operation, result(err)).Observe(duration.Seconds())
+}
+
+// ObservePublish records how long publishing a collection took, from when it started
+func ObservePublish(trigger string, started time.Time, err error) {
+	publishDuration.WithLabelValues(trigger, result(err)).Observe(time.Since(started).Seconds())
+}
+
+// ObserveCache records a lookup in the named cache, and updates its hit ratio
+func ObserveCache(cache string, hit bool) {
+	label := "miss"
+	if hit {
+		label = "hit"
+	}
+	cacheRequests.WithLabelValues(cache, label).Inc()
+
+	cacheCounts.Lock()
+	defer cacheCounts.Unlock()
+	cacheCounts.lookups[cache]++
+	if hit {
+		cacheCounts.hits[cache]++
+	}
+	cacheHitRatio.WithLabelValues(cache).Set(cacheCounts.hits[cache] / cacheCounts.lookups[cache])
+}
+
+// SetCacheSize records the number of entries in the named cache and the size of their content
+func SetCacheSize(cache string, entries int, bytes int64) {
+	cacheEntries.WithLabelValues(cache).Set(float64(entries))
+	cacheBytes.WithLabelValues(cache).Set(float64(bytes))
+}
+
+// ObserveCacheEviction records an entry being evicted from the named cache
+func ObserveCacheEviction(cache string) {
+	cacheEvictions.WithLabelValues(cache).Inc()
+}
+
+// RouteLabel returns the route template without the patterns of its variables, e.g. /v1/content/{uri} for
+// /v1/content/{uri:.*}
+func RouteLabel(template string) string {
+	var b strings.Builder
+	depth := 0
+	inPattern := false
+	for _, c := range template {
+		switch {
+		case c == '{':
+			depth++
+			if depth == 1 {
+				inPattern = false
+			}
+		case c == '}':
+			depth--
+			if depth == 0 {
+				inPattern = false
+			}
+		case c == ':' && depth == 1:
+			inPattern = true
+		}
+		if !inPattern || (c == '}' && depth == 0) {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// result returns the result label for an operation that returned the error
+func result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
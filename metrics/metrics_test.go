@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRouteLabel(t *testing.T) {
+	Convey("Given route templates with variables", t, func() {
+		Convey("Then the patterns of the variables are left out of their labels", func() {
+			So(RouteLabel("/v1/content/{uri:.*}"), ShouldEqual, "/v1/content/{uri}")
+			So(RouteLabel("/v1/content/{uri:.*}/previous/v{version:[0-9]+}"), ShouldEqual, "/v1/content/{uri}/previous/v{version}")
+			So(RouteLabel("/v1/collections/{id}"), ShouldEqual, "/v1/collections/{id}")
+			So(RouteLabel("/v1/codes/{code:[A-Z]{4}}"), ShouldEqual, "/v1/codes/{code}")
+			So(RouteLabel("/health"), ShouldEqual, "/health")
+		})
+	})
+}
+
+func TestObserveRequest(t *testing.T) {
+	Convey("Given requests to two URIs of the same route", t, func() {
+		counter := requests.WithLabelValues("/v1/content/{uri}", http.MethodGet, "200")
+		before := testutil.ToFloat64(counter)
+
+		ObserveRequest("/v1/content/{uri:.*}", http.MethodGet, http.StatusOK, 10*time.Millisecond)
+		ObserveRequest("/v1/content/{uri:.*}", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+
+		Convey("Then both are counted against the route template", func() {
+			So(testutil.ToFloat64(counter)-before, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestObserveCache(t *testing.T) {
+	Convey("Given a cache that is looked up four times, with three hits", t, func() {
+		ObserveCache("test", true)
+		ObserveCache("test", true)
+		ObserveCache("test", false)
+		ObserveCache("test", true)
+
+		Convey("Then its hits and misses are counted", func() {
+			So(testutil.ToFloat64(cacheRequests.WithLabelValues("test", "hit")), ShouldEqual, 3)
+			So(testutil.ToFloat64(cacheRequests.WithLabelValues("test", "miss")), ShouldEqual, 1)
+		})
+
+		Convey("Then its hit ratio is three quarters", func() {
+			So(testutil.ToFloat64(cacheHitRatio.WithLabelValues("test")), ShouldEqual, 0.75)
+		})
+	})
+}
+
+func TestCacheSize(t *testing.T) {
+	Convey("Given a cache that has evicted an entry to stay within its size", t, func() {
+		SetCacheSize("sized", 2, 1024)
+		ObserveCacheEviction("sized")
+
+		Convey("Then its size and evictions are recorded", func() {
+			So(testutil.ToFloat64(cacheEntries.WithLabelValues("sized")), ShouldEqual, 2)
+			So(testutil.ToFloat64(cacheBytes.WithLabelValues("sized")), ShouldEqual, 1024)
+			So(testutil.ToFloat64(cacheEvictions.WithLabelValues("sized")), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestHandler(t *testing.T) {
+	Convey("Given a recorded store operation", t, func() {
+		ObserveStoreOperation("find", 5*time.Millisecond, nil)
+
+		Convey("When the metrics are requested", func() {
+			w := httptest.NewRecorder()
+			Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			Convey("Then they are returned in the Prometheus format", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(strings.Contains(w.Body.String(), `content_api_store_operation_duration_seconds_count{operation="find",result="success"}`), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/log.go/log"
+)
+
+// Access describes a request once it has been handled
+type Access struct {
+	Request   *http.Request
+	Status    int
+	Bytes     int64
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Duration returns how long the request took to handle
+func (a Access) Duration() time.Duration {
+	return a.EndedAt.Sub(a.StartedAt)
+}
+
+// AccessLog returns middleware that passes a description of every request, once it has been handled, to the
+// logger
+func AccessLog(logger func(context.Context, Access)) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			capture := captureResponse(w)
+			started := time.Now().UTC()
+			h.ServeHTTP(capture, req)
+
+			status := capture.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			logger(req.Context(), Access{Request: req, Status: status, Bytes: capture.bytesWritten, StartedAt: started, EndedAt: time.Now().UTC()})
+		})
+	}
+}
+
+// LogAccess logs the request with its method, path, status, duration and the number of bytes in its response
+func LogAccess(ctx context.Context, a Access) {
+	log.Event(ctx, "request handled", log.INFO,
+		log.HTTP(a.Request, a.Status, a.Bytes, &a.StartedAt, &a.EndedAt),
+		log.Data{"bytes": a.Bytes, "duration_ms": a.Duration().Milliseconds()})
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAccessLog(t *testing.T) {
+	Convey("Given an access log in front of a handler", t, func() {
+		var logged []Access
+		accessLog := AccessLog(func(ctx context.Context, a Access) {
+			logged = append(logged, a)
+		})
+
+		Convey("When the handler writes a status and body", func() {
+			handler := accessLog(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"type":"static_page"}`))
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/content/economy", nil))
+
+			Convey("Then the request is logged with the status and the number of bytes written", func() {
+				So(logged, ShouldHaveLength, 1)
+				So(logged[0].Request.Method, ShouldEqual, http.MethodPost)
+				So(logged[0].Request.URL.Path, ShouldEqual, "/v1/content/economy")
+				So(logged[0].Status, ShouldEqual, http.StatusCreated)
+				So(logged[0].Bytes, ShouldEqual, 22)
+				So(logged[0].Duration(), ShouldBeGreaterThanOrEqualTo, time.Duration(0))
+			})
+		})
+
+		Convey("When the handler writes nothing", func() {
+			handler := accessLog(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			Convey("Then the request is logged as a 200 with an empty body", func() {
+				So(logged[0].Status, ShouldEqual, http.StatusOK)
+				So(logged[0].Bytes, ShouldEqual, 0)
+			})
+		})
+	})
+}
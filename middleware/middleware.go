@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-content-api/config"
+)
+
+// requestIDLength is the length of the request IDs created for requests that do not give one
+const requestIDLength = 16
+
+// Middleware wraps a handler with behaviour common to every request
+type Middleware func(http.Handler) http.Handler
+
+// Chain is a list of middleware, in the order that requests pass through them
+type Chain []Middleware
+
+// New returns the middleware chain for the service. Requests are given a request ID before anything else, so that
+// every log event for the request has it, and panics are recovered inside the access log so that it records the
+// 500 returned.
+func New(cfg *config.Config) Chain {
+	chain := Chain{RequestID(requestIDLength)}
+	if cfg.AccessLogEnabled {
+		chain = append(chain, AccessLog(LogAccess))
+	}
+	return append(chain, Recovery)
+}
+
+// Then returns the handler wrapped in every middleware of the chain, with the first middleware outermost
+func (c Chain) Then(h http.Handler) http.Handler {
+	for i := len(c) - 1; i >= 0; i-- {
+		h = c[i](h)
+	}
+	return h
+}
+
+// responseCapture records the status and size of the response written through it
+type responseCapture struct {
+	http.ResponseWriter
+	status       int
+	bytesWritten int64
+}
+
+// WriteHeader records the status before writing it
+func (r *responseCapture) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written, and that the status is 200 if it has not been written
+func (r *responseCapture) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytesWritten += int64(n)
+	return n, err
+}
+
+// Flush sends any buffered data to the client, if the underlying writer supports it
+func (r *responseCapture) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// captureResponse returns the writer wrapped to record its response, unless it already is
+func captureResponse(w http.ResponseWriter) *responseCapture {
+	if c, ok := w.(*responseCapture); ok {
+		return c
+	}
+	return &responseCapture{ResponseWriter: w}
+}
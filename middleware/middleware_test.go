@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChain(t *testing.T) {
+	Convey("Given a chain of middleware that each record when they are called", t, func() {
+		var calls []string
+		record := func(name string) Middleware {
+			return func(h http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					calls = append(calls, name)
+					h.ServeHTTP(w, req)
+				})
+			}
+		}
+		chain := Chain{record("first"), record("second")}
+
+		Convey("When a request is handled through it", func() {
+			handler := chain.Then(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				calls = append(calls, "handler")
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil))
+
+			Convey("Then the request passes through the middleware in order before reaching the handler", func() {
+				So(calls, ShouldResemble, []string{"first", "second", "handler"})
+			})
+		})
+	})
+
+	Convey("Given the configuration of the service", t, func() {
+		cfg := &config.Config{AccessLogEnabled: true}
+
+		Convey("Then the chain gives request IDs, logs access and recovers from panics", func() {
+			So(New(cfg), ShouldHaveLength, 3)
+		})
+
+		Convey("Then the access log is left out if it is disabled", func() {
+			cfg.AccessLogEnabled = false
+			So(New(cfg), ShouldHaveLength, 2)
+		})
+
+		Convey("When a handler panics behind the chain", func() {
+			handler := New(cfg).Then(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				panic("unexpected")
+			}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil))
+
+			Convey("Then a 500 is returned with the ID of the request", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Header().Get("X-Request-Id"), ShouldHaveLength, requestIDLength)
+			})
+		})
+	})
+}
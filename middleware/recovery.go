@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// Recovery is middleware that recovers from panics in the handler, so that a single request cannot stop the
+// service. The panic is logged with the stack trace of where it happened, and a 500 is returned unless the handler
+// had already started its response.
+func Recovery(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		capture := captureResponse(w)
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if p == http.ErrAbortHandler {
+				// the server aborts the response without logging it, as the handler intended
+				panic(p)
+			}
+
+			ctx := req.Context()
+			log.Event(ctx, "recovered from panic in handler", log.ERROR, log.Error(fmt.Errorf("panic: %v", p)),
+				log.Data{"method": req.Method, "path": req.URL.Path, "stack": string(debug.Stack())})
+			if capture.status != 0 {
+				return
+			}
+			if err := apierrors.NewResponse(apierrors.ErrInternalServer).Write(capture, http.StatusInternalServerError); err != nil {
+				log.Event(ctx, "writing error response failed", log.ERROR, log.Error(err))
+			}
+		}()
+		h.ServeHTTP(capture, req)
+	})
+}
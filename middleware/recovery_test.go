@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecovery(t *testing.T) {
+	Convey("Given a handler that panics", t, func() {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			panic("nil map")
+		}))
+
+		Convey("When a request is made", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil))
+
+			Convey("Then a 500 is returned with an internal error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Body.String(), ShouldEqual, `{"errors":[{"code":"InternalError","description":"internal error"}]}`)
+			})
+		})
+	})
+
+	Convey("Given a handler that panics after starting its response", t, func() {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("partial"))
+			panic("connection lost")
+		}))
+
+		Convey("When a request is made", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil))
+
+			Convey("Then the response is left as it was", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, "partial")
+			})
+		})
+	})
+
+	Convey("Given a handler that aborts its response", t, func() {
+		handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		Convey("Then the abort is passed on to the server", func() {
+			So(func() {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil))
+			}, ShouldPanicWith, http.ErrAbortHandler)
+		})
+	})
+}
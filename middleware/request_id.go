@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	dprequest "github.com/ONSdigital/dp-net/request"
+)
+
+// RequestID returns middleware that gives every request an ID, so that the log events for a request can be found
+// together. The ID in the X-Request-Id header is used if the caller gave one, and a random ID of the length is
+// created if not. The ID is added to the context of the request and returned in the X-Request-Id header of the
+// response.
+func RequestID(length int) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			id := req.Header.Get(dprequest.RequestHeaderKey)
+			if id == "" {
+				id = dprequest.NewRequestID(length)
+				req.Header.Set(dprequest.RequestHeaderKey, id)
+			}
+			w.Header().Set(dprequest.RequestHeaderKey, id)
+			h.ServeHTTP(w, req.WithContext(dprequest.WithRequestId(req.Context(), id)))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dprequest "github.com/ONSdigital/dp-net/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestID(t *testing.T) {
+	Convey("Given a handler that records the request ID in its context", t, func() {
+		var contextID string
+		handler := RequestID(16)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			contextID = dprequest.GetRequestId(req.Context())
+		}))
+
+		Convey("When a request is made with a request ID", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+			req.Header.Set("X-Request-Id", "abc123")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			Convey("Then the ID is passed on to the handler and returned", func() {
+				So(contextID, ShouldEqual, "abc123")
+				So(w.Header().Get("X-Request-Id"), ShouldEqual, "abc123")
+			})
+		})
+
+		Convey("When a request is made without a request ID", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/content/economy", nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			Convey("Then an ID is created for it", func() {
+				So(contextID, ShouldHaveLength, 16)
+				So(req.Header.Get("X-Request-Id"), ShouldEqual, contextID)
+				So(w.Header().Get("X-Request-Id"), ShouldEqual, contextID)
+			})
+		})
+	})
+}
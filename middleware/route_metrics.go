@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/gorilla/mux"
+)
+
+// RouteMetrics is router middleware that records the count and latency of requests against the template of the
+// route they matched. It must be added to the router with Use, as the route is only known once it has matched.
+func RouteMetrics(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		template := "unknown"
+		if route := mux.CurrentRoute(req); route != nil {
+			if t, err := route.GetPathTemplate(); err == nil {
+				template = t
+			}
+		}
+
+		capture := captureResponse(w)
+		started := time.Now()
+		h.ServeHTTP(capture, req)
+
+		status := capture.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.ObserveRequest(template, req.Method, status, time.Since(started))
+	})
+}
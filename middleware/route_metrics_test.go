@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRouteMetrics(t *testing.T) {
+	Convey("Given a router that records metrics for a route with a variable", t, func() {
+		r := mux.NewRouter()
+		r.Use(RouteMetrics)
+		r.HandleFunc("/v1/things/{id:[0-9]+}", func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}).Methods(http.MethodGet)
+
+		Convey("When two of its URIs are requested", func() {
+			for _, target := range []string{"/v1/things/123", "/v1/things/456"} {
+				r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+			}
+
+			Convey("Then both requests are counted against the route template, with their status", func() {
+				w := httptest.NewRecorder()
+				metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+				So(w.Body.String(), ShouldContainSubstring, `content_api_http_requests_total{method="GET",route="/v1/things/{id}",status="404"} 2`)
+			})
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/mongo"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+// migrateCommand is the argument that migrates the stored content instead of running the service
+const migrateCommand = "migrate"
+
+// runMigrate adds the fields and index entries that content stored by earlier versions of the service is missing.
+// It is run with: dp-content-api migrate
+func runMigrate(ctx context.Context) error {
+	// stop on interrupt, as the migration can be run again from the start
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+
+	cfg, err := config.Get()
+	if err != nil {
+		return errors.Wrap(err, "error getting configuration")
+	}
+	store, err := mongo.New(ctx, cfg.MongoConfig)
+	if err != nil {
+		return errors.Wrap(err, "connecting to mongodb failed")
+	}
+	defer store.Close(ctx)
+
+	log.Event(ctx, "migrating content", log.INFO)
+	if err := store.Migrate(ctx); err != nil {
+		return errors.Wrap(err, "migration failed")
+	}
+	log.Event(ctx, "migration finished", log.INFO)
+	return nil
+}
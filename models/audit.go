@@ -0,0 +1,93 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction is the kind of change to content that an audit record describes
+type AuditAction string
+
+// The changes to content that are audited
+const (
+	AuditActionCreate      AuditAction = "create"
+	AuditActionUpdate      AuditAction = "update"
+	AuditActionDelete      AuditAction = "delete"
+	AuditActionUpdateDraft AuditAction = "update_draft"
+	AuditActionDeleteDraft AuditAction = "delete_draft"
+	AuditActionPublish     AuditAction = "publish"
+	AuditActionMove        AuditAction = "move"
+
+	AuditActionCreateCollection AuditAction = "create_collection"
+	AuditActionDeleteCollection AuditAction = "delete_collection"
+	AuditActionCompleteItem     AuditAction = "complete_item"
+	AuditActionReviewItem       AuditAction = "review_item"
+	AuditActionUpdateRedirect   AuditAction = "update_redirect"
+	AuditActionDeleteRedirect   AuditAction = "delete_redirect"
+)
+
+// AuditRecord records who changed a page, how and when. The hashes of the page before and after the change
+// identify exactly which content was replaced, and are empty when there was no page before or after it. Changes to
+// translations of a page record the language of the translation, and moves record the URI the page was moved to.
+// Changes to collections record no URI, and changes to redirects record the URI the redirect is from and leads to.
+// A change is recorded before it is made, so a change that the store then fails to make is recorded again as failed.
+type AuditRecord struct {
+	ID           string      `json:"id"`
+	User         string      `json:"user"`
+	Service      bool        `json:"service"`
+	Action       AuditAction `json:"action"`
+	URI          string      `json:"uri"`
+	Lang         Language    `json:"lang,omitempty"`
+	MovedTo      string      `json:"moved_to,omitempty"`
+	RedirectTo   string      `json:"redirect_to,omitempty"`
+	CollectionID string      `json:"collection_id,omitempty"`
+	BeforeHash   string      `json:"before_hash,omitempty"`
+	AfterHash    string      `json:"after_hash,omitempty"`
+	RequestID    string      `json:"request_id,omitempty"`
+	Failed       bool        `json:"failed,omitempty"`
+	Timestamp    time.Time   `json:"timestamp"`
+}
+
+// AuditFilter restricts the audit records that are returned. Empty fields match every record, and the
+// time range includes records made at exactly the from and to times. Offset and limit select a page of the
+// matching records, and a limit of zero returns all of them.
+type AuditFilter struct {
+	URI    string
+	User   string
+	From   time.Time
+	To     time.Time
+	Offset int
+	Limit  int
+}
+
+// Matches returns true if the audit record meets every condition of the filter
+func (f AuditFilter) Matches(record *AuditRecord) bool {
+	switch {
+	case f.URI != "" && record.URI != f.URI:
+		return false
+	case f.User != "" && record.User != f.User:
+		return false
+	case !f.From.IsZero() && record.Timestamp.Before(f.From):
+		return false
+	case !f.To.IsZero() && record.Timestamp.After(f.To):
+		return false
+	}
+	return true
+}
+
+// HashPage returns the SHA-256 hash of the page data, or an empty string if there is no page. Stores keep page data
+// byte for byte as it is given, so the hash of a page being written is also the hash of the page read back.
+func HashPage(page *Page) string {
+	if page == nil {
+		return ""
+	}
+	return hashData(page.Data)
+}
+
+// hashData returns the hex encoded SHA-256 hash of the provided JSON
+func hashData(data json.RawMessage) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAuditFilter(t *testing.T) {
+	Convey("Given an audit record", t, func() {
+		record := &AuditRecord{
+			User:      "publisher@ons.gov.uk",
+			Action:    AuditActionUpdate,
+			URI:       "/economy",
+			Timestamp: time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC),
+		}
+
+		Convey("Then an empty filter matches it", func() {
+			So(AuditFilter{}.Matches(record), ShouldBeTrue)
+		})
+
+		Convey("Then a filter on its URI and user matches it", func() {
+			So(AuditFilter{URI: "/economy", User: "publisher@ons.gov.uk"}.Matches(record), ShouldBeTrue)
+		})
+
+		Convey("Then a filter on another URI or user does not match it", func() {
+			So(AuditFilter{URI: "/employmentandlabourmarket"}.Matches(record), ShouldBeFalse)
+			So(AuditFilter{User: "viewer@ons.gov.uk"}.Matches(record), ShouldBeFalse)
+		})
+
+		Convey("Then a time range including its timestamp matches it", func() {
+			So(AuditFilter{From: record.Timestamp, To: record.Timestamp}.Matches(record), ShouldBeTrue)
+			So(AuditFilter{From: record.Timestamp.Add(-time.Hour)}.Matches(record), ShouldBeTrue)
+		})
+
+		Convey("Then a time range excluding its timestamp does not match it", func() {
+			So(AuditFilter{From: record.Timestamp.Add(time.Second)}.Matches(record), ShouldBeFalse)
+			So(AuditFilter{To: record.Timestamp.Add(-time.Second)}.Matches(record), ShouldBeFalse)
+		})
+	})
+}
+
+func TestHashPage(t *testing.T) {
+	Convey("Given a page", t, func() {
+		page := &Page{URI: "/economy", Data: json.RawMessage(`{"type":"static_page"}`)}
+
+		Convey("Then its hash is the SHA-256 of its data", func() {
+			So(HashPage(page), ShouldEqual, "d2ca3216188c1dd1fd9682449a5519bcee25230211cb6cd08795dfb9c19ccae6")
+		})
+
+		Convey("Then a page with different data has a different hash", func() {
+			So(HashPage(page), ShouldNotEqual, HashPage(&Page{URI: "/economy", Data: json.RawMessage(`{"type":"bulletin"}`)}))
+		})
+	})
+
+	Convey("Then there is no hash without a page", t, func() {
+		So(HashPage(nil), ShouldBeEmpty)
+	})
+}
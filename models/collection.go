@@ -0,0 +1,93 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CollectionState is the publishing state of a collection
+type CollectionState string
+
+// The possible states of a collection
+const (
+	CollectionStateInProgress CollectionState = "in_progress"
+	CollectionStatePublished  CollectionState = "published"
+)
+
+// ItemState is the review state of a draft page within a collection
+type ItemState string
+
+// The possible states of a collection item, in the order they must be progressed through
+const (
+	ItemStateInProgress ItemState = "in_progress"
+	ItemStateComplete   ItemState = "complete"
+	ItemStateReviewed   ItemState = "reviewed"
+)
+
+// Collection is a set of draft pages that are published together
+type Collection struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	State       CollectionState  `json:"state"`
+	Items       []CollectionItem `json:"items"`
+	LastUpdated time.Time        `json:"last_updated"`
+	PublishDate *time.Time       `json:"publish_date,omitempty"`
+	PublishedAt *time.Time       `json:"published_at,omitempty"`
+}
+
+// CollectionItem is a draft page within a collection
+type CollectionItem struct {
+	URI   string    `json:"uri"`
+	Type  PageType  `json:"type"`
+	State ItemState `json:"state"`
+}
+
+// NewCollectionRequest is the body of a request to create a collection
+type NewCollectionRequest struct {
+	Name        string     `json:"name"`
+	PublishDate *time.Time `json:"publish_date,omitempty"`
+}
+
+// ETag returns the entity tag of the collection as it is returned, which changes whenever the collection or the state
+// of any of its items does
+func (c *Collection) ETag() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return ETag(data)
+}
+
+// Published returns a copy of the collection as it is once published at the time
+func (c *Collection) Published(publishedAt time.Time) *Collection {
+	published := *c
+	published.Items = append([]CollectionItem(nil), c.Items...)
+	published.State = CollectionStatePublished
+	published.PublishedAt = &publishedAt
+	published.LastUpdated = publishedAt
+	return &published
+}
+
+// Item returns the item for the page at the provided URI, or nil if the page is not in the collection
+func (c *Collection) Item(uri string) *CollectionItem {
+	for i := range c.Items {
+		if c.Items[i].URI == uri {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
+
+// IsPublishable returns true if the collection has not been published and contains
+// at least one page, with every page reviewed
+func (c *Collection) IsPublishable() bool {
+	if c.State != CollectionStateInProgress || len(c.Items) == 0 {
+		return false
+	}
+	for _, item := range c.Items {
+		if item.State != ItemStateReviewed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCollectionIsPublishable(t *testing.T) {
+	Convey("Given a collection in progress", t, func() {
+		c := &Collection{State: CollectionStateInProgress}
+
+		Convey("Then it cannot be published while it is empty", func() {
+			So(c.IsPublishable(), ShouldBeFalse)
+		})
+
+		Convey("Then it cannot be published while a page has not been reviewed", func() {
+			c.Items = []CollectionItem{
+				{URI: "/economy", State: ItemStateReviewed},
+				{URI: "/economy/inflationandpriceindices", State: ItemStateComplete},
+			}
+			So(c.IsPublishable(), ShouldBeFalse)
+		})
+
+		Convey("Then it can be published once every page has been reviewed", func() {
+			c.Items = []CollectionItem{{URI: "/economy", State: ItemStateReviewed}}
+			So(c.IsPublishable(), ShouldBeTrue)
+			So(c.Item("/economy"), ShouldNotBeNil)
+			So(c.Item("/business"), ShouldBeNil)
+		})
+
+		Convey("Then it cannot be published again once published", func() {
+			c.Items = []CollectionItem{{URI: "/economy", State: ItemStateReviewed}}
+			c.State = CollectionStatePublished
+			So(c.IsPublishable(), ShouldBeFalse)
+		})
+	})
+}
+
+func TestCollectionPublished(t *testing.T) {
+	Convey("Given a collection in progress", t, func() {
+		c := &Collection{ID: "123", State: CollectionStateInProgress, Items: []CollectionItem{{URI: "/economy", State: ItemStateReviewed}}}
+
+		Convey("Then it is returned as published at the time, without changing it", func() {
+			publishedAt := time.Date(2021, 4, 21, 6, 0, 0, 0, time.UTC)
+			published := c.Published(publishedAt)
+			So(published.State, ShouldEqual, CollectionStatePublished)
+			So(*published.PublishedAt, ShouldEqual, publishedAt)
+			So(published.LastUpdated, ShouldEqual, publishedAt)
+			So(published.Items, ShouldResemble, c.Items)
+			So(c.State, ShouldEqual, CollectionStateInProgress)
+			So(c.PublishedAt, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PageType is the discriminating type field of an ONS page
+type PageType string
+
+// The page types that can be stored by the content API
+const (
+	PageTypeBulletin              PageType = "bulletin"
+	PageTypeArticle               PageType = "article"
+	PageTypeCompendiumLandingPage PageType = "compendium_landing_page"
+	PageTypeDatasetLandingPage    PageType = "dataset_landing_page"
+	PageTypeTimeseries            PageType = "timeseries"
+	PageTypeStaticMethodology     PageType = "static_methodology"
+	PageTypeStaticPage            PageType = "static_page"
+	PageTypeRelease               PageType = "release"
+	PageTypeHomePage              PageType = "home_page"
+	PageTypeHomePageCensus        PageType = "home_page_census"
+	PageTypeTaxonomyLandingPage   PageType = "taxonomy_landing_page"
+	PageTypeProductPage           PageType = "product_page"
+	PageTypeCompendiumChapter     PageType = "compendium_chapter"
+	PageTypeCompendiumData        PageType = "compendium_data"
+	PageTypeArticleDownload       PageType = "article_download"
+	PageTypeDataset               PageType = "dataset"
+	PageTypeTimeseriesDataset     PageType = "timeseries_dataset"
+	PageTypeStaticLandingPage     PageType = "static_landing_page"
+	PageTypeStaticArticle         PageType = "static_article"
+	PageTypeStaticQMI             PageType = "static_qmi"
+	PageTypeStaticAdHoc           PageType = "static_adhoc"
+	PageTypeStaticFOI             PageType = "static_foi"
+	PageTypeReferenceTables       PageType = "reference_tables"
+)
+
+// newContentFuncs creates an empty model for each supported page type. Zebedee types that share their structure,
+// such as compendium chapters and articles, share a model.
+var newContentFuncs = map[PageType]func() Content{
+	PageTypeBulletin:              func() Content { return &Bulletin{} },
+	PageTypeArticle:               func() Content { return &Article{} },
+	PageTypeCompendiumLandingPage: func() Content { return &CompendiumLandingPage{} },
+	PageTypeDatasetLandingPage:    func() Content { return &DatasetLandingPage{} },
+	PageTypeTimeseries:            func() Content { return &Timeseries{} },
+	PageTypeStaticMethodology:     func() Content { return &StaticMethodology{} },
+	PageTypeStaticPage:            func() Content { return &StaticPage{} },
+	PageTypeRelease:               func() Content { return &Release{} },
+	PageTypeHomePage:              func() Content { return &HomePage{} },
+	PageTypeHomePageCensus:        func() Content { return &HomePage{} },
+	PageTypeTaxonomyLandingPage:   func() Content { return &TaxonomyLandingPage{} },
+	PageTypeProductPage:           func() Content { return &ProductPage{} },
+	PageTypeCompendiumChapter:     func() Content { return &Article{} },
+	PageTypeCompendiumData:        func() Content { return &DatasetLandingPage{} },
+	PageTypeArticleDownload:       func() Content { return &Article{} },
+	PageTypeDataset:               func() Content { return &Dataset{} },
+	PageTypeTimeseriesDataset:     func() Content { return &Dataset{} },
+	PageTypeStaticLandingPage:     func() Content { return &StaticLandingPage{} },
+	PageTypeStaticArticle:         func() Content { return &StaticMethodology{} },
+	PageTypeStaticQMI:             func() Content { return &StaticDocument{} },
+	PageTypeStaticAdHoc:           func() Content { return &StaticDocument{} },
+	PageTypeStaticFOI:             func() Content { return &StaticDocument{} },
+	PageTypeReferenceTables:       func() Content { return &Dataset{} },
+}
+
+// IsValid returns true if the page type is one that can be stored
+func (t PageType) IsValid() bool {
+	_, ok := newContentFuncs[t]
+	return ok
+}
+
+// Content is implemented by each of the typed ONS page models
+type Content interface {
+	// Base returns the fields common to every page type
+	Base() *PageBase
+	// Validate checks the content against the rules for its page type
+	Validate() ValidationErrors
+}
+
+// ValidationError describes a single field of a page that failed validation
+type ValidationError struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// ValidationErrors is the list of problems found when validating a page
+type ValidationErrors []ValidationError
+
+// Error implements the error interface, summarising every validation failure
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = fmt.Sprintf("%s: %s", e.Field, e.Description)
+	}
+	return "invalid page: " + strings.Join(msgs, ", ")
+}
+
+// ParseContent unmarshals page JSON into the concrete model for its declared type.
+// A ValidationErrors is returned if the type is missing or unsupported, or if the
+// JSON does not match the structure of that type.
+func ParseContent(data []byte) (Content, error) {
+	var header struct {
+		Type PageType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, unmarshalError(err)
+	}
+
+	if header.Type == "" {
+		return nil, ValidationErrors{{Field: "type", Description: "is required"}}
+	}
+
+	newContent, ok := newContentFuncs[header.Type]
+	if !ok {
+		return nil, ValidationErrors{{Field: "type", Description: fmt.Sprintf("unsupported page type %q", header.Type)}}
+	}
+
+	content := newContent()
+	if err := json.Unmarshal(data, content); err != nil {
+		return nil, unmarshalError(err)
+	}
+	return content, nil
+}
+
+// unmarshalError converts a JSON type mismatch into a validation error for the offending field
+func unmarshalError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+		if field == "" {
+			field = "type"
+		}
+		return ValidationErrors{{Field: field, Description: fmt.Sprintf("must be of type %s", typeErr.Type)}}
+	}
+	return err
+}
+
+// validator accumulates validation errors for a page
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(field, description string) {
+	v.errs = append(v.errs, ValidationError{Field: field, Description: description})
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) links(field string, links []Link) {
+	for i, link := range links {
+		if !strings.HasPrefix(link.URI, "/") {
+			v.add(fieldIndex(field, i, "uri"), "must be a site relative URI beginning with /")
+		}
+	}
+}
+
+func (v *validator) sections(field string, sections []Section) {
+	for i, section := range sections {
+		v.required(fieldIndex(field, i, "title"), section.Title)
+	}
+}
+
+func (v *validator) timeseriesValues(field string, values []TimeseriesValue) {
+	for i, value := range values {
+		v.required(fieldIndex(field, i, "date"), value.Date)
+		v.required(fieldIndex(field, i, "value"), value.Value)
+	}
+}
+
+func (v *validator) result() ValidationErrors {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// fieldIndex builds the name of a field within an element of a list, e.g. years[2].value
+func fieldIndex(list string, i int, field string) string {
+	return fmt.Sprintf("%s[%d].%s", list, i, field)
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	validBulletin = `{
+		"type": "bulletin",
+		"uri": "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021",
+		"description": {"title": "Consumer price inflation, UK: February 2021", "releaseDate": "2021-03-24T07:00:00.000Z", "nationalStatistic": true},
+		"sections": [{"title": "Main points", "markdown": "* The CPIH rose by 0.7% in the 12 months to February 2021"}],
+		"relatedBulletins": [{"uri": "/economy/inflationandpriceindices/bulletins/producerpriceinflation/february2021"}]
+	}`
+	validTimeseries = `{
+		"type": "timeseries",
+		"description": {"title": "CPIH ANNUAL RATE 00: ALL ITEMS 2015=100", "cdid": "L55O"},
+		"years": [{"date": "2020", "value": "1.0", "year": "2020"}],
+		"months": [{"date": "2021 FEB", "value": "0.7", "year": "2021", "month": "February"}]
+	}`
+)
+
+func TestParseContent(t *testing.T) {
+	Convey("Given a JSON page for each supported type", t, func() {
+		pages := map[PageType]string{
+			PageTypeBulletin:              validBulletin,
+			PageTypeArticle:               `{"type": "article", "description": {"title": "Coronavirus and the impact on output", "releaseDate": "2021-03-12T07:00:00.000Z"}}`,
+			PageTypeCompendiumLandingPage: `{"type": "compendium_landing_page", "description": {"title": "UK National Accounts, The Blue Book", "releaseDate": "2020-10-30T09:30:00.000Z"}, "chapters": [{"uri": "/economy/grossdomesticproductgdp/compendium/unitedkingdomnationalaccountsthebluebook/2020/chapter1"}]}`,
+			PageTypeDatasetLandingPage:    `{"type": "dataset_landing_page", "description": {"title": "Consumer price inflation", "releaseDate": "2021-03-24T07:00:00.000Z"}, "datasets": [{"uri": "/economy/inflationandpriceindices/datasets/consumerpriceinflation/current"}]}`,
+			PageTypeTimeseries:            validTimeseries,
+			PageTypeStaticMethodology:     `{"type": "static_methodology", "description": {"title": "Consumer Prices Index including owner occupiers' housing costs (CPIH)"}}`,
+			PageTypeStaticPage:            `{"type": "static_page", "description": {"title": "About us"}, "markdown": ["We are the UK's largest independent producer of official statistics."]}`,
+			PageTypeRelease:               `{"type": "release", "description": {"title": "Consumer price inflation, UK: March 2021", "releaseDate": "2021-04-21T06:00:00.000Z", "finalised": true}}`,
+			PageTypeHomePage:              `{"type": "home_page", "description": {}, "sections": [{"theme": {"uri": "/economy"}, "statistics": {"uri": "/economy/grossdomesticproductgdp/timeseries/ihyq/pn2"}}], "serviceMessage": ""}`,
+			PageTypeHomePageCensus:        `{"type": "home_page_census", "description": {"title": "Census"}}`,
+			PageTypeTaxonomyLandingPage:   `{"type": "taxonomy_landing_page", "description": {"title": "Economy"}, "sections": [{"uri": "/economy/inflationandpriceindices"}], "highlightedLinks": [{"uri": "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/february2021"}]}`,
+			PageTypeProductPage:           `{"type": "product_page", "description": {"title": "Inflation and price indices"}, "items": [{"uri": "/economy/inflationandpriceindices/timeseries/l55o/mm23"}], "statsBulletins": [{"uri": "/economy/inflationandpriceindices/bulletins/consumerpriceinflation"}]}`,
+			PageTypeCompendiumChapter:     `{"type": "compendium_chapter", "description": {"title": "Chapter 1: Main points", "releaseDate": "2020-10-30T09:30:00.000Z"}, "sections": [{"title": "Main points", "markdown": "GDP fell"}]}`,
+			PageTypeCompendiumData:        `{"type": "compendium_data", "description": {"title": "Blue Book tables", "releaseDate": "2020-10-30T09:30:00.000Z"}, "downloads": [{"file": "bluebook.xlsx"}]}`,
+			PageTypeArticleDownload:       `{"type": "article_download", "description": {"title": "Output in the construction industry", "releaseDate": "2021-03-12T07:00:00.000Z"}, "downloads": [{"file": "article.pdf"}]}`,
+			PageTypeDataset:               `{"type": "dataset", "description": {"title": "Consumer price inflation", "releaseDate": "2021-03-24T07:00:00.000Z"}, "downloads": [{"file": "consumerpriceinflationdetailedreferencetables.xlsx"}]}`,
+			PageTypeTimeseriesDataset:     `{"type": "timeseries_dataset", "description": {"title": "MM23", "datasetId": "MM23"}, "downloads": [{"file": "mm23.csdb"}]}`,
+			PageTypeStaticLandingPage:     `{"type": "static_landing_page", "description": {"title": "About us"}, "sections": [{"title": "What we do", "uri": "/aboutus/whatwedo"}]}`,
+			PageTypeStaticArticle:         `{"type": "static_article", "description": {"title": "Guide to the census"}, "sections": [{"title": "Overview", "markdown": "The census takes place every 10 years"}]}`,
+			PageTypeStaticQMI:             `{"type": "static_qmi", "description": {"title": "Consumer price inflation QMI"}, "markdown": ["Quality and methodology information"], "downloads": [{"file": "qmi.pdf"}]}`,
+			PageTypeStaticAdHoc:           `{"type": "static_adhoc", "description": {"title": "Deaths by occupation"}, "markdown": ["Ad hoc data"], "downloads": [{"file": "deaths.xlsx"}]}`,
+			PageTypeStaticFOI:             `{"type": "static_foi", "description": {"title": "Staff numbers"}, "markdown": ["Freedom of information response"], "downloads": [{"file": "staff.xlsx"}]}`,
+			PageTypeReferenceTables:       `{"type": "reference_tables", "description": {"title": "Population estimates tables"}, "migrationLink": "/peoplepopulationandcommunity"}`,
+		}
+
+		Convey("Then each is parsed into the concrete model for its type and is valid", func() {
+			for pageType, page := range pages {
+				content, err := ParseContent([]byte(page))
+				So(err, ShouldBeNil)
+				So(content.Base().Type, ShouldEqual, pageType)
+				So(content.Validate(), ShouldBeNil)
+			}
+		})
+
+		Convey("Then every supported type is covered", func() {
+			for pageType := range newContentFuncs {
+				So(pages, ShouldContainKey, pageType)
+				So(pageType.IsValid(), ShouldBeTrue)
+			}
+		})
+	})
+
+	Convey("Given a bulletin", t, func() {
+		content, err := ParseContent([]byte(validBulletin))
+		So(err, ShouldBeNil)
+
+		Convey("Then it is parsed into a Bulletin", func() {
+			bulletin, ok := content.(*Bulletin)
+			So(ok, ShouldBeTrue)
+			So(bulletin.Description.NationalStatistic, ShouldBeTrue)
+			So(bulletin.Sections, ShouldHaveLength, 1)
+			So(bulletin.RelatedBulletins[0].URI, ShouldEqual, "/economy/inflationandpriceindices/bulletins/producerpriceinflation/february2021")
+		})
+
+		Convey("Then marshalling it keeps its type field", func() {
+			b, err := json.Marshal(content)
+			So(err, ShouldBeNil)
+			reparsed, err := ParseContent(b)
+			So(err, ShouldBeNil)
+			So(reparsed, ShouldResemble, content)
+		})
+	})
+
+	Convey("Given a page with no type", t, func() {
+		_, err := ParseContent([]byte(`{"description": {"title": "Untyped"}}`))
+
+		Convey("Then a validation error for the type field is returned", func() {
+			So(err, ShouldResemble, ValidationErrors{{Field: "type", Description: "is required"}})
+		})
+	})
+
+	Convey("Given a page with an unsupported type", t, func() {
+		_, err := ParseContent([]byte(`{"type": "visualisation_page"}`))
+
+		Convey("Then a validation error for the type field is returned", func() {
+			So(err, ShouldResemble, ValidationErrors{{Field: "type", Description: `unsupported page type "visualisation_page"`}})
+		})
+	})
+
+	Convey("Given a page whose type field is not a string", t, func() {
+		_, err := ParseContent([]byte(`{"type": 7}`))
+
+		Convey("Then a validation error for the type field is returned", func() {
+			So(err, ShouldResemble, ValidationErrors{{Field: "type", Description: "must be of type models.PageType"}})
+		})
+	})
+
+	Convey("Given a page whose fields do not match its declared type", t, func() {
+		_, err := ParseContent([]byte(`{"type": "bulletin", "description": {"title": "CPI"}, "sections": "not a list"}`))
+
+		Convey("Then a validation error for the mismatched field is returned", func() {
+			So(err, ShouldResemble, ValidationErrors{{Field: "sections", Description: "must be of type []models.Section"}})
+		})
+	})
+}
+
+func TestValidate(t *testing.T) {
+	Convey("Given a bulletin without a title, release date or section titles", t, func() {
+		content, err := ParseContent([]byte(`{"type": "bulletin", "sections": [{"markdown": "text"}], "relatedBulletins": [{"uri": "economy"}]}`))
+		So(err, ShouldBeNil)
+
+		Convey("Then every problem is reported", func() {
+			So(content.Validate(), ShouldResemble, ValidationErrors{
+				{Field: "description.title", Description: "is required"},
+				{Field: "description.releaseDate", Description: "is required"},
+				{Field: "sections[0].title", Description: "is required"},
+				{Field: "relatedBulletins[0].uri", Description: "must be a site relative URI beginning with /"},
+			})
+		})
+	})
+
+	Convey("Given a timeseries without a CDID and with an incomplete observation", t, func() {
+		content, err := ParseContent([]byte(`{"type": "timeseries", "description": {"title": "CPIH"}, "quarters": [{"date": "2020 Q4"}]}`))
+		So(err, ShouldBeNil)
+
+		Convey("Then the missing CDID and value are reported", func() {
+			So(content.Validate(), ShouldResemble, ValidationErrors{
+				{Field: "description.cdid", Description: "is required"},
+				{Field: "quarters[0].value", Description: "is required"},
+			})
+		})
+	})
+
+	Convey("Given a cancelled release without a cancellation notice", t, func() {
+		content, err := ParseContent([]byte(`{"type": "release", "description": {"title": "CPI", "releaseDate": "2021-04-21T06:00:00.000Z", "cancelled": true}}`))
+		So(err, ShouldBeNil)
+
+		Convey("Then the missing notice is reported", func() {
+			So(content.Validate(), ShouldResemble, ValidationErrors{
+				{Field: "description.cancellationNotice", Description: "is required when a release is cancelled"},
+			})
+		})
+	})
+
+	Convey("Given a set of validation errors", t, func() {
+		errs := ValidationErrors{
+			{Field: "description.title", Description: "is required"},
+			{Field: "type", Description: "is required"},
+		}
+
+		Convey("Then the error message summarises all of them", func() {
+			So(errs.Error(), ShouldEqual, "invalid page: description.title: is required, type: is required")
+		})
+	})
+}
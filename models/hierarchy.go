@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+)
+
+// MoveRequest is the body of a request to move a page, and every page below it, to another URI
+type MoveRequest struct {
+	Destination string `json:"destination"`
+}
+
+// ParentURI returns the URI above the one given in the taxonomy, e.g. "/economy" for
+// "/economy/inflationandpriceindices". The root URI has no parent, and an empty string is returned for it.
+func ParentURI(uri string) string {
+	if uri == "/" {
+		return ""
+	}
+	return path.Dir(uri)
+}
+
+// AncestorURIs returns every URI above the one given in the taxonomy, starting from the root
+func AncestorURIs(uri string) []string {
+	var ancestors []string
+	for parent := ParentURI(uri); parent != ""; parent = ParentURI(parent) {
+		ancestors = append([]string{parent}, ancestors...)
+	}
+	return ancestors
+}
+
+// IsWithin returns true if the URI is the root URI given or is below it in the taxonomy
+func IsWithin(uri, root string) bool {
+	return uri == root || root == "/" || strings.HasPrefix(uri, root+"/")
+}
+
+// MovedURI returns the URI that a page within the subtree at from is moved to when the subtree is moved to to
+func MovedURI(uri, from, to string) string {
+	return to + strings.TrimPrefix(uri, from)
+}
+
+// Move returns the page as it is once moved to the URI, with the URI in its JSON replaced. The page is not
+// validated again, as it was validated when it was stored.
+func (p *Page) Move(uri string, lastUpdated time.Time) (*Page, error) {
+	data, err := UpdateContent(p.Data, func(content Content) error {
+		content.Base().URI = uri
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Page{
+		URI:         uri,
+		Type:        p.Type,
+		Data:        data,
+		LastUpdated: lastUpdated,
+	}, nil
+}
+
+// Move returns the version as a previous version of the page at the URI, when the page is moved there. The
+// content of the version is left as it was, as versions are never modified.
+func (v *PageVersion) Move(pageURI string) *PageVersion {
+	c := *v
+	c.URI = VersionURI(pageURI, v.Version)
+	c.PageURI = pageURI
+	c.Data = append(json.RawMessage(nil), v.Data...)
+	return &c
+}
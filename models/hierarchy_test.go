@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHierarchyURIs(t *testing.T) {
+	Convey("Given the URI of a page in the taxonomy", t, func() {
+		uri := "/economy/inflationandpriceindices/bulletins/consumerpriceinflation"
+
+		Convey("Then its parent is the URI above it", func() {
+			So(ParentURI(uri), ShouldEqual, "/economy/inflationandpriceindices/bulletins")
+			So(ParentURI("/economy"), ShouldEqual, "/")
+			So(ParentURI("/"), ShouldBeEmpty)
+		})
+
+		Convey("Then its ancestors are every URI above it, starting from the root", func() {
+			So(AncestorURIs(uri), ShouldResemble, []string{"/", "/economy", "/economy/inflationandpriceindices", "/economy/inflationandpriceindices/bulletins"})
+			So(AncestorURIs("/"), ShouldBeEmpty)
+		})
+
+		Convey("Then it is within each of its ancestors and itself, but not a sibling with the same prefix", func() {
+			So(IsWithin(uri, uri), ShouldBeTrue)
+			So(IsWithin(uri, "/economy"), ShouldBeTrue)
+			So(IsWithin(uri, "/"), ShouldBeTrue)
+			So(IsWithin("/economy/inflationandpriceindices-old", "/economy/inflationandpriceindices"), ShouldBeFalse)
+		})
+
+		Convey("Then moving the subtree it is in gives it a URI below the destination", func() {
+			So(MovedURI(uri, "/economy/inflationandpriceindices", "/economy/prices"), ShouldEqual, "/economy/prices/bulletins/consumerpriceinflation")
+			So(MovedURI("/economy", "/economy", "/theeconomy"), ShouldEqual, "/theeconomy")
+		})
+	})
+}
+
+func TestMove(t *testing.T) {
+	movedAt := time.Date(2021, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a stored page", t, func() {
+		page := &Page{URI: "/aboutus", Type: PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","uri":"/aboutus","description":{"title":"About us"}}`)}
+
+		Convey("When it is moved", func() {
+			moved, err := page.Move("/about", movedAt)
+
+			Convey("Then it is stored at the new URI, which replaces the URI in its JSON", func() {
+				So(err, ShouldBeNil)
+				So(moved.URI, ShouldEqual, "/about")
+				So(moved.Type, ShouldEqual, PageTypeStaticPage)
+				So(moved.LastUpdated, ShouldEqual, movedAt)
+				So(string(moved.Data), ShouldEqual, `{"type":"static_page","uri":"/about","description":{"title":"About us"}}`)
+			})
+		})
+	})
+
+	Convey("Given a previous version of a page", t, func() {
+		version := &PageVersion{URI: "/aboutus/previous/v2", PageURI: "/aboutus", Version: 2, Data: json.RawMessage(`{"uri":"/aboutus"}`)}
+
+		Convey("When the page is moved", func() {
+			moved := version.Move("/about")
+
+			Convey("Then the version is available below the new URI, with its content unchanged", func() {
+				So(moved.URI, ShouldEqual, "/about/previous/v2")
+				So(moved.PageURI, ShouldEqual, "/about")
+				So(string(moved.Data), ShouldEqual, `{"uri":"/aboutus"}`)
+				So(version.URI, ShouldEqual, "/aboutus/previous/v2")
+			})
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Language is the language that a page is written in, as an ISO 639-1 code
+type Language string
+
+// The languages that pages can be written in. Every page is written in English, and may be translated into Welsh.
+const (
+	LanguageEnglish Language = "en"
+	LanguageWelsh   Language = "cy"
+)
+
+// IsValid returns true if pages can be written in the language
+func (l Language) IsValid() bool {
+	return l == LanguageEnglish || l == LanguageWelsh
+}
+
+// IsTranslation returns true if pages in the language are translations of an English page
+func (l Language) IsTranslation() bool {
+	return l == LanguageWelsh
+}
+
+// PreferredLanguage returns the language most preferred by an Accept-Language header, e.g. "cy-GB, en;q=0.8".
+// Languages that pages are not written in are ignored, and English is returned if none of them are accepted.
+func PreferredLanguage(acceptLanguage string) Language {
+	preferred, preferredQuality := LanguageEnglish, 0.0
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(entry, ";")
+		tag := strings.ToLower(strings.TrimSpace(params[0]))
+		lang := Language(strings.SplitN(tag, "-", 2)[0])
+		if !lang.IsValid() {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				if err != nil {
+					q = 0
+				}
+				quality = q
+			}
+		}
+
+		// the first of equally preferred languages is used
+		if quality > preferredQuality {
+			preferred, preferredQuality = lang, quality
+		}
+	}
+	return preferred
+}
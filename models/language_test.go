@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPreferredLanguage(t *testing.T) {
+	Convey("Given Accept-Language headers", t, func() {
+		headers := map[string]Language{
+			"":                       LanguageEnglish,
+			"cy":                     LanguageWelsh,
+			"CY-GB":                  LanguageWelsh,
+			"en-GB,en;q=0.9":         LanguageEnglish,
+			"en;q=0.8, cy-GB;q=0.9":  LanguageWelsh,
+			"fr, cy;q=0.5, en;q=0.5": LanguageWelsh,
+			"fr, de;q=0.5":           LanguageEnglish,
+			"cy;q=0, en;q=0.1":       LanguageEnglish,
+			"cy;q=invalid, en;q=0.1": LanguageEnglish,
+			"*, cy;q=0.9":            LanguageWelsh,
+		}
+
+		Convey("Then the most preferred language that pages are written in is chosen", func() {
+			for header, lang := range headers {
+				So(PreferredLanguage(header), ShouldEqual, lang)
+			}
+		})
+	})
+}
+
+func TestLanguage(t *testing.T) {
+	Convey("Then English and Welsh are valid languages, and only Welsh is a translation", t, func() {
+		So(LanguageEnglish.IsValid(), ShouldBeTrue)
+		So(LanguageWelsh.IsValid(), ShouldBeTrue)
+		So(Language("fr").IsValid(), ShouldBeFalse)
+		So(LanguageEnglish.IsTranslation(), ShouldBeFalse)
+		So(LanguageWelsh.IsTranslation(), ShouldBeTrue)
+	})
+}
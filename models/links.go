@@ -0,0 +1,62 @@
+package models
+
+import (
+	"reflect"
+	"time"
+)
+
+var linkType = reflect.TypeOf(Link{})
+
+// Reference summarises the page that a link refers to
+type Reference struct {
+	Type        PageType   `json:"type,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+	// Links are the links from the page referred to, resolved in turn until the depth limit is reached
+	Links []*Link `json:"links,omitempty"`
+	// Cycle is true if the page referred to also refers, directly or indirectly, to the page linking to it. The
+	// links from the page are not resolved again.
+	Cycle bool `json:"cycle,omitempty"`
+	// Error describes why the reference could not be resolved, e.g. because the page does not exist
+	Error string `json:"error,omitempty"`
+}
+
+// NewReference returns the summary of the content that a link refers to
+func NewReference(content Content) *Reference {
+	description := content.Base().Description
+	return &Reference{
+		Type:        content.Base().Type,
+		Title:       description.Title,
+		ReleaseDate: description.ReleaseDate,
+	}
+}
+
+// Links returns every link from the content to another page. The links are returned by reference,
+// so that resolving a link updates the content it belongs to.
+func Links(content Content) []*Link {
+	var links []*Link
+	collectLinks(reflect.ValueOf(content), &links)
+	return links
+}
+
+// collectLinks appends every link found in the value to the list, searching through pointers, structs and slices
+func collectLinks(v reflect.Value, links *[]*Link) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			collectLinks(v.Elem(), links)
+		}
+	case reflect.Struct:
+		if v.Type() == linkType {
+			*links = append(*links, v.Addr().Interface().(*Link))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			collectLinks(v.Field(i), links)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectLinks(v.Index(i), links)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLinks(t *testing.T) {
+	Convey("Given a bulletin with related bulletins and data", t, func() {
+		bulletin := &Bulletin{
+			PageBase:         PageBase{Type: PageTypeBulletin, Description: PageDescription{Title: "Consumer price inflation"}},
+			RelatedBulletins: []Link{{URI: "/economy/bulletins/ppi"}, {URI: "/economy/bulletins/hpi"}},
+			RelatedData:      []Link{{URI: "/economy/datasets/cpi"}},
+		}
+
+		Convey("When its links are listed", func() {
+			links := Links(bulletin)
+
+			Convey("Then every link is returned", func() {
+				So(links, ShouldHaveLength, 3)
+				So(links[0].URI, ShouldEqual, "/economy/bulletins/ppi")
+				So(links[1].URI, ShouldEqual, "/economy/bulletins/hpi")
+				So(links[2].URI, ShouldEqual, "/economy/datasets/cpi")
+			})
+
+			Convey("Then updating a link updates the bulletin", func() {
+				links[2].Title = "Consumer price inflation dataset"
+				So(bulletin.RelatedData[0].Title, ShouldEqual, "Consumer price inflation dataset")
+			})
+		})
+	})
+
+	Convey("Given a page without links", t, func() {
+		page := &StaticPage{PageBase: PageBase{Type: PageTypeStaticPage}}
+
+		Convey("Then no links are returned", func() {
+			So(Links(page), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestNewReference(t *testing.T) {
+	Convey("Given a bulletin with a release date", t, func() {
+		releaseDate := time.Date(2021, 3, 24, 7, 0, 0, 0, time.UTC)
+		bulletin := &Bulletin{
+			PageBase: PageBase{Type: PageTypeBulletin, Description: PageDescription{Title: "Consumer price inflation", ReleaseDate: &releaseDate}},
+		}
+
+		Convey("Then it is referred to by its type, title and release date", func() {
+			So(NewReference(bulletin), ShouldResemble, &Reference{Type: PageTypeBulletin, Title: "Consumer price inflation", ReleaseDate: &releaseDate})
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+)
+
+// Page represents a single page of ONS website content, stored against its URI
+type Page struct {
+	URI         string          `json:"uri"`
+	Type        PageType        `json:"type"`
+	Data        json.RawMessage `json:"data"`
+	LastUpdated time.Time       `json:"last_updated"`
+}
+
+// PageSummary identifies a page in lists of pages
+type PageSummary struct {
+	URI   string   `json:"uri"`
+	Type  PageType `json:"type"`
+	Title string   `json:"title"`
+}
+
+// Summarise returns the summary of a page
+func Summarise(page *Page) (*PageSummary, error) {
+	content, err := ParseContent(page.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &PageSummary{URI: page.URI, Type: page.Type, Title: content.Base().Description.Title}, nil
+}
+
+// ETag returns the strong entity tag of the page, which changes whenever its stored data does
+func (p *Page) ETag() string {
+	return ETag(p.Data)
+}
+
+// ETag returns a strong entity tag for the body of a response, quoted as it is given in the ETag header
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// CleanURI normalises a page URI so that equivalent URIs are stored under the same key,
+// e.g. "economy/inflationandpriceindices/" becomes "/economy/inflationandpriceindices"
+func CleanURI(uri string) string {
+	return path.Clean("/" + strings.TrimSpace(uri))
+}
+
+// NewPage parses the JSON of a page, validates it against the model for its declared type and returns the page
+// to store at the URI. The URI of a page is always the one it is stored at, replacing any URI in the JSON, and
+// any resolved references in its links are dropped.
+func NewPage(uri string, data []byte, lastUpdated time.Time) (*Page, error) {
+	return newPage(uri, "", data, lastUpdated)
+}
+
+// NewTranslation returns a page to store as the translation of the page at the URI, as NewPage does. The language of
+// the translation is recorded in its description, as Zebedee does for Welsh pages.
+func NewTranslation(uri string, lang Language, data []byte, lastUpdated time.Time) (*Page, error) {
+	return newPage(uri, lang, data, lastUpdated)
+}
+
+// newPage makes a page from its JSON, setting the language in its description unless it is empty. The JSON is stored
+// as it was given apart from these changes, so that fields the model for its type does not define are kept.
+func newPage(uri string, lang Language, data []byte, lastUpdated time.Time) (*Page, error) {
+	content, data, err := updateContent(data, func(content Content) error {
+		content.Base().URI = uri
+		for _, link := range Links(content) {
+			link.Resolved = nil
+		}
+		if lang != "" {
+			content.Base().Description.Language = string(lang)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if errs := content.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &Page{
+		URI:         uri,
+		Type:        content.Base().Type,
+		Data:        data,
+		LastUpdated: lastUpdated,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCleanURI(t *testing.T) {
+	Convey("Given a set of equivalent page URIs", t, func() {
+		uris := []string{
+			"economy/inflationandpriceindices",
+			"/economy/inflationandpriceindices",
+			"/economy/inflationandpriceindices/",
+			"//economy//inflationandpriceindices",
+			" /economy/inflationandpriceindices ",
+		}
+
+		Convey("Then they are all normalised to the same URI", func() {
+			for _, uri := range uris {
+				So(CleanURI(uri), ShouldEqual, "/economy/inflationandpriceindices")
+			}
+		})
+	})
+
+	Convey("Given an empty URI", t, func() {
+		Convey("Then it is normalised to the root URI", func() {
+			So(CleanURI(""), ShouldEqual, "/")
+		})
+	})
+}
+
+func TestETag(t *testing.T) {
+	Convey("Given a stored page", t, func() {
+		page := &Page{URI: "/aboutus", Data: []byte(`{"type":"static_page","description":{"title":"About us"}}`)}
+
+		Convey("Then its ETag is a quoted tag of its data", func() {
+			etag := page.ETag()
+			So(etag, ShouldStartWith, `"`)
+			So(etag, ShouldEndWith, `"`)
+			So(etag, ShouldHaveLength, 34)
+			So(etag, ShouldEqual, ETag(page.Data))
+		})
+
+		Convey("Then its ETag changes when its data does", func() {
+			changed := &Page{URI: "/aboutus", Data: []byte(`{"type":"static_page","description":{"title":"About the ONS"}}`)}
+			So(changed.ETag(), ShouldNotEqual, page.ETag())
+		})
+	})
+}
+
+func TestNewPage(t *testing.T) {
+	lastUpdated := time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given the JSON of a valid page with a different URI", t, func() {
+		data := []byte(`{"type":"static_page","uri":"/somewhere/else","description":{"title":"About us"},"unknown":true}`)
+
+		Convey("When a page is made from it", func() {
+			page, err := NewPage("/aboutus", data, lastUpdated)
+			So(err, ShouldBeNil)
+
+			Convey("Then the page is stored at its URI, keeping fields that its model does not define", func() {
+				So(page.URI, ShouldEqual, "/aboutus")
+				So(page.Type, ShouldEqual, PageTypeStaticPage)
+				So(page.LastUpdated, ShouldEqual, lastUpdated)
+				So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/aboutus","description":{"title":"About us"},"unknown":true}`)
+			})
+		})
+	})
+
+	Convey("Given the JSON of a page written by Zebedee, with fields the model does not define", t, func() {
+		data := []byte(`{
+			"type": "static_page",
+			"description": {"title": "About us", "_abstract": "Who we are", "releaseDate": "2021-03-17T09:30:00.000Z"},
+			"relatedMethodology": [{"uri": "/methodology/about", "title": "Methods"}],
+			"downloads": [{"title": "Annual report", "file": "report.pdf"}],
+			"links": [{"uri": "/economy", "order": 1, "resolved": {"title": "Economy"}}]
+		}`)
+
+		Convey("Then every field is kept as it was given, apart from the URI and resolved references", func() {
+			page, err := NewPage("/aboutus", data, lastUpdated)
+			So(err, ShouldBeNil)
+			So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/aboutus",`+
+				`"description":{"title":"About us","_abstract":"Who we are","releaseDate":"2021-03-17T09:30:00.000Z"},`+
+				`"relatedMethodology":[{"uri":"/methodology/about","title":"Methods"}],`+
+				`"downloads":[{"title":"Annual report","file":"report.pdf"}],`+
+				`"links":[{"uri":"/economy","order":1}]}`)
+		})
+	})
+
+	Convey("Given the JSON of a page with resolved references", t, func() {
+		data := []byte(`{"type":"static_page","description":{"title":"About us"},"links":[{"uri":"/economy","resolved":{"title":"Economy"}}]}`)
+
+		Convey("Then the references are not kept in the page", func() {
+			page, err := NewPage("/aboutus", data, lastUpdated)
+			So(err, ShouldBeNil)
+			So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/aboutus","description":{"title":"About us"},"links":[{"uri":"/economy"}]}`)
+		})
+	})
+
+	Convey("Given the JSON of a page that fails validation", t, func() {
+		data := []byte(`{"type":"bulletin","description":{"title":"Consumer price inflation"}}`)
+
+		Convey("Then a page cannot be made from it", func() {
+			_, err := NewPage("/economy", data, lastUpdated)
+			So(err, ShouldResemble, ValidationErrors{{Field: "description.releaseDate", Description: "is required"}})
+		})
+	})
+}
+
+func TestNewTranslation(t *testing.T) {
+	Convey("Given the JSON of a Welsh page", t, func() {
+		data := []byte(`{"type":"static_page","description":{"title":"Amdanom ni"}}`)
+
+		Convey("When a Welsh translation is made from it", func() {
+			page, err := NewTranslation("/aboutus", LanguageWelsh, data, time.Now())
+			So(err, ShouldBeNil)
+
+			Convey("Then its language is recorded in its description", func() {
+				So(page.URI, ShouldEqual, "/aboutus")
+				So(string(page.Data), ShouldEqual, `{"type":"static_page","uri":"/aboutus","description":{"title":"Amdanom ni","language":"cy"}}`)
+			})
+		})
+	})
+}
+
+func TestSummarise(t *testing.T) {
+	Convey("Given a page", t, func() {
+		page, err := NewPage("/aboutus", []byte(`{"type":"static_page","description":{"title":"About us","summary":"Who we are"}}`), time.Now())
+		So(err, ShouldBeNil)
+
+		Convey("Then it is summarised by its URI, type and title", func() {
+			summary, err := Summarise(page)
+			So(err, ShouldBeNil)
+			So(summary, ShouldResemble, &PageSummary{URI: "/aboutus", Type: PageTypeStaticPage, Title: "About us"})
+		})
+	})
+}
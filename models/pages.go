@@ -0,0 +1,445 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// PageBase holds the fields common to every page type
+type PageBase struct {
+	Type        PageType        `json:"type"`
+	URI         string          `json:"uri,omitempty"`
+	Description PageDescription `json:"description"`
+}
+
+// Base returns the fields common to every page type
+func (b *PageBase) Base() *PageBase {
+	return b
+}
+
+// validate checks the fields common to every page type
+func (b *PageBase) validate(v *validator) {
+	v.required("description.title", b.Description.Title)
+}
+
+// PageDescription holds the descriptive metadata of a page
+type PageDescription struct {
+	Title              string     `json:"title"`
+	Edition            string     `json:"edition,omitempty"`
+	Summary            string     `json:"summary,omitempty"`
+	Keywords           []string   `json:"keywords,omitempty"`
+	MetaDescription    string     `json:"metaDescription,omitempty"`
+	NationalStatistic  bool       `json:"nationalStatistic,omitempty"`
+	LatestRelease      bool       `json:"latestRelease,omitempty"`
+	Contact            *Contact   `json:"contact,omitempty"`
+	ReleaseDate        *time.Time `json:"releaseDate,omitempty"`
+	NextRelease        string     `json:"nextRelease,omitempty"`
+	DatasetID          string     `json:"datasetId,omitempty"`
+	CDID               string     `json:"cdid,omitempty"`
+	Unit               string     `json:"unit,omitempty"`
+	PreUnit            string     `json:"preUnit,omitempty"`
+	Source             string     `json:"source,omitempty"`
+	Language           string     `json:"language,omitempty"`
+	Published          bool       `json:"published,omitempty"`
+	Finalised          bool       `json:"finalised,omitempty"`
+	Cancelled          bool       `json:"cancelled,omitempty"`
+	CancellationNotice []string   `json:"cancellationNotice,omitempty"`
+	ProvisionalDate    string     `json:"provisionalDate,omitempty"`
+}
+
+// Contact holds the details of the person responsible for a page
+type Contact struct {
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Telephone string `json:"telephone,omitempty"`
+}
+
+// Link is a reference from one page to another
+type Link struct {
+	URI   string `json:"uri"`
+	Title string `json:"title,omitempty"`
+	// Resolved summarises the page linked to when references are resolved for a response. It is never stored.
+	Resolved *Reference `json:"resolved,omitempty"`
+}
+
+// Section is a titled block of markdown
+type Section struct {
+	Title    string `json:"title"`
+	Markdown string `json:"markdown"`
+}
+
+// Figure is a reference to a chart, table, image or equation displayed on a page
+type Figure struct {
+	Title    string `json:"title,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	URI      string `json:"uri"`
+}
+
+// Alert is a notice displayed at the top of a page, e.g. a correction
+type Alert struct {
+	Date     *time.Time `json:"date,omitempty"`
+	Markdown string     `json:"markdown"`
+	Type     string     `json:"type,omitempty"`
+}
+
+// Version describes a previous version of a page, kept when the page is corrected
+type Version struct {
+	URI              string     `json:"uri"`
+	UpdateDate       *time.Time `json:"updateDate,omitempty"`
+	CorrectionNotice string     `json:"correctionNotice,omitempty"`
+	Label            string     `json:"label,omitempty"`
+}
+
+// Bulletin is a statistical bulletin, the main written output of a statistical release
+type Bulletin struct {
+	PageBase
+	Sections         []Section `json:"sections,omitempty"`
+	Accordion        []Section `json:"accordion,omitempty"`
+	RelatedBulletins []Link    `json:"relatedBulletins,omitempty"`
+	RelatedData      []Link    `json:"relatedData,omitempty"`
+	Links            []Link    `json:"links,omitempty"`
+	Charts           []Figure  `json:"charts,omitempty"`
+	Tables           []Figure  `json:"tables,omitempty"`
+	Images           []Figure  `json:"images,omitempty"`
+	Equations        []Figure  `json:"equations,omitempty"`
+	Alerts           []Alert   `json:"alerts,omitempty"`
+	Versions         []Version `json:"versions,omitempty"`
+}
+
+// Validate checks that the bulletin has a release date and well formed sections and links
+func (p *Bulletin) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	if p.Description.ReleaseDate == nil {
+		v.add("description.releaseDate", "is required")
+	}
+	v.sections("sections", p.Sections)
+	v.sections("accordion", p.Accordion)
+	v.links("relatedBulletins", p.RelatedBulletins)
+	v.links("relatedData", p.RelatedData)
+	return v.result()
+}
+
+// Article is a statistical article, providing analysis beyond a single release
+type Article struct {
+	PageBase
+	Sections                  []Section `json:"sections,omitempty"`
+	Accordion                 []Section `json:"accordion,omitempty"`
+	RelatedArticles           []Link    `json:"relatedArticles,omitempty"`
+	RelatedData               []Link    `json:"relatedData,omitempty"`
+	RelatedMethodology        []Link    `json:"relatedMethodology,omitempty"`
+	RelatedMethodologyArticle []Link    `json:"relatedMethodologyArticle,omitempty"`
+	Links                     []Link    `json:"links,omitempty"`
+	Charts                    []Figure  `json:"charts,omitempty"`
+	Tables                    []Figure  `json:"tables,omitempty"`
+	Images                    []Figure  `json:"images,omitempty"`
+	Equations                 []Figure  `json:"equations,omitempty"`
+	Alerts                    []Alert   `json:"alerts,omitempty"`
+	Versions                  []Version `json:"versions,omitempty"`
+}
+
+// Validate checks that the article has a release date and well formed sections and links
+func (p *Article) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	if p.Description.ReleaseDate == nil {
+		v.add("description.releaseDate", "is required")
+	}
+	v.sections("sections", p.Sections)
+	v.sections("accordion", p.Accordion)
+	v.links("relatedArticles", p.RelatedArticles)
+	v.links("relatedData", p.RelatedData)
+	v.links("relatedMethodology", p.RelatedMethodology)
+	v.links("relatedMethodologyArticle", p.RelatedMethodologyArticle)
+	return v.result()
+}
+
+// CompendiumLandingPage is the landing page of a compendium, linking to its chapters and data
+type CompendiumLandingPage struct {
+	PageBase
+	Sections           []Section `json:"sections,omitempty"`
+	Chapters           []Link    `json:"chapters,omitempty"`
+	Datasets           []Link    `json:"datasets,omitempty"`
+	RelatedMethodology []Link    `json:"relatedMethodology,omitempty"`
+	Alerts             []Alert   `json:"alerts,omitempty"`
+}
+
+// Validate checks that the compendium has a release date and well formed chapter links
+func (p *CompendiumLandingPage) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	if p.Description.ReleaseDate == nil {
+		v.add("description.releaseDate", "is required")
+	}
+	v.sections("sections", p.Sections)
+	v.links("chapters", p.Chapters)
+	v.links("datasets", p.Datasets)
+	v.links("relatedMethodology", p.RelatedMethodology)
+	return v.result()
+}
+
+// DatasetLandingPage describes a dataset and links to its downloadable files
+type DatasetLandingPage struct {
+	PageBase
+	Section                   *Section  `json:"section,omitempty"`
+	Notes                     *Section  `json:"notes,omitempty"`
+	Datasets                  []Link    `json:"datasets,omitempty"`
+	RelatedDatasets           []Link    `json:"relatedDatasets,omitempty"`
+	RelatedDocuments          []Link    `json:"relatedDocuments,omitempty"`
+	RelatedMethodology        []Link    `json:"relatedMethodology,omitempty"`
+	RelatedMethodologyArticle []Link    `json:"relatedMethodologyArticle,omitempty"`
+	Alerts                    []Alert   `json:"alerts,omitempty"`
+	Versions                  []Version `json:"versions,omitempty"`
+}
+
+// Validate checks that the dataset landing page has a release date and well formed links
+func (p *DatasetLandingPage) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	if p.Description.ReleaseDate == nil {
+		v.add("description.releaseDate", "is required")
+	}
+	v.links("datasets", p.Datasets)
+	v.links("relatedDatasets", p.RelatedDatasets)
+	v.links("relatedDocuments", p.RelatedDocuments)
+	v.links("relatedMethodology", p.RelatedMethodology)
+	v.links("relatedMethodologyArticle", p.RelatedMethodologyArticle)
+	return v.result()
+}
+
+// TimeseriesValue is a single observation in a timeseries
+type TimeseriesValue struct {
+	Date          string     `json:"date"`
+	Value         string     `json:"value"`
+	Year          string     `json:"year,omitempty"`
+	Quarter       string     `json:"quarter,omitempty"`
+	Month         string     `json:"month,omitempty"`
+	Label         string     `json:"label,omitempty"`
+	SourceDataset string     `json:"sourceDataset,omitempty"`
+	UpdateDate    *time.Time `json:"updateDate,omitempty"`
+}
+
+// Timeseries is a single series of statistics, identified by its CDID
+type Timeseries struct {
+	PageBase
+	Years                     []TimeseriesValue `json:"years,omitempty"`
+	Quarters                  []TimeseriesValue `json:"quarters,omitempty"`
+	Months                    []TimeseriesValue `json:"months,omitempty"`
+	Section                   *Section          `json:"section,omitempty"`
+	Notes                     []string          `json:"notes,omitempty"`
+	RelatedDatasets           []Link            `json:"relatedDatasets,omitempty"`
+	RelatedDocuments          []Link            `json:"relatedDocuments,omitempty"`
+	RelatedData               []Link            `json:"relatedData,omitempty"`
+	RelatedMethodology        []Link            `json:"relatedMethodology,omitempty"`
+	RelatedMethodologyArticle []Link            `json:"relatedMethodologyArticle,omitempty"`
+	Versions                  []Version         `json:"versions,omitempty"`
+}
+
+// Validate checks that the timeseries has a CDID and that every observation has a date and value
+func (p *Timeseries) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.required("description.cdid", p.Description.CDID)
+	v.timeseriesValues("years", p.Years)
+	v.timeseriesValues("quarters", p.Quarters)
+	v.timeseriesValues("months", p.Months)
+	v.links("relatedDatasets", p.RelatedDatasets)
+	v.links("relatedDocuments", p.RelatedDocuments)
+	v.links("relatedData", p.RelatedData)
+	v.links("relatedMethodology", p.RelatedMethodology)
+	v.links("relatedMethodologyArticle", p.RelatedMethodologyArticle)
+	return v.result()
+}
+
+// StaticMethodology is a page describing the methods used to produce statistics
+type StaticMethodology struct {
+	PageBase
+	Sections         []Section `json:"sections,omitempty"`
+	Accordion        []Section `json:"accordion,omitempty"`
+	Links            []Link    `json:"links,omitempty"`
+	RelatedDocuments []Link    `json:"relatedDocuments,omitempty"`
+	RelatedData      []Link    `json:"relatedData,omitempty"`
+	Alerts           []Alert   `json:"alerts,omitempty"`
+}
+
+// Validate checks that the methodology has well formed sections and links
+func (p *StaticMethodology) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.sections("sections", p.Sections)
+	v.sections("accordion", p.Accordion)
+	v.links("relatedDocuments", p.RelatedDocuments)
+	v.links("relatedData", p.RelatedData)
+	return v.result()
+}
+
+// StaticPage is a general page of markdown, such as an about or help page
+type StaticPage struct {
+	PageBase
+	Markdown []string `json:"markdown,omitempty"`
+	Links    []Link   `json:"links,omitempty"`
+}
+
+// Validate checks the fields common to every page, as static pages have no further requirements
+func (p *StaticPage) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	return v.result()
+}
+
+// ReleaseDateChange records a change to the announced date of a release
+type ReleaseDateChange struct {
+	PreviousDate string `json:"previousDate"`
+	ChangeNotice string `json:"changeNotice"`
+}
+
+// Release is a release calendar entry, announcing when statistics will be published
+type Release struct {
+	PageBase
+	Markdown                  []string            `json:"markdown,omitempty"`
+	RelatedDocuments          []Link              `json:"relatedDocuments,omitempty"`
+	RelatedDatasets           []Link              `json:"relatedDatasets,omitempty"`
+	RelatedMethodology        []Link              `json:"relatedMethodology,omitempty"`
+	RelatedMethodologyArticle []Link              `json:"relatedMethodologyArticle,omitempty"`
+	Links                     []Link              `json:"links,omitempty"`
+	DateChanges               []ReleaseDateChange `json:"dateChanges,omitempty"`
+}
+
+// Validate checks that the release has a date, and that a cancelled release says why and was not published
+func (p *Release) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	if p.Description.ReleaseDate == nil {
+		v.add("description.releaseDate", "is required")
+	}
+	if p.Description.Cancelled && len(p.Description.CancellationNotice) == 0 {
+		v.add("description.cancellationNotice", "is required when a release is cancelled")
+	}
+	if p.Description.Cancelled && p.Description.Published {
+		v.add("description.published", "cannot be set for a cancelled release")
+	}
+	for i, change := range p.DateChanges {
+		v.required(fieldIndex("dateChanges", i, "changeNotice"), change.ChangeNotice)
+	}
+	v.links("relatedDocuments", p.RelatedDocuments)
+	v.links("relatedDatasets", p.RelatedDatasets)
+	v.links("relatedMethodology", p.RelatedMethodology)
+	v.links("relatedMethodologyArticle", p.RelatedMethodologyArticle)
+	return v.result()
+}
+
+// HomePage is the home page of the website. Its title is not required, as Zebedee does not give it one.
+type HomePage struct {
+	PageBase
+	Sections []HomeSection `json:"sections,omitempty"`
+}
+
+// HomeSection is a theme featured on the home page, with the statistic highlighted for it
+type HomeSection struct {
+	Theme      *Link `json:"theme,omitempty"`
+	Statistics *Link `json:"statistics,omitempty"`
+}
+
+// Validate checks that the sections of the home page link to site relative URIs
+func (p *HomePage) Validate() ValidationErrors {
+	v := &validator{}
+	for i, section := range p.Sections {
+		if section.Theme != nil {
+			v.links(fmt.Sprintf("sections[%d].theme", i), []Link{*section.Theme})
+		}
+		if section.Statistics != nil {
+			v.links(fmt.Sprintf("sections[%d].statistics", i), []Link{*section.Statistics})
+		}
+	}
+	return v.result()
+}
+
+// TaxonomyLandingPage is a page of the taxonomy, such as a theme, linking to the pages below it
+type TaxonomyLandingPage struct {
+	PageBase
+	Sections         []Link `json:"sections,omitempty"`
+	HighlightedLinks []Link `json:"highlightedLinks,omitempty"`
+}
+
+// Validate checks that the taxonomy landing page has a title and well formed links
+func (p *TaxonomyLandingPage) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.links("sections", p.Sections)
+	v.links("highlightedLinks", p.HighlightedLinks)
+	return v.result()
+}
+
+// ProductPage is the page of a topic at the bottom of the taxonomy, linking to its statistics and publications
+type ProductPage struct {
+	PageBase
+	Items                     []Link `json:"items,omitempty"`
+	Datasets                  []Link `json:"datasets,omitempty"`
+	StatsBulletins            []Link `json:"statsBulletins,omitempty"`
+	RelatedArticles           []Link `json:"relatedArticles,omitempty"`
+	RelatedMethodology        []Link `json:"relatedMethodology,omitempty"`
+	RelatedMethodologyArticle []Link `json:"relatedMethodologyArticle,omitempty"`
+	HighlightedLinks          []Link `json:"highlightedLinks,omitempty"`
+}
+
+// Validate checks that the product page has a title and well formed links
+func (p *ProductPage) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.links("items", p.Items)
+	v.links("datasets", p.Datasets)
+	v.links("statsBulletins", p.StatsBulletins)
+	v.links("relatedArticles", p.RelatedArticles)
+	v.links("relatedMethodology", p.RelatedMethodology)
+	v.links("relatedMethodologyArticle", p.RelatedMethodologyArticle)
+	v.links("highlightedLinks", p.HighlightedLinks)
+	return v.result()
+}
+
+// Dataset is a version of a dataset, or of a set of reference tables, whose files are downloaded from its page
+type Dataset struct {
+	PageBase
+	RelatedDatasets  []Link    `json:"relatedDatasets,omitempty"`
+	RelatedDocuments []Link    `json:"relatedDocuments,omitempty"`
+	Versions         []Version `json:"versions,omitempty"`
+}
+
+// Validate checks that the dataset has a title and well formed links
+func (p *Dataset) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.links("relatedDatasets", p.RelatedDatasets)
+	v.links("relatedDocuments", p.RelatedDocuments)
+	return v.result()
+}
+
+// StaticLandingPage is a landing page of the static pages, such as the about us section
+type StaticLandingPage struct {
+	PageBase
+	Markdown []string `json:"markdown,omitempty"`
+	Links    []Link   `json:"links,omitempty"`
+}
+
+// Validate checks that the static landing page has a title and well formed links
+func (p *StaticLandingPage) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.links("links", p.Links)
+	return v.result()
+}
+
+// StaticDocument is a static page of markdown with downloadable files, such as a quality and methodology
+// information report, an ad hoc release of data or a freedom of information response
+type StaticDocument struct {
+	PageBase
+	Markdown []string `json:"markdown,omitempty"`
+	Links    []Link   `json:"links,omitempty"`
+}
+
+// Validate checks that the document has a title and well formed links
+func (p *StaticDocument) Validate() ValidationErrors {
+	v := &validator{}
+	p.validate(v)
+	v.links("links", p.Links)
+	return v.result()
+}
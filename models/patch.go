@@ -0,0 +1,224 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// UpdateContent applies the change to the typed model of the page JSON, and returns the JSON with only the changes
+// made to the model applied. Fields that the model does not define, and values it would write differently, such as
+// the precision of a timestamp, are kept as they were, so that pages keep the shape they were written in.
+func UpdateContent(data []byte, change func(Content) error) ([]byte, error) {
+	_, updated, err := updateContent(data, change)
+	return updated, err
+}
+
+// updateContent applies the change as UpdateContent does, also returning the changed model
+func updateContent(data []byte, change func(Content) error) (Content, []byte, error) {
+	content, err := ParseContent(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err := json.Marshal(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := change(content); err != nil {
+		return nil, nil, err
+	}
+	after, err := json.Marshal(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patched, err := patchJSON(data, before, after)
+	if err != nil {
+		return nil, nil, err
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, patched); err != nil {
+		return nil, nil, err
+	}
+	return content, compacted.Bytes(), nil
+}
+
+// patchJSON applies the difference between the model of a value before and after it was changed to the value as
+// it was given. Objects are patched field by field, keeping the fields the model does not have. Arrays of the same
+// length are patched element by element, and otherwise the elements that the change left as they were are kept.
+func patchJSON(raw, before, after json.RawMessage) (json.RawMessage, error) {
+	if bytes.Equal(before, after) {
+		return raw, nil
+	}
+	switch {
+	case isJSON(raw, '{') && isJSON(before, '{') && isJSON(after, '{'):
+		return patchObject(raw, before, after)
+	case isJSON(raw, '[') && isJSON(before, '[') && isJSON(after, '['):
+		return patchArray(raw, before, after)
+	}
+	return after, nil
+}
+
+// patchObject applies the difference between the model of an object before and after it was changed to the object
+func patchObject(raw, before, after json.RawMessage) (json.RawMessage, error) {
+	rawFields, err := parseObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	beforeFields, err := parseObject(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := parseObject(after)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range beforeFields {
+		if afterFields.index(field.key) < 0 {
+			rawFields = rawFields.remove(field.key)
+		}
+	}
+
+	// fields added by the change are placed after the field that precedes them in the model
+	previous := ""
+	for _, field := range afterFields {
+		var beforeValue json.RawMessage
+		if i := beforeFields.index(field.key); i >= 0 {
+			beforeValue = beforeFields[i].value
+		}
+		if i := rawFields.index(field.key); i >= 0 {
+			if rawFields[i].value, err = patchJSON(rawFields[i].value, beforeValue, field.value); err != nil {
+				return nil, err
+			}
+			previous = field.key
+			continue
+		}
+		if bytes.Equal(beforeValue, field.value) {
+			continue
+		}
+		rawFields = rawFields.insertAfter(previous, field)
+		previous = field.key
+	}
+	return rawFields.marshal(), nil
+}
+
+// patchArray applies the difference between the model of an array before and after it was changed to the array
+func patchArray(raw, before, after json.RawMessage) (json.RawMessage, error) {
+	var rawElements, beforeElements, afterElements []json.RawMessage
+	for _, a := range []struct {
+		data     json.RawMessage
+		elements *[]json.RawMessage
+	}{{raw, &rawElements}, {before, &beforeElements}, {after, &afterElements}} {
+		if err := json.Unmarshal(a.data, a.elements); err != nil {
+			return nil, err
+		}
+	}
+	if len(rawElements) != len(beforeElements) {
+		return after, nil
+	}
+
+	patched := make([]json.RawMessage, len(afterElements))
+	if len(beforeElements) == len(afterElements) {
+		for i := range afterElements {
+			element, err := patchJSON(rawElements[i], beforeElements[i], afterElements[i])
+			if err != nil {
+				return nil, err
+			}
+			patched[i] = element
+		}
+		return json.Marshal(patched)
+	}
+
+	// elements were added or removed, so each element kept is found by its value rather than its position
+	used := make([]bool, len(beforeElements))
+	for i, element := range afterElements {
+		patched[i] = element
+		for j, beforeElement := range beforeElements {
+			if !used[j] && bytes.Equal(beforeElement, element) {
+				patched[i], used[j] = rawElements[j], true
+				break
+			}
+		}
+	}
+	return json.Marshal(patched)
+}
+
+// isJSON returns true if the JSON value begins with the delimiter, i.e. is an object or an array
+func isJSON(data json.RawMessage, delim byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == delim
+}
+
+// jsonField is a field of a JSON object
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonObject is the fields of a JSON object, in the order they were given
+type jsonObject []jsonField
+
+// parseObject returns the fields of the JSON object in order
+func parseObject(data json.RawMessage) (jsonObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	var fields jsonObject
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		field := jsonField{key: token.(string)}
+		if err := decoder.Decode(&field.value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// index returns the position of the field with the key, or -1 if there is none
+func (o jsonObject) index(key string) int {
+	for i, field := range o {
+		if field.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// remove returns the object without the field with the key
+func (o jsonObject) remove(key string) jsonObject {
+	if i := o.index(key); i >= 0 {
+		return append(o[:i], o[i+1:]...)
+	}
+	return o
+}
+
+// insertAfter returns the object with the field inserted after the field with the key, or first if there is none
+func (o jsonObject) insertAfter(key string, field jsonField) jsonObject {
+	i := o.index(key) + 1
+	o = append(o, jsonField{})
+	copy(o[i+1:], o[i:])
+	o[i] = field
+	return o
+}
+
+// marshal returns the JSON of the object, with its fields in order
+func (o jsonObject) marshal() json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(field.value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
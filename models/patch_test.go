@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUpdateContent(t *testing.T) {
+	Convey("Given the JSON of a timeseries with fields its model does not define", t, func() {
+		data := []byte(`{"type":"timeseries","description":{"title":"CPI","cdid":"D7G7","_abstract":"Prices"},` +
+			`"years":[{"date":"2019","value":"1.8","extra":"a"},{"date":"2020","value":"0.9","extra":"b"}],"downloads":[]}`)
+
+		Convey("When observations are added between and after those it has", func() {
+			updated, err := UpdateContent(data, func(content Content) error {
+				content.(*Timeseries).MergeValues(TimeseriesValues{Years: []TimeseriesValue{{Date: "2021", Value: "2.6"}, {Date: "2019", Value: "1.7"}}})
+				return nil
+			})
+
+			Convey("Then only the observations changed are replaced, and every other field is kept", func() {
+				So(err, ShouldBeNil)
+				So(string(updated), ShouldEqual, `{"type":"timeseries","description":{"title":"CPI","cdid":"D7G7","_abstract":"Prices"},`+
+					`"years":[{"date":"2019","value":"1.7"},{"date":"2020","value":"0.9","extra":"b"},{"date":"2021","value":"2.6"}],"downloads":[]}`)
+			})
+		})
+
+		Convey("When a field of its description is cleared", func() {
+			updated, err := UpdateContent(data, func(content Content) error {
+				content.Base().Description.CDID = ""
+				return nil
+			})
+
+			Convey("Then the field is removed, and the rest of the description is kept", func() {
+				So(err, ShouldBeNil)
+				So(string(updated), ShouldContainSubstring, `"description":{"title":"CPI","_abstract":"Prices"}`)
+			})
+		})
+
+		Convey("When nothing is changed", func() {
+			updated, err := UpdateContent(data, func(content Content) error { return nil })
+
+			Convey("Then the JSON is returned as it was", func() {
+				So(err, ShouldBeNil)
+				So(string(updated), ShouldEqual, string(data))
+			})
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+)
+
+// Redirect sends requests for a URI that has no page to the URI of the page that replaced it, e.g. after the page
+// was moved. Redirects never lead to another redirect, as chains of them are followed to the end when they are stored.
+type Redirect struct {
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// RedirectRequest is the body of a request to create or replace the redirect from a URI
+type RedirectRequest struct {
+	To string `json:"to"`
+}
+
+// ParseRedirectsCSV reads redirects from CSV with a from and a to URI on each line, e.g. for migrating redirects
+// from another system. A header line of "from,to" is skipped. A ValidationErrors is returned describing every line
+// that is not a valid redirect, or that repeats the from URI of an earlier line, and ErrTooManyRedirects is returned
+// as soon as there are more than maxRows redirects.
+func ParseRedirectsCSV(r io.Reader, lastUpdated time.Time, maxRows int) ([]*Redirect, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var redirects []*Redirect
+	v := &validator{}
+	lines := make(map[string]int)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			v.add(lineField(line), err.Error())
+			break
+		}
+		if line == 1 && len(record) == 2 && strings.EqualFold(record[0], "from") && strings.EqualFold(record[1], "to") {
+			continue
+		}
+		if len(record) != 2 || strings.TrimSpace(record[0]) == "" || strings.TrimSpace(record[1]) == "" {
+			v.add(lineField(line), "must contain a from and a to uri")
+			continue
+		}
+
+		redirect := &Redirect{From: CleanURI(record[0]), To: CleanURI(record[1]), LastUpdated: lastUpdated}
+		if previous, ok := lines[redirect.From]; ok {
+			v.add(lineField(line), fmt.Sprintf("redirect from %s is already given on line %d", redirect.From, previous))
+			continue
+		}
+		lines[redirect.From] = line
+		if len(redirects) == maxRows {
+			return nil, apierrors.ErrTooManyRedirects
+		}
+		redirects = append(redirects, redirect)
+	}
+
+	if errs := v.result(); len(errs) > 0 {
+		return nil, errs
+	}
+	return redirects, nil
+}
+
+// lineField names a line of a CSV file in validation errors
+func lineField(line int) string {
+	return fmt.Sprintf("line %d", line)
+}
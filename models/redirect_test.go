@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseRedirectsCSV(t *testing.T) {
+	lastUpdated := time.Date(2021, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given CSV of redirects with a header line", t, func() {
+		csv := "from,to\n/economy/inflation,/economy/prices\neconomy/gdp/, /economy/grossdomesticproductgdp\n"
+
+		Convey("When it is parsed", func() {
+			redirects, err := ParseRedirectsCSV(strings.NewReader(csv), lastUpdated, 10)
+
+			Convey("Then a redirect is returned for each line after the header, with clean URIs", func() {
+				So(err, ShouldBeNil)
+				So(redirects, ShouldResemble, []*Redirect{
+					{From: "/economy/inflation", To: "/economy/prices", LastUpdated: lastUpdated},
+					{From: "/economy/gdp", To: "/economy/grossdomesticproductgdp", LastUpdated: lastUpdated},
+				})
+			})
+		})
+	})
+
+	Convey("Given CSV with more redirects than the maximum", t, func() {
+		csv := "from,to\n/economy/inflation,/economy/prices\n/economy/gdp,/economy/grossdomesticproductgdp\n"
+
+		Convey("When it is parsed", func() {
+			redirects, err := ParseRedirectsCSV(strings.NewReader(csv), lastUpdated, 1)
+
+			Convey("Then it is rejected", func() {
+				So(err, ShouldEqual, apierrors.ErrTooManyRedirects)
+				So(redirects, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given CSV with missing URIs and a repeated from URI", t, func() {
+		csv := "/economy/inflation,/economy/prices\n/economy/gdp\n/economy/inflation,/economy/cpi\n,/economy\n"
+
+		Convey("When it is parsed", func() {
+			_, err := ParseRedirectsCSV(strings.NewReader(csv), lastUpdated, 10)
+
+			Convey("Then every invalid line is described", func() {
+				So(err, ShouldResemble, ValidationErrors{
+					{Field: "line 2", Description: "must contain a from and a to uri"},
+					{Field: "line 3", Description: "redirect from /economy/inflation is already given on line 1"},
+					{Field: "line 4", Description: "must contain a from and a to uri"},
+				})
+			})
+		})
+	})
+}
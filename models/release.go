@@ -0,0 +1,194 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+)
+
+// ReleaseStatus is the state of a release calendar entry, as shown on the release calendar
+type ReleaseStatus string
+
+// The possible statuses of a release
+const (
+	ReleaseStatusProvisional ReleaseStatus = "provisional"
+	ReleaseStatusConfirmed   ReleaseStatus = "confirmed"
+	ReleaseStatusPostponed   ReleaseStatus = "postponed"
+	ReleaseStatusCancelled   ReleaseStatus = "cancelled"
+	ReleaseStatusPublished   ReleaseStatus = "published"
+)
+
+// IsValid returns true if the status is one that a release can have
+func (s ReleaseStatus) IsValid() bool {
+	switch s {
+	case ReleaseStatusProvisional, ReleaseStatusConfirmed, ReleaseStatusPostponed, ReleaseStatusCancelled, ReleaseStatusPublished:
+		return true
+	}
+	return false
+}
+
+// Status returns the status of the release. A release is provisional until its date is confirmed, and postponed
+// once its date has been changed, until it is published or cancelled.
+func (p *Release) Status() ReleaseStatus {
+	switch {
+	case p.Description.Published:
+		return ReleaseStatusPublished
+	case p.Description.Cancelled:
+		return ReleaseStatusCancelled
+	case len(p.DateChanges) > 0:
+		return ReleaseStatusPostponed
+	case p.Description.Finalised:
+		return ReleaseStatusConfirmed
+	}
+	return ReleaseStatusProvisional
+}
+
+// UpdateRelease applies the change to the release stored as the page, and returns the page to store in its place.
+// ErrReleaseNotFound is returned if the page is not a release.
+func UpdateRelease(page *Page, lastUpdated time.Time, change func(*Release) error) (*Page, error) {
+	if page.Type != PageTypeRelease {
+		return nil, apierrors.ErrReleaseNotFound
+	}
+	data, err := UpdateContent(page.Data, func(content Content) error {
+		return change(content.(*Release))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewPage(page.URI, data, lastUpdated)
+}
+
+// Confirm finalises the date of a provisional release, replacing its release date if one is given
+func (p *Release) Confirm(releaseDate *time.Time) error {
+	if err := p.checkChangeable(); err != nil {
+		return err
+	}
+	if p.Description.Finalised {
+		return apierrors.ErrReleaseConfirmed
+	}
+	if releaseDate != nil {
+		date := releaseDate.UTC()
+		p.Description.ReleaseDate = &date
+	}
+	p.Description.Finalised = true
+	p.Description.ProvisionalDate = ""
+	return nil
+}
+
+// Postpone moves the release to a later date, recording the date it was moved from and the reason in its history
+// of date changes
+func (p *Release) Postpone(releaseDate time.Time, reason string) error {
+	if err := p.checkChangeable(); err != nil {
+		return err
+	}
+	if previous := p.Description.ReleaseDate; previous != nil {
+		if !releaseDate.After(*previous) {
+			return apierrors.ErrReleaseDateNotPostponed
+		}
+		p.DateChanges = append(p.DateChanges, ReleaseDateChange{PreviousDate: previous.UTC().Format(time.RFC3339), ChangeNotice: reason})
+	}
+	date := releaseDate.UTC()
+	p.Description.ReleaseDate = &date
+	return nil
+}
+
+// Cancel cancels the release, giving the reason as its cancellation notice
+func (p *Release) Cancel(reason string) error {
+	if err := p.checkChangeable(); err != nil {
+		return err
+	}
+	p.Description.Cancelled = true
+	p.Description.CancellationNotice = []string{reason}
+	return nil
+}
+
+// checkChangeable returns an error if the release has been published or cancelled, as its date can then no longer
+// be changed
+func (p *Release) checkChangeable() error {
+	switch {
+	case p.Description.Published:
+		return apierrors.ErrReleasePublished
+	case p.Description.Cancelled:
+		return apierrors.ErrReleaseCancelled
+	}
+	return nil
+}
+
+// ReleaseDateRequest is the body of a request to confirm a release, optionally at a new date
+type ReleaseDateRequest struct {
+	ReleaseDate *time.Time `json:"release_date,omitempty"`
+}
+
+// PostponeRequest is the body of a request to postpone a release to a later date
+type PostponeRequest struct {
+	ReleaseDate *time.Time `json:"release_date"`
+	Reason      string     `json:"reason"`
+}
+
+// CancelRequest is the body of a request to cancel a release
+type CancelRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReleaseSummary describes a release in the release calendar. Links are to the content published in the release.
+type ReleaseSummary struct {
+	URI                string              `json:"uri"`
+	Title              string              `json:"title"`
+	Summary            string              `json:"summary,omitempty"`
+	Status             ReleaseStatus       `json:"status"`
+	ReleaseDate        time.Time           `json:"release_date"`
+	ProvisionalDate    string              `json:"provisional_date,omitempty"`
+	NationalStatistic  bool                `json:"national_statistic"`
+	DateChanges        []ReleaseDateChange `json:"date_changes,omitempty"`
+	CancellationNotice []string            `json:"cancellation_notice,omitempty"`
+	Links              []Link              `json:"links,omitempty"`
+}
+
+// SummariseRelease returns the release calendar entry for a release page
+func SummariseRelease(page *Page) (*ReleaseSummary, error) {
+	var release Release
+	if err := json.Unmarshal(page.Data, &release); err != nil {
+		return nil, err
+	}
+	summary := &ReleaseSummary{
+		URI:                page.URI,
+		Title:              release.Description.Title,
+		Summary:            release.Description.Summary,
+		Status:             release.Status(),
+		ProvisionalDate:    release.Description.ProvisionalDate,
+		NationalStatistic:  release.Description.NationalStatistic,
+		DateChanges:        release.DateChanges,
+		CancellationNotice: release.Description.CancellationNotice,
+	}
+	if release.Description.ReleaseDate != nil {
+		summary.ReleaseDate = release.Description.ReleaseDate.UTC()
+	}
+	for _, links := range [][]Link{release.RelatedDocuments, release.RelatedDatasets, release.RelatedMethodology, release.RelatedMethodologyArticle} {
+		summary.Links = append(summary.Links, links...)
+	}
+	return summary, nil
+}
+
+// ReleaseFilter restricts the releases that are listed. Empty fields match every release, and the date range
+// includes releases at exactly the from and to times. Offset and limit select a page of the matching releases.
+type ReleaseFilter struct {
+	From   time.Time
+	To     time.Time
+	Status ReleaseStatus
+	Offset int
+	Limit  int
+}
+
+// Matches returns true if the release meets every condition of the filter
+func (f ReleaseFilter) Matches(release *ReleaseSummary) bool {
+	switch {
+	case f.Status != "" && release.Status != f.Status:
+		return false
+	case !f.From.IsZero() && release.ReleaseDate.Before(f.From):
+		return false
+	case !f.To.IsZero() && release.ReleaseDate.After(f.To):
+		return false
+	}
+	return true
+}
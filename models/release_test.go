@@ -0,0 +1,169 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReleaseStatus(t *testing.T) {
+	Convey("Given releases at each stage of the calendar", t, func() {
+		releases := map[ReleaseStatus]*Release{
+			ReleaseStatusProvisional: {},
+			ReleaseStatusConfirmed:   {PageBase: PageBase{Description: PageDescription{Finalised: true}}},
+			ReleaseStatusPostponed:   {PageBase: PageBase{Description: PageDescription{Finalised: true}}, DateChanges: []ReleaseDateChange{{ChangeNotice: "Delayed"}}},
+			ReleaseStatusCancelled:   {PageBase: PageBase{Description: PageDescription{Finalised: true, Cancelled: true}}},
+			ReleaseStatusPublished:   {PageBase: PageBase{Description: PageDescription{Finalised: true, Published: true}}, DateChanges: []ReleaseDateChange{{ChangeNotice: "Delayed"}}},
+		}
+
+		Convey("Then each has the status of its stage", func() {
+			for status, release := range releases {
+				So(release.Status(), ShouldEqual, status)
+				So(status.IsValid(), ShouldBeTrue)
+			}
+			So(ReleaseStatus("upcoming").IsValid(), ShouldBeFalse)
+		})
+	})
+}
+
+func TestChangeRelease(t *testing.T) {
+	releaseDate := time.Date(2021, 4, 21, 6, 0, 0, 0, time.UTC)
+
+	Convey("Given a provisional release", t, func() {
+		release := &Release{PageBase: PageBase{Description: PageDescription{ReleaseDate: &releaseDate, ProvisionalDate: "April 2021"}}}
+
+		Convey("When it is confirmed at a new date", func() {
+			confirmed := releaseDate.Add(24 * time.Hour)
+			err := release.Confirm(&confirmed)
+
+			Convey("Then it is confirmed at that date, without a provisional date", func() {
+				So(err, ShouldBeNil)
+				So(*release.Description.ReleaseDate, ShouldEqual, confirmed)
+				So(release.Description.ProvisionalDate, ShouldBeEmpty)
+				So(release.Status(), ShouldEqual, ReleaseStatusConfirmed)
+			})
+
+			Convey("And it is confirmed again", func() {
+				err := release.Confirm(nil)
+
+				Convey("Then it is refused", func() {
+					So(err, ShouldEqual, apierrors.ErrReleaseConfirmed)
+				})
+			})
+		})
+
+		Convey("When it is postponed twice", func() {
+			first := releaseDate.Add(7 * 24 * time.Hour)
+			second := first.Add(7 * 24 * time.Hour)
+			So(release.Postpone(first, "Data delayed"), ShouldBeNil)
+			So(release.Postpone(second, "Further quality assurance"), ShouldBeNil)
+
+			Convey("Then each previous date is kept with the reason it was changed", func() {
+				So(*release.Description.ReleaseDate, ShouldEqual, second)
+				So(release.DateChanges, ShouldResemble, []ReleaseDateChange{
+					{PreviousDate: "2021-04-21T06:00:00Z", ChangeNotice: "Data delayed"},
+					{PreviousDate: "2021-04-28T06:00:00Z", ChangeNotice: "Further quality assurance"},
+				})
+				So(release.Status(), ShouldEqual, ReleaseStatusPostponed)
+			})
+		})
+
+		Convey("When it is postponed to an earlier date", func() {
+			err := release.Postpone(releaseDate.Add(-time.Hour), "Brought forward")
+
+			Convey("Then it is refused", func() {
+				So(err, ShouldEqual, apierrors.ErrReleaseDateNotPostponed)
+				So(release.DateChanges, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When it is cancelled", func() {
+			So(release.Cancel("Merged into another release"), ShouldBeNil)
+
+			Convey("Then the reason is its cancellation notice", func() {
+				So(release.Description.CancellationNotice, ShouldResemble, []string{"Merged into another release"})
+				So(release.Status(), ShouldEqual, ReleaseStatusCancelled)
+			})
+
+			Convey("Then its date can no longer be changed", func() {
+				So(release.Postpone(releaseDate.Add(time.Hour), "Delayed"), ShouldEqual, apierrors.ErrReleaseCancelled)
+				So(release.Confirm(nil), ShouldEqual, apierrors.ErrReleaseCancelled)
+				So(release.Cancel("Again"), ShouldEqual, apierrors.ErrReleaseCancelled)
+			})
+		})
+	})
+
+	Convey("Given a published release", t, func() {
+		release := &Release{PageBase: PageBase{Description: PageDescription{ReleaseDate: &releaseDate, Published: true}}}
+
+		Convey("Then it cannot be cancelled", func() {
+			So(release.Cancel("Too late"), ShouldEqual, apierrors.ErrReleasePublished)
+		})
+	})
+}
+
+func TestUpdateRelease(t *testing.T) {
+	lastUpdated := time.Date(2021, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a stored release page", t, func() {
+		page, err := NewPage("/releases/cpi", []byte(`{"type":"release","description":{"title":"CPI","releaseDate":"2021-04-21T06:00:00Z"}}`), lastUpdated)
+		So(err, ShouldBeNil)
+
+		Convey("When it is updated", func() {
+			updated, err := UpdateRelease(page, lastUpdated.Add(time.Hour), func(r *Release) error { return r.Cancel("Merged") })
+
+			Convey("Then the page to store has the change", func() {
+				So(err, ShouldBeNil)
+				So(updated.LastUpdated, ShouldEqual, lastUpdated.Add(time.Hour))
+				summary, err := SummariseRelease(updated)
+				So(err, ShouldBeNil)
+				So(summary.Status, ShouldEqual, ReleaseStatusCancelled)
+				So(summary.CancellationNotice, ShouldResemble, []string{"Merged"})
+			})
+		})
+	})
+
+	Convey("Given a page that is not a release", t, func() {
+		page := &Page{URI: "/economy", Type: PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`)}
+
+		Convey("Then it cannot be updated as a release", func() {
+			_, err := UpdateRelease(page, lastUpdated, func(r *Release) error { return nil })
+			So(err, ShouldEqual, apierrors.ErrReleaseNotFound)
+		})
+	})
+}
+
+func TestSummariseRelease(t *testing.T) {
+	Convey("Given a published release that links to the content published in it", t, func() {
+		page := &Page{URI: "/releases/cpi", Type: PageTypeRelease, Data: json.RawMessage(`{
+			"type": "release",
+			"description": {"title": "CPI", "releaseDate": "2021-04-21T06:00:00Z", "finalised": true, "published": true, "nationalStatistic": true},
+			"relatedDocuments": [{"uri": "/economy/inflation/bulletins/cpi"}],
+			"relatedDatasets": [{"uri": "/economy/inflation/datasets/cpi"}]
+		}`)}
+
+		Convey("Then its summary has its status, date and links", func() {
+			summary, err := SummariseRelease(page)
+			So(err, ShouldBeNil)
+			So(summary, ShouldResemble, &ReleaseSummary{
+				URI:               "/releases/cpi",
+				Title:             "CPI",
+				Status:            ReleaseStatusPublished,
+				ReleaseDate:       time.Date(2021, 4, 21, 6, 0, 0, 0, time.UTC),
+				NationalStatistic: true,
+				Links:             []Link{{URI: "/economy/inflation/bulletins/cpi"}, {URI: "/economy/inflation/datasets/cpi"}},
+			})
+		})
+
+		Convey("Then it matches filters on its status and date", func() {
+			summary, _ := SummariseRelease(page)
+			So(ReleaseFilter{Status: ReleaseStatusPublished}.Matches(summary), ShouldBeTrue)
+			So(ReleaseFilter{Status: ReleaseStatusCancelled}.Matches(summary), ShouldBeFalse)
+			So(ReleaseFilter{From: summary.ReleaseDate, To: summary.ReleaseDate}.Matches(summary), ShouldBeTrue)
+			So(ReleaseFilter{From: summary.ReleaseDate.Add(time.Second)}.Matches(summary), ShouldBeFalse)
+		})
+	})
+}
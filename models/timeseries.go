@@ -0,0 +1,167 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+)
+
+// months are the abbreviations used in the dates of monthly observations, e.g. "2021 MAR"
+var months = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+// TimeseriesValues are the observations of a timeseries at each frequency. It is the body of a request to update
+// some of the observations of a timeseries.
+type TimeseriesValues struct {
+	Years    []TimeseriesValue `json:"years,omitempty"`
+	Quarters []TimeseriesValue `json:"quarters,omitempty"`
+	Months   []TimeseriesValue `json:"months,omitempty"`
+}
+
+// IsEmpty returns true if there are no observations at any frequency
+func (v TimeseriesValues) IsEmpty() bool {
+	return len(v.Years) == 0 && len(v.Quarters) == 0 && len(v.Months) == 0
+}
+
+// MergeValues adds the observations to the timeseries, replacing any it already has for the same dates. The
+// observations at each frequency are kept in date order.
+func (p *Timeseries) MergeValues(values TimeseriesValues) {
+	p.Years = mergeValues(p.Years, values.Years)
+	p.Quarters = mergeValues(p.Quarters, values.Quarters)
+	p.Months = mergeValues(p.Months, values.Months)
+}
+
+// mergeValues returns the existing observations with the updates applied, in date order
+func mergeValues(existing, updates []TimeseriesValue) []TimeseriesValue {
+	if len(updates) == 0 {
+		return existing
+	}
+
+	merged := append([]TimeseriesValue{}, existing...)
+	index := make(map[string]int, len(merged))
+	for i, value := range merged {
+		index[value.Date] = i
+	}
+	for _, value := range updates {
+		if i, ok := index[value.Date]; ok {
+			merged[i] = value
+			continue
+		}
+		index[value.Date] = len(merged)
+		merged = append(merged, value)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return periodBefore(merged[i].Date, merged[j].Date)
+	})
+	return merged
+}
+
+// periodBefore returns true if the observation date a is earlier than b. Dates that are not a year, quarter or
+// month are compared as text.
+func periodBefore(a, b string) bool {
+	yearA, partA, okA := parsePeriod(a)
+	yearB, partB, okB := parsePeriod(b)
+	if !okA || !okB {
+		return a < b
+	}
+	if yearA != yearB {
+		return yearA < yearB
+	}
+	return partA < partB
+}
+
+// parsePeriod splits the date of an observation into its year and the quarter or month within it, e.g. "2021 Q1"
+// or "2021 MAR". The part is 0 for annual observations.
+func parsePeriod(date string) (year, part int, ok bool) {
+	fields := strings.Fields(strings.ToUpper(date))
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, 0, false
+	}
+	year, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	if len(fields) == 1 {
+		return year, 0, true
+	}
+	if month, ok := months[fields[1]]; ok {
+		return year, month, true
+	}
+	if len(fields[1]) == 2 && fields[1][0] == 'Q' {
+		quarter, err := strconv.Atoi(fields[1][1:])
+		return year, quarter, err == nil && quarter >= 1 && quarter <= 4
+	}
+	return 0, 0, false
+}
+
+// UpdateTimeseries merges the observations into the timeseries stored as the page, and returns the page to store
+// in its place. ErrTimeseriesNotFound is returned if the page is not a timeseries.
+func UpdateTimeseries(page *Page, values TimeseriesValues, lastUpdated time.Time) (*Page, error) {
+	if page.Type != PageTypeTimeseries {
+		return nil, apierrors.ErrTimeseriesNotFound
+	}
+	data, err := UpdateContent(page.Data, func(content Content) error {
+		content.(*Timeseries).MergeValues(values)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewPage(page.URI, data, lastUpdated)
+}
+
+// TimeseriesDownload is the metadata and observations of a timeseries, as given in downloads of its data
+type TimeseriesDownload struct {
+	Title       string            `json:"title"`
+	CDID        string            `json:"cdid"`
+	DatasetID   string            `json:"dataset_id,omitempty"`
+	PreUnit     string            `json:"pre_unit,omitempty"`
+	Unit        string            `json:"unit,omitempty"`
+	ReleaseDate *time.Time        `json:"release_date,omitempty"`
+	NextRelease string            `json:"next_release,omitempty"`
+	Notes       []string          `json:"notes,omitempty"`
+	Years       []TimeseriesValue `json:"years"`
+	Quarters    []TimeseriesValue `json:"quarters"`
+	Months      []TimeseriesValue `json:"months"`
+}
+
+// NewTimeseriesDownload returns the download of the timeseries stored as the page
+func NewTimeseriesDownload(page *Page) (*TimeseriesDownload, error) {
+	if page.Type != PageTypeTimeseries {
+		return nil, apierrors.ErrTimeseriesNotFound
+	}
+	var timeseries Timeseries
+	if err := json.Unmarshal(page.Data, &timeseries); err != nil {
+		return nil, err
+	}
+	description := timeseries.Description
+	return &TimeseriesDownload{
+		Title:       description.Title,
+		CDID:        description.CDID,
+		DatasetID:   description.DatasetID,
+		PreUnit:     description.PreUnit,
+		Unit:        description.Unit,
+		ReleaseDate: description.ReleaseDate,
+		NextRelease: description.NextRelease,
+		Notes:       timeseries.Notes,
+		Years:       nonNilValues(timeseries.Years),
+		Quarters:    nonNilValues(timeseries.Quarters),
+		Months:      nonNilValues(timeseries.Months),
+	}, nil
+}
+
+// nonNilValues returns the observations, or an empty list if there are none, so that they are given as an empty
+// list in JSON
+func nonNilValues(values []TimeseriesValue) []TimeseriesValue {
+	if values == nil {
+		return []TimeseriesValue{}
+	}
+	return values
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMergeValues(t *testing.T) {
+	Convey("Given a timeseries with annual and monthly observations", t, func() {
+		timeseries := &Timeseries{
+			Years:  []TimeseriesValue{{Date: "2019", Value: "1.8"}, {Date: "2020", Value: "0.9"}},
+			Months: []TimeseriesValue{{Date: "2020 NOV", Value: "0.3"}, {Date: "2020 DEC", Value: "0.6"}},
+		}
+
+		Convey("When revised and new observations are merged into it", func() {
+			timeseries.MergeValues(TimeseriesValues{
+				Quarters: []TimeseriesValue{{Date: "2020 Q4", Value: "0.5"}, {Date: "2020 Q3", Value: "0.6"}},
+				Months:   []TimeseriesValue{{Date: "2021 JAN", Value: "0.7"}, {Date: "2020 DEC", Value: "0.7"}},
+			})
+
+			Convey("Then observations for the same dates are replaced, and each frequency is in date order", func() {
+				So(timeseries.Years, ShouldResemble, []TimeseriesValue{{Date: "2019", Value: "1.8"}, {Date: "2020", Value: "0.9"}})
+				So(timeseries.Quarters, ShouldResemble, []TimeseriesValue{{Date: "2020 Q3", Value: "0.6"}, {Date: "2020 Q4", Value: "0.5"}})
+				So(timeseries.Months, ShouldResemble, []TimeseriesValue{
+					{Date: "2020 NOV", Value: "0.3"},
+					{Date: "2020 DEC", Value: "0.7"},
+					{Date: "2021 JAN", Value: "0.7"},
+				})
+			})
+		})
+	})
+}
+
+func TestParsePeriod(t *testing.T) {
+	Convey("Then the dates of observations at each frequency are parsed", t, func() {
+		for date, expected := range map[string][2]int{"2021": {2021, 0}, "2021 Q2": {2021, 2}, "2021 mar": {2021, 3}} {
+			year, part, ok := parsePeriod(date)
+			So(ok, ShouldBeTrue)
+			So([2]int{year, part}, ShouldEqual, expected)
+		}
+		for _, date := range []string{"", "Q2 2021", "2021 Q5", "2021 MARCH", "2021 Q1 MAR"} {
+			_, _, ok := parsePeriod(date)
+			So(ok, ShouldBeFalse)
+		}
+	})
+}
+
+func TestUpdateTimeseries(t *testing.T) {
+	lastUpdated := time.Date(2021, 4, 21, 6, 0, 0, 0, time.UTC)
+
+	Convey("Given a stored timeseries page", t, func() {
+		page, err := NewPage("/economy/inflation/timeseries/d7g7/mm23", []byte(`{"type":"timeseries","description":{"title":"CPI annual rate","cdid":"D7G7","datasetId":"MM23","unit":"%","releaseDate":"2021-03-17T07:00:00Z"},"months":[{"date":"2021 FEB","value":"0.4"}]}`), lastUpdated)
+		So(err, ShouldBeNil)
+
+		Convey("When an observation is added to it", func() {
+			updated, err := UpdateTimeseries(page, TimeseriesValues{Months: []TimeseriesValue{{Date: "2021 MAR", Value: "0.7"}}}, lastUpdated.Add(time.Hour))
+			So(err, ShouldBeNil)
+
+			Convey("Then its download has both observations and its metadata", func() {
+				d, err := NewTimeseriesDownload(updated)
+				So(err, ShouldBeNil)
+				releaseDate := time.Date(2021, 3, 17, 7, 0, 0, 0, time.UTC)
+				So(d, ShouldResemble, &TimeseriesDownload{
+					Title:       "CPI annual rate",
+					CDID:        "D7G7",
+					DatasetID:   "MM23",
+					Unit:        "%",
+					ReleaseDate: &releaseDate,
+					Years:       []TimeseriesValue{},
+					Quarters:    []TimeseriesValue{},
+					Months:      []TimeseriesValue{{Date: "2021 FEB", Value: "0.4"}, {Date: "2021 MAR", Value: "0.7"}},
+				})
+			})
+		})
+
+		Convey("When an observation without a value is added to it", func() {
+			_, err := UpdateTimeseries(page, TimeseriesValues{Months: []TimeseriesValue{{Date: "2021 MAR"}}}, lastUpdated)
+
+			Convey("Then it fails validation", func() {
+				So(err, ShouldResemble, ValidationErrors{{Field: "months[1].value", Description: "is required"}})
+			})
+		})
+	})
+
+	Convey("Given a page that is not a timeseries", t, func() {
+		page := &Page{URI: "/economy", Type: PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`)}
+
+		Convey("Then it cannot be updated or downloaded as a timeseries", func() {
+			_, err := UpdateTimeseries(page, TimeseriesValues{}, lastUpdated)
+			So(err, ShouldEqual, apierrors.ErrTimeseriesNotFound)
+			_, err = NewTimeseriesDownload(page)
+			So(err, ShouldEqual, apierrors.ErrTimeseriesNotFound)
+		})
+	})
+}
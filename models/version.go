@@ -0,0 +1,110 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// correctionAlertType is the type of alert added to a page when it is corrected
+const correctionAlertType = "correction"
+
+// versionURIPattern matches the URI of a previous version of a page
+var versionURIPattern = regexp.MustCompile(`/previous/v([0-9]+)$`)
+
+// PageVersion is a previous version of a page, kept when the page is replaced. Versions are never modified once created.
+type PageVersion struct {
+	URI              string          `json:"uri"`
+	PageURI          string          `json:"-"`
+	Version          int             `json:"version"`
+	Type             PageType        `json:"type"`
+	Data             json.RawMessage `json:"-"`
+	LastUpdated      time.Time       `json:"last_updated"`
+	SupersededAt     time.Time       `json:"superseded_at"`
+	CorrectionNotice string          `json:"correction_notice,omitempty"`
+}
+
+// VersionURI returns the URI that a previous version of a page is available at, as used by the ONS website
+func VersionURI(uri string, version int) string {
+	return fmt.Sprintf("%s/previous/v%d", uri, version)
+}
+
+// IsVersionURI returns true if the URI is that of a previous version of a page
+func IsVersionURI(uri string) bool {
+	return versionURIPattern.MatchString(uri)
+}
+
+// ParseVersionURI returns the URI of the page and the number of the version that a previous version URI refers to
+func ParseVersionURI(uri string) (pageURI string, version int, ok bool) {
+	match := versionURIPattern.FindStringSubmatchIndex(uri)
+	if match == nil {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(uri[match[2]:match[3]])
+	if err != nil {
+		return "", 0, false
+	}
+	return uri[:match[0]], version, true
+}
+
+// NewPageVersion returns the version that a page becomes when it is replaced by the next page.
+// The correction notice is taken from any correction alerts that the next page adds.
+func NewPageVersion(previous, next *Page, version int, supersededAt time.Time) *PageVersion {
+	return &PageVersion{
+		URI:              VersionURI(previous.URI, version),
+		PageURI:          previous.URI,
+		Version:          version,
+		Type:             previous.Type,
+		Data:             append(json.RawMessage(nil), previous.Data...),
+		LastUpdated:      previous.LastUpdated,
+		SupersededAt:     supersededAt,
+		CorrectionNotice: correctionNotice(previous.Data, next.Data),
+	}
+}
+
+// ToPage returns the version as it was when it was the published page
+func (v *PageVersion) ToPage() *Page {
+	return &Page{
+		URI:         v.PageURI,
+		Type:        v.Type,
+		Data:        append(json.RawMessage(nil), v.Data...),
+		LastUpdated: v.LastUpdated,
+	}
+}
+
+// correctionNotice returns the text of the correction alerts in the next page that were not in the previous page
+func correctionNotice(previous, next json.RawMessage) string {
+	existing := make(map[string]bool)
+	for _, alert := range correctionAlerts(previous) {
+		existing[alert] = true
+	}
+
+	var notices []string
+	for _, alert := range correctionAlerts(next) {
+		if !existing[alert] {
+			notices = append(notices, alert)
+		}
+	}
+	return strings.Join(notices, "\n")
+}
+
+// correctionAlerts returns the text of every correction alert on a page
+func correctionAlerts(data json.RawMessage) []string {
+	var page struct {
+		Alerts []Alert `json:"alerts"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil
+	}
+
+	var alerts []string
+	for _, alert := range page.Alerts {
+		if alert.Type == correctionAlertType {
+			alerts = append(alerts, alert.Markdown)
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewPageVersion(t *testing.T) {
+	Convey("Given a published bulletin", t, func() {
+		published := time.Date(2021, 3, 17, 7, 0, 0, 0, time.UTC)
+		corrected := time.Date(2021, 3, 18, 9, 30, 0, 0, time.UTC)
+		previous := &Page{
+			URI:         "/economy/bulletins/cpi/february2021",
+			Type:        PageTypeBulletin,
+			Data:        json.RawMessage(`{"type":"bulletin","alerts":[{"type":"alert","markdown":"Data is provisional"}]}`),
+			LastUpdated: published,
+		}
+
+		Convey("When it is replaced by a correction", func() {
+			next := &Page{
+				URI:  previous.URI,
+				Data: json.RawMessage(`{"type":"bulletin","alerts":[{"type":"alert","markdown":"Data is provisional"},{"type":"correction","markdown":"Figure 3 has been corrected"}]}`),
+			}
+			version := NewPageVersion(previous, next, 1, corrected)
+
+			Convey("Then the previous page is kept at its previous version URI", func() {
+				So(version.URI, ShouldEqual, "/economy/bulletins/cpi/february2021/previous/v1")
+				So(version.PageURI, ShouldEqual, previous.URI)
+				So(version.Version, ShouldEqual, 1)
+				So(string(version.Data), ShouldEqual, string(previous.Data))
+				So(version.LastUpdated, ShouldEqual, published)
+				So(version.SupersededAt, ShouldEqual, corrected)
+				So(version.ToPage(), ShouldResemble, previous)
+			})
+
+			Convey("Then the correction notice is taken from the new correction alert", func() {
+				So(version.CorrectionNotice, ShouldEqual, "Figure 3 has been corrected")
+			})
+		})
+
+		Convey("When it is replaced without a correction", func() {
+			next := &Page{URI: previous.URI, Data: previous.Data}
+
+			Convey("Then the version has no correction notice", func() {
+				So(NewPageVersion(previous, next, 2, corrected).CorrectionNotice, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestIsVersionURI(t *testing.T) {
+	Convey("Previous version URIs are recognised", t, func() {
+		So(IsVersionURI("/economy/bulletins/cpi/february2021/previous/v1"), ShouldBeTrue)
+		So(IsVersionURI("/economy/bulletins/cpi/february2021/previous/v12"), ShouldBeTrue)
+		So(IsVersionURI("/economy/bulletins/cpi/february2021"), ShouldBeFalse)
+		So(IsVersionURI("/economy/previous/versions"), ShouldBeFalse)
+	})
+}
+
+func TestParseVersionURI(t *testing.T) {
+	Convey("Previous version URIs are split into the page URI and version number", t, func() {
+		uri, version, ok := ParseVersionURI("/economy/bulletins/cpi/february2021/previous/v12")
+		So(ok, ShouldBeTrue)
+		So(uri, ShouldEqual, "/economy/bulletins/cpi/february2021")
+		So(version, ShouldEqual, 12)
+
+		_, _, ok = ParseVersionURI("/economy/bulletins/cpi/february2021")
+		So(ok, ShouldBeFalse)
+	})
+}
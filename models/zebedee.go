@@ -0,0 +1,53 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// zebedeeTimeFormat is the format that Zebedee writes timestamps in, always in UTC with millisecond precision
+const zebedeeTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// zebedeeTimeFields are the fields of a page that hold timestamps. Other fields named date, such as the
+// date of a timeseries observation, are labels rather than timestamps and are left as they are.
+var zebedeeTimeFields = map[string]bool{
+	"releaseDate": true,
+	"updateDate":  true,
+	"date":        true,
+}
+
+// ZebedeeJSON converts page data into the legacy data.json format served by Zebedee, so that clients
+// written for Zebedee can read content from this API unchanged
+func ZebedeeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var page interface{}
+	if err := decoder.Decode(&page); err != nil {
+		return nil, err
+	}
+	return json.Marshal(zebedeeValue("", page))
+}
+
+// zebedeeValue converts the value of a field, and every value nested within it, to the Zebedee format
+func zebedeeValue(field string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = zebedeeValue(key, nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = zebedeeValue(field, nested)
+		}
+	case string:
+		if !zebedeeTimeFields[field] {
+			return v
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.UTC().Format(zebedeeTimeFormat)
+		}
+	}
+	return value
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestZebedeeJSON(t *testing.T) {
+	Convey("Given page data containing timestamps", t, func() {
+		data := []byte(`{"type":"timeseries","description":{"title":"CPIH","releaseDate":"2021-03-24T07:00:00Z"},"months":[{"date":"2021 FEB","value":"0.7","updateDate":"2021-03-24T08:00:00+01:00"}],"alerts":[{"date":"2021-03-25T09:30:00.5Z","markdown":"Corrected"}],"weight":1.50}`)
+
+		Convey("When it is converted to the Zebedee format", func() {
+			converted, err := ZebedeeJSON(data)
+			So(err, ShouldBeNil)
+
+			Convey("Then timestamps are written in UTC with milliseconds, and everything else is unchanged", func() {
+				So(string(converted), ShouldEqual, `{"alerts":[{"date":"2021-03-25T09:30:00.500Z","markdown":"Corrected"}],"description":{"releaseDate":"2021-03-24T07:00:00.000Z","title":"CPIH"},"months":[{"date":"2021 FEB","updateDate":"2021-03-24T07:00:00.000Z","value":"0.7"}],"type":"timeseries","weight":1.50}`)
+			})
+		})
+	})
+
+	Convey("Given data that is not JSON", t, func() {
+		Convey("Then it cannot be converted", func() {
+			_, err := ZebedeeJSON([]byte(`{`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditDocument is the representation of an audit record as stored in MongoDB
+type auditDocument struct {
+	ID           string             `bson:"_id"`
+	User         string             `bson:"user"`
+	Service      bool               `bson:"service"`
+	Action       models.AuditAction `bson:"action"`
+	URI          string             `bson:"uri"`
+	Lang         models.Language    `bson:"lang,omitempty"`
+	MovedTo      string             `bson:"moved_to,omitempty"`
+	RedirectTo   string             `bson:"redirect_to,omitempty"`
+	CollectionID string             `bson:"collection_id,omitempty"`
+	BeforeHash   string             `bson:"before_hash,omitempty"`
+	AfterHash    string             `bson:"after_hash,omitempty"`
+	RequestID    string             `bson:"request_id,omitempty"`
+	Failed       bool               `bson:"failed,omitempty"`
+	Timestamp    time.Time          `bson:"timestamp"`
+}
+
+// AddAuditRecord stores a record of a change to content
+func (m *Mongo) AddAuditRecord(ctx context.Context, record *models.AuditRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.audit.InsertOne(ctx, auditDocument(*record))
+	return err
+}
+
+// GetAuditRecords returns the page of audit records that match the filter, most recent first, along with the total
+// number of records that match it
+func (m *Mongo) GetAuditRecords(ctx context.Context, filter models.AuditFilter) ([]*models.AuditRecord, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	query := auditQuery(filter)
+	total, err := m.audit.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(filter.Offset))
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cursor, err := m.audit.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var docs []auditDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]*models.AuditRecord, len(docs))
+	for i := range docs {
+		record := models.AuditRecord(docs[i])
+		records[i] = &record
+	}
+	return records, int(total), nil
+}
+
+// auditQuery returns the MongoDB query for the audit records that match the filter
+func auditQuery(filter models.AuditFilter) bson.M {
+	query := bson.M{}
+	if filter.URI != "" {
+		query["uri"] = filter.URI
+	}
+	if filter.User != "" {
+		query["user"] = filter.User
+	}
+
+	timestamp := bson.M{}
+	if !filter.From.IsZero() {
+		timestamp["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timestamp["$lte"] = filter.To
+	}
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+	return query
+}
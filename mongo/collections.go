@@ -0,0 +1,399 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collectionDocument is the representation of a collection as stored in MongoDB
+type collectionDocument struct {
+	ID          string                   `bson:"_id"`
+	Name        string                   `bson:"name"`
+	State       models.CollectionState   `bson:"state"`
+	Items       []collectionItemDocument `bson:"items"`
+	LastUpdated time.Time                `bson:"last_updated"`
+	PublishDate *time.Time               `bson:"publish_date,omitempty"`
+	PublishedAt *time.Time               `bson:"published_at,omitempty"`
+}
+
+// collectionItemDocument is the representation of a collection item as stored in MongoDB
+type collectionItemDocument struct {
+	URI   string           `bson:"uri"`
+	Type  models.PageType  `bson:"type"`
+	State models.ItemState `bson:"state"`
+}
+
+// draftDocument is the representation of a draft page as stored in MongoDB
+type draftDocument struct {
+	ID           string          `bson:"_id"`
+	CollectionID string          `bson:"collection_id"`
+	URI          string          `bson:"uri"`
+	Type         models.PageType `bson:"type"`
+	Data         bson.D          `bson:"data"`
+	Raw          string          `bson:"raw,omitempty"`
+	LastUpdated  time.Time       `bson:"last_updated"`
+}
+
+// CreateCollection stores a new collection
+func (m *Mongo) CreateCollection(ctx context.Context, collection *models.Collection) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.collections.InsertOne(ctx, newCollectionDocument(collection))
+	return err
+}
+
+// GetCollection returns the collection with the provided ID
+func (m *Mongo) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	var doc collectionDocument
+	if err := m.collections.FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	return doc.toCollection(), nil
+}
+
+// GetCollections returns every collection, ordered by ID
+func (m *Mongo) GetCollections(ctx context.Context) ([]*models.Collection, error) {
+	return m.findCollections(ctx, bson.M{})
+}
+
+// GetScheduledCollections returns every collection that is waiting to be published at a publish date, ordered by ID
+func (m *Mongo) GetScheduledCollections(ctx context.Context) ([]*models.Collection, error) {
+	return m.findCollections(ctx, bson.M{"state": models.CollectionStateInProgress, "publish_date": bson.M{"$exists": true}})
+}
+
+// findCollections returns every collection matching the selector, ordered by ID
+func (m *Mongo) findCollections(ctx context.Context, selector bson.M) ([]*models.Collection, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	cursor, err := m.collections.Find(ctx, selector, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []collectionDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	collections := make([]*models.Collection, len(docs))
+	for i := range docs {
+		collections[i] = docs[i].toCollection()
+	}
+	return collections, nil
+}
+
+// DeleteCollection removes a collection and all of its draft pages
+func (m *Mongo) DeleteCollection(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		res, err := m.collections.DeleteOne(sc, bson.M{"_id": id})
+		if err != nil {
+			return nil, err
+		}
+		if res.DeletedCount == 0 {
+			return nil, apierrors.ErrCollectionNotFound
+		}
+		_, err = m.drafts.DeleteMany(sc, bson.M{"collection_id": id})
+		return nil, err
+	})
+	return err
+}
+
+// GetDraftPage returns the draft of the page at the provided URI from a collection
+func (m *Mongo) GetDraftPage(ctx context.Context, collectionID, uri string) (*models.Page, error) {
+	if _, err := m.GetCollection(ctx, collectionID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	var doc draftDocument
+	if err := m.drafts.FindOne(ctx, bson.M{"_id": draftID(collectionID, uri)}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrCollectionItemNotFound
+		}
+		return nil, err
+	}
+	return doc.toPage()
+}
+
+// UpsertDraftPage adds or replaces a draft page in a collection, marking it as in progress
+func (m *Mongo) UpsertDraftPage(ctx context.Context, collectionID string, page *models.Page) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newDraftDocument(collectionID, page)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := m.editableCollection(sc, collectionID); err != nil {
+			return nil, err
+		}
+		return nil, m.storeDraftPage(sc, doc)
+	})
+	return err
+}
+
+// ReplaceDraftPage replaces a draft page in a collection only if the stored draft still has the ETag, so that
+// changes made since it was read are not overwritten. The draft is marked as in progress again.
+func (m *Mongo) ReplaceDraftPage(ctx context.Context, collectionID string, page *models.Page, etag string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newDraftDocument(collectionID, page)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := m.editableCollection(sc, collectionID); err != nil {
+			return nil, err
+		}
+
+		var existing draftDocument
+		if err := m.drafts.FindOne(sc, bson.M{"_id": doc.ID}).Decode(&existing); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, apierrors.ErrCollectionItemNotFound
+			}
+			return nil, err
+		}
+		if err := checkETag(existing.toPage, etag); err != nil {
+			return nil, err
+		}
+		return nil, m.storeDraftPage(sc, doc)
+	})
+	return err
+}
+
+// storeDraftPage adds or replaces the draft in its collection, marking it as in progress. It must be called within
+// a transaction so that the draft and the item for it are stored together.
+func (m *Mongo) storeDraftPage(ctx context.Context, doc *draftDocument) error {
+	// remove any existing item for the page before adding it again as in progress
+	if _, err := m.collections.UpdateOne(ctx, bson.M{"_id": doc.CollectionID}, bson.M{"$pull": bson.M{"items": bson.M{"uri": doc.URI}}}); err != nil {
+		return err
+	}
+	item := collectionItemDocument{URI: doc.URI, Type: doc.Type, State: models.ItemStateInProgress}
+	update := bson.M{
+		"$push": bson.M{"items": item},
+		"$set":  bson.M{"last_updated": doc.LastUpdated},
+	}
+	if _, err := m.collections.UpdateOne(ctx, bson.M{"_id": doc.CollectionID}, update); err != nil {
+		return err
+	}
+
+	_, err := m.drafts.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+// newDraftDocument converts a draft page into its MongoDB representation in the collection
+func newDraftDocument(collectionID string, page *models.Page) (*draftDocument, error) {
+	pageDoc, err := newPageDocument(page)
+	if err != nil {
+		return nil, err
+	}
+	return &draftDocument{
+		ID:           draftID(collectionID, page.URI),
+		CollectionID: collectionID,
+		URI:          page.URI,
+		Type:         page.Type,
+		Data:         pageDoc.Data,
+		Raw:          pageDoc.Raw,
+		LastUpdated:  page.LastUpdated,
+	}, nil
+}
+
+// DeleteDraftPage removes a draft page from a collection
+func (m *Mongo) DeleteDraftPage(ctx context.Context, collectionID, uri string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		collection, err := m.editableCollection(sc, collectionID)
+		if err != nil {
+			return nil, err
+		}
+		if collection.Item(uri) == nil {
+			return nil, apierrors.ErrCollectionItemNotFound
+		}
+
+		update := bson.M{
+			"$pull": bson.M{"items": bson.M{"uri": uri}},
+			"$set":  bson.M{"last_updated": time.Now().UTC()},
+		}
+		if _, err := m.collections.UpdateOne(sc, bson.M{"_id": collectionID}, update); err != nil {
+			return nil, err
+		}
+
+		_, err = m.drafts.DeleteOne(sc, bson.M{"_id": draftID(collectionID, uri)})
+		return nil, err
+	})
+	return err
+}
+
+// UpdateItemState sets the review state of a draft page in a collection
+func (m *Mongo) UpdateItemState(ctx context.Context, collectionID, uri string, state models.ItemState) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	if _, err := m.editableCollection(ctx, collectionID); err != nil {
+		return err
+	}
+
+	selector := bson.M{"_id": collectionID, "state": models.CollectionStateInProgress, "items.uri": uri}
+	update := bson.M{"$set": bson.M{"items.$.state": state, "last_updated": time.Now().UTC()}}
+	res, err := m.collections.UpdateOne(ctx, selector, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return apierrors.ErrCollectionItemNotFound
+	}
+	return nil
+}
+
+// PublishCollection makes every draft page in a collection live, marking the collection as published.
+// The pages are published in a single transaction, so either all of them are published or none are.
+func (m *Mongo) PublishCollection(ctx context.Context, collectionID string, publishedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		collection, err := m.editableCollection(sc, collectionID)
+		if err != nil {
+			return nil, err
+		}
+		if !collection.IsPublishable() {
+			return nil, apierrors.ErrCollectionNotPublishable
+		}
+
+		cursor, err := m.drafts.Find(sc, bson.M{"collection_id": collectionID})
+		if err != nil {
+			return nil, err
+		}
+		var drafts []draftDocument
+		if err := cursor.All(sc, &drafts); err != nil {
+			return nil, err
+		}
+
+		for _, draft := range drafts {
+			page, err := draft.toPage()
+			if err != nil {
+				return nil, err
+			}
+			page.LastUpdated = publishedAt
+			if _, err := m.replacePage(sc, page); err != nil {
+				return nil, err
+			}
+		}
+
+		update := bson.M{"$set": bson.M{
+			"state":        models.CollectionStatePublished,
+			"published_at": publishedAt,
+			"last_updated": publishedAt,
+		}}
+		if _, err := m.collections.UpdateOne(sc, bson.M{"_id": collectionID}, update); err != nil {
+			return nil, err
+		}
+
+		_, err = m.drafts.DeleteMany(sc, bson.M{"collection_id": collectionID})
+		return nil, err
+	})
+	return err
+}
+
+// editableCollection returns the collection, failing if it does not exist or has already been published
+func (m *Mongo) editableCollection(ctx context.Context, id string) (*models.Collection, error) {
+	var doc collectionDocument
+	if err := m.collections.FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	if doc.State == models.CollectionStatePublished {
+		return nil, apierrors.ErrCollectionPublished
+	}
+	return doc.toCollection(), nil
+}
+
+// withTransaction runs the provided function in a MongoDB transaction, committing it if no error is returned
+func (m *Mongo) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) (interface{}, error)) (interface{}, error) {
+	session, err := m.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	return session.WithTransaction(ctx, fn)
+}
+
+// draftID returns the document ID of the draft of a page within a collection
+func draftID(collectionID, uri string) string {
+	return collectionID + uri
+}
+
+// newCollectionDocument converts a collection into its MongoDB representation
+func newCollectionDocument(collection *models.Collection) *collectionDocument {
+	items := make([]collectionItemDocument, len(collection.Items))
+	for i, item := range collection.Items {
+		items[i] = collectionItemDocument{URI: item.URI, Type: item.Type, State: item.State}
+	}
+	return &collectionDocument{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		State:       collection.State,
+		Items:       items,
+		LastUpdated: collection.LastUpdated,
+		PublishDate: collection.PublishDate,
+		PublishedAt: collection.PublishedAt,
+	}
+}
+
+// toCollection converts a stored document back into a collection
+func (doc *collectionDocument) toCollection() *models.Collection {
+	items := make([]models.CollectionItem, len(doc.Items))
+	for i, item := range doc.Items {
+		items[i] = models.CollectionItem{URI: item.URI, Type: item.Type, State: item.State}
+	}
+	collection := &models.Collection{
+		ID:          doc.ID,
+		Name:        doc.Name,
+		State:       doc.State,
+		Items:       items,
+		LastUpdated: doc.LastUpdated.UTC(),
+	}
+	if doc.PublishDate != nil {
+		publishDate := doc.PublishDate.UTC()
+		collection.PublishDate = &publishDate
+	}
+	if doc.PublishedAt != nil {
+		publishedAt := doc.PublishedAt.UTC()
+		collection.PublishedAt = &publishedAt
+	}
+	return collection
+}
+
+// toPage converts a stored draft back into a page
+func (doc *draftDocument) toPage() (*models.Page, error) {
+	page := &pageDocument{URI: doc.URI, Type: doc.Type, Data: doc.Data, Raw: doc.Raw, LastUpdated: doc.LastUpdated}
+	return page.toPage()
+}
@@ -0,0 +1,323 @@
+package mongo
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// hierarchyDocument indexes where a page is in the taxonomy, summarising it so that breadcrumbs and children can
+// be listed without reading the pages themselves
+type hierarchyDocument struct {
+	URI    string          `bson:"_id"`
+	Parent string          `bson:"parent"`
+	Type   models.PageType `bson:"type"`
+	Title  string          `bson:"title"`
+}
+
+// GetBreadcrumb returns a summary of every page above the page at the provided URI in the taxonomy, starting
+// from the root. URIs above the page that have no page of their own are left out.
+func (m *Mongo) GetBreadcrumb(ctx context.Context, uri string) ([]*models.PageSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	if err := m.hierarchy.FindOne(ctx, bson.M{"_id": uri}).Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrPageNotFound
+		}
+		return nil, err
+	}
+
+	// a URI sorts before every URI below it, so sorting by URI orders the breadcrumb from the root
+	return m.findHierarchy(ctx, bson.M{"_id": bson.M{"$in": models.AncestorURIs(uri)}})
+}
+
+// GetChildren returns a summary of every page directly below the page at the provided URI in the taxonomy, by URI
+func (m *Mongo) GetChildren(ctx context.Context, uri string) ([]*models.PageSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	if err := m.hierarchy.FindOne(ctx, bson.M{"_id": uri}).Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrPageNotFound
+		}
+		return nil, err
+	}
+	return m.findHierarchy(ctx, bson.M{"parent": uri})
+}
+
+// GetSubtree returns the page at the provided URI and every page below it in the taxonomy, by URI
+func (m *Mongo) GetSubtree(ctx context.Context, uri string) ([]*models.Page, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	return m.findSubtree(ctx, uri)
+}
+
+// MovePages moves the page at from and every page below it in the taxonomy to the same place below to, along with
+// their translations and previous versions, and redirects each old URI to the new one. The pages are moved in a
+// single transaction, so either every page is moved or none are. The transaction must complete within the query
+// timeout, so callers limit the number of pages moved at once. Pages cannot be moved while they have drafts in a
+// collection, or onto pages that already exist.
+func (m *Mongo) MovePages(ctx context.Context, from, to string, movedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		pages, err := m.findSubtree(sc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		drafts, err := m.drafts.CountDocuments(sc, subtreeFilter("uri", from))
+		if err != nil {
+			return nil, err
+		}
+		if drafts > 0 {
+			return nil, apierrors.ErrPageHasDrafts
+		}
+
+		dests := make([]string, len(pages))
+		for i, page := range pages {
+			dests[i] = models.MovedURI(page.URI, from, to)
+		}
+		if err := m.checkDestinations(sc, dests); err != nil {
+			return nil, err
+		}
+
+		for i, page := range pages {
+			if err := m.movePage(sc, page, dests[i], movedAt); err != nil {
+				return nil, err
+			}
+			if err := m.addRedirect(sc, &models.Redirect{From: page.URI, To: dests[i], LastUpdated: movedAt}); err != nil {
+				return nil, err
+			}
+		}
+		if err := m.moveTranslations(sc, from, to, movedAt); err != nil {
+			return nil, err
+		}
+		return nil, m.moveVersions(sc, from, to)
+	})
+	return err
+}
+
+// checkDestinations fails if a page, or a previous version of one, already exists at any of the URIs
+func (m *Mongo) checkDestinations(ctx context.Context, uris []string) error {
+	pages, err := m.pages.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": uris}})
+	if err != nil {
+		return err
+	}
+	versions, err := m.versions.CountDocuments(ctx, bson.M{"page_uri": bson.M{"$in": uris}})
+	if err != nil {
+		return err
+	}
+	if pages > 0 || versions > 0 {
+		return apierrors.ErrPageAlreadyExists
+	}
+	return nil
+}
+
+// movePage stores the page at its new URI and removes it from its old one, updating the hierarchy to match
+func (m *Mongo) movePage(ctx context.Context, page *models.Page, uri string, movedAt time.Time) error {
+	moved, err := page.Move(uri, movedAt)
+	if err != nil {
+		return err
+	}
+	doc, err := newPageDocument(moved)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.pages.DeleteOne(ctx, bson.M{"_id": page.URI}); err != nil {
+		return err
+	}
+	if _, err := m.hierarchy.DeleteOne(ctx, bson.M{"_id": page.URI}); err != nil {
+		return err
+	}
+	if _, err := m.pages.InsertOne(ctx, doc); err != nil {
+		return err
+	}
+	return m.pageStored(ctx, moved)
+}
+
+// moveTranslations moves the translations of the pages in the subtree at from to the subtree at to
+func (m *Mongo) moveTranslations(ctx context.Context, from, to string, movedAt time.Time) error {
+	filter := subtreeFilter("uri", from)
+	cursor, err := m.translations.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	var docs []translationDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return err
+	}
+	if _, err := m.translations.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		translation, err := doc.toPage()
+		if err != nil {
+			return err
+		}
+		moved, err := translation.Move(models.MovedURI(doc.URI, from, to), movedAt)
+		if err != nil {
+			return err
+		}
+		movedDoc, err := newTranslationDocument(doc.Lang, moved)
+		if err != nil {
+			return err
+		}
+		if _, err := m.translations.InsertOne(ctx, movedDoc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveVersions moves the previous versions of the pages in the subtree at from to the subtree at to
+func (m *Mongo) moveVersions(ctx context.Context, from, to string) error {
+	filter := subtreeFilter("page_uri", from)
+	cursor, err := m.versions.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	var docs []versionDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return err
+	}
+	if _, err := m.versions.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		version, err := doc.toVersion()
+		if err != nil {
+			return err
+		}
+		movedDoc, err := newVersionDocument(version.Move(models.MovedURI(doc.PageURI, from, to)))
+		if err != nil {
+			return err
+		}
+		if _, err := m.versions.InsertOne(ctx, movedDoc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findSubtree returns the page at the URI and every page below it, failing if there is no page at the URI
+func (m *Mongo) findSubtree(ctx context.Context, uri string) ([]*models.Page, error) {
+	cursor, err := m.pages.Find(ctx, subtreeFilter("_id", uri), options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []pageDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	// the page itself sorts before every page below it
+	if len(docs) == 0 || docs[0].URI != uri {
+		return nil, apierrors.ErrPageNotFound
+	}
+
+	pages := make([]*models.Page, len(docs))
+	for i := range docs {
+		if pages[i], err = docs[i].toPage(); err != nil {
+			return nil, err
+		}
+	}
+	return pages, nil
+}
+
+// findHierarchy returns a summary of every page in the hierarchy matching the selector, by URI
+func (m *Mongo) findHierarchy(ctx context.Context, selector bson.M) ([]*models.PageSummary, error) {
+	cursor, err := m.hierarchy.Find(ctx, selector, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []hierarchyDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*models.PageSummary, len(docs))
+	for i, doc := range docs {
+		summaries[i] = &models.PageSummary{URI: doc.URI, Type: doc.Type, Title: doc.Title}
+	}
+	return summaries, nil
+}
+
+// indexPage adds the page to the hierarchy, or updates its summary if it is already there. It is called whenever
+// a page is stored, within the same transaction.
+func (m *Mongo) indexPage(ctx context.Context, page *models.Page) error {
+	doc, err := newHierarchyDocument(page)
+	if err != nil {
+		return err
+	}
+	_, err = m.hierarchy.ReplaceOne(ctx, bson.M{"_id": doc.URI}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+// buildHierarchy indexes every stored page, so that pages stored before the hierarchy existed are included in it.
+// Each page is indexed within the query timeout, but reading every page is not bound by it.
+func (m *Mongo) buildHierarchy(ctx context.Context) error {
+	cursor, err := m.pages.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		var doc pageDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		page, err := doc.toPage()
+		if err != nil {
+			return err
+		}
+		indexCtx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+		err = m.indexPage(indexCtx, page)
+		cancel()
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	if count > 0 {
+		log.Event(ctx, "built hierarchy from stored pages", log.INFO, log.Data{"pages": count})
+	}
+	return cursor.Err()
+}
+
+// subtreeFilter returns the selector for documents whose field is the URI or a URI below it
+func subtreeFilter(field, uri string) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{field: uri},
+		bson.M{field: bson.M{"$regex": "^" + regexp.QuoteMeta(uri+"/")}},
+	}}
+}
+
+// newHierarchyDocument indexes the page under its parent in the taxonomy
+func newHierarchyDocument(page *models.Page) (*hierarchyDocument, error) {
+	summary, err := models.Summarise(page)
+	if err != nil {
+		return nil, err
+	}
+	return &hierarchyDocument{
+		URI:    page.URI,
+		Parent: models.ParentURI(page.URI),
+		Type:   summary.Type,
+		Title:  summary.Title,
+	}, nil
+}
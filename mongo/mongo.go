@@ -0,0 +1,413 @@
+package mongo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Mongo is a content store backed by MongoDB
+type Mongo struct {
+	URI                    string
+	Database               string
+	PagesCollection        string
+	CollectionsCollection  string
+	DraftsCollection       string
+	VersionsCollection     string
+	AuditCollection        string
+	TranslationsCollection string
+	HierarchyCollection    string
+	RedirectsCollection    string
+	ConnectTimeout         time.Duration
+	QueryTimeout           time.Duration
+	client                 *mongo.Client
+	pages                  *mongo.Collection
+	collections            *mongo.Collection
+	drafts                 *mongo.Collection
+	versions               *mongo.Collection
+	audit                  *mongo.Collection
+	translations           *mongo.Collection
+	hierarchy              *mongo.Collection
+	redirects              *mongo.Collection
+}
+
+// pageDocument is the representation of a page as stored in MongoDB. The page JSON is kept as given in Raw, as
+// converting it to BSON for Data, which is what is queried, rewrites numbers and escapes, and the page must be
+// returned with the bytes its ETag and audit hashes were made from.
+type pageDocument struct {
+	URI         string          `bson:"_id"`
+	Type        models.PageType `bson:"type"`
+	Data        bson.D          `bson:"data"`
+	Raw         string          `bson:"raw,omitempty"`
+	LastUpdated time.Time       `bson:"last_updated"`
+	Release     *releaseFields  `bson:"release,omitempty"`
+}
+
+// New creates a MongoDB content store from the provided configuration and connects to it
+func New(ctx context.Context, cfg config.MongoConfig) (*Mongo, error) {
+	m := &Mongo{
+		URI:                    cfg.URI,
+		Database:               cfg.Database,
+		PagesCollection:        cfg.PagesCollection,
+		CollectionsCollection:  cfg.CollectionsCollection,
+		DraftsCollection:       cfg.DraftsCollection,
+		VersionsCollection:     cfg.VersionsCollection,
+		AuditCollection:        cfg.AuditCollection,
+		TranslationsCollection: cfg.TranslationsCollection,
+		HierarchyCollection:    cfg.HierarchyCollection,
+		RedirectsCollection:    cfg.RedirectsCollection,
+		ConnectTimeout:         cfg.ConnectTimeout,
+		QueryTimeout:           cfg.QueryTimeout,
+	}
+	if err := m.Init(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Init connects to MongoDB and prepares the collections used by the store
+func (m *Mongo) Init(ctx context.Context) error {
+	if m.client != nil {
+		return errors.New("mongo client already initialised")
+	}
+
+	clientOpts := options.Client().
+		ApplyURI(m.URI).
+		SetConnectTimeout(m.ConnectTimeout).
+		SetServerSelectionTimeout(m.ConnectTimeout).
+		SetMonitor(newCommandMonitor())
+
+	connectCtx, cancel := context.WithTimeout(ctx, m.ConnectTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(connectCtx, clientOpts)
+	if err != nil {
+		return err
+	}
+
+	db := client.Database(m.Database)
+	m.client = client
+	m.pages = db.Collection(m.PagesCollection)
+	m.collections = db.Collection(m.CollectionsCollection)
+	m.drafts = db.Collection(m.DraftsCollection)
+	m.versions = db.Collection(m.VersionsCollection)
+	m.audit = db.Collection(m.AuditCollection)
+	m.translations = db.Collection(m.TranslationsCollection)
+	m.hierarchy = db.Collection(m.HierarchyCollection)
+	m.redirects = db.Collection(m.RedirectsCollection)
+
+	// drafts are looked up by collection when a collection is published or deleted
+	indexCtx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+	if _, err := m.drafts.Indexes().CreateOne(indexCtx, mongo.IndexModel{Keys: bson.D{{Key: "collection_id", Value: 1}}}); err != nil {
+		return err
+	}
+
+	// versions are listed by page, and the unique index prevents two versions being created with the same number
+	versionsIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "page_uri", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := m.versions.Indexes().CreateOne(indexCtx, versionsIndex); err != nil {
+		return err
+	}
+
+	// audit records are queried by page or user over a range of time
+	auditIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "uri", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "user", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "timestamp", Value: -1}}},
+	}
+	if _, err := m.audit.Indexes().CreateMany(indexCtx, auditIndexes); err != nil {
+		return err
+	}
+
+	// translations are joined to their pages when listing the pages that have not been translated
+	if _, err := m.translations.Indexes().CreateOne(indexCtx, mongo.IndexModel{Keys: bson.D{{Key: "uri", Value: 1}}}); err != nil {
+		return err
+	}
+
+	// the children of a page are found by their parent
+	if _, err := m.hierarchy.Indexes().CreateOne(indexCtx, mongo.IndexModel{Keys: bson.D{{Key: "parent", Value: 1}}}); err != nil {
+		return err
+	}
+
+	// redirects are found by where they lead to, so that chains of redirects can be followed to the end
+	if _, err := m.redirects.Indexes().CreateOne(indexCtx, mongo.IndexModel{Keys: bson.D{{Key: "to", Value: 1}}}); err != nil {
+		return err
+	}
+
+	// timeseries are found by their CDID when their data is downloaded
+	if _, err := m.pages.Indexes().CreateOne(indexCtx, mongo.IndexModel{Keys: bson.D{{Key: "data.description.cdid", Value: 1}}}); err != nil {
+		return err
+	}
+
+	// releases are listed by status over a range of release dates
+	releasesIndex := mongo.IndexModel{Keys: bson.D{{Key: "release.date", Value: 1}, {Key: "release.status", Value: 1}}}
+	if _, err := m.pages.Indexes().CreateOne(indexCtx, releasesIndex); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Migrate adds the fields and index entries that pages stored by earlier versions of the service are missing. It
+// reads every page, so it is run as a one-off command rather than when the service starts, and is not bound by the
+// query timeout. It can be run again safely if it is interrupted.
+func (m *Mongo) Migrate(ctx context.Context) error {
+	if err := m.indexReleases(ctx); err != nil {
+		return err
+	}
+	return m.buildHierarchy(ctx)
+}
+
+// Checker updates the health check state with the current status of the MongoDB connection
+func (m *Mongo) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	if err := m.client.Ping(ctx, readpref.Primary()); err != nil {
+		log.Event(ctx, "mongodb health check failed", log.ERROR, log.Error(err))
+		return state.Update(healthcheck.StatusCritical, "mongodb is unavailable", 0)
+	}
+	return state.Update(healthcheck.StatusOK, "mongodb is OK", 0)
+}
+
+// Close disconnects from MongoDB
+func (m *Mongo) Close(ctx context.Context) error {
+	return m.client.Disconnect(ctx)
+}
+
+// GetPage returns the page stored against the provided URI
+func (m *Mongo) GetPage(ctx context.Context, uri string) (*models.Page, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	var doc pageDocument
+	if err := m.pages.FindOne(ctx, bson.M{"_id": uri}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrPageNotFound
+		}
+		return nil, err
+	}
+	return doc.toPage()
+}
+
+// CreatePage stores a new page, failing if a page already exists at its URI
+func (m *Mongo) CreatePage(ctx context.Context, page *models.Page) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newPageDocument(page)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := m.pages.InsertOne(sc, doc); err != nil {
+			if isDuplicateKeyError(err) {
+				return nil, apierrors.ErrPageAlreadyExists
+			}
+			return nil, err
+		}
+		return nil, m.pageStored(sc, page)
+	})
+	return err
+}
+
+// UpsertPage creates or replaces the page at its URI, returning true if a new page was created.
+// A replaced page is kept as a previous version.
+func (m *Mongo) UpsertPage(ctx context.Context, page *models.Page) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	created, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return m.replacePage(sc, page)
+	})
+	if err != nil {
+		return false, err
+	}
+	return created.(bool), nil
+}
+
+// ReplacePage replaces the page at its URI only if the stored page still has the ETag, so that changes made since
+// it was read are not overwritten. The page is read and replaced in one transaction, so a concurrent change to it
+// causes the transaction to be retried and the ETag to be compared again. The replaced page is kept as a previous
+// version.
+func (m *Mongo) ReplacePage(ctx context.Context, page *models.Page, etag string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var existing pageDocument
+		if err := m.pages.FindOne(sc, bson.M{"_id": page.URI}).Decode(&existing); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, apierrors.ErrPageNotFound
+			}
+			return nil, err
+		}
+		if err := checkETag(existing.toPage, etag); err != nil {
+			return nil, err
+		}
+		return m.replacePage(sc, page)
+	})
+	return err
+}
+
+// checkETag returns ErrEditConflict if the stored page does not have the ETag it was expected to have
+func checkETag(toPage func() (*models.Page, error), etag string) error {
+	stored, err := toPage()
+	if err != nil {
+		return err
+	}
+	if stored.ETag() != etag {
+		return apierrors.ErrEditConflict
+	}
+	return nil
+}
+
+// DeletePage removes the page stored against the provided URI, along with its translations
+func (m *Mongo) DeletePage(ctx context.Context, uri string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		res, err := m.pages.DeleteOne(sc, bson.M{"_id": uri})
+		if err != nil {
+			return nil, err
+		}
+		if res.DeletedCount == 0 {
+			return nil, apierrors.ErrPageNotFound
+		}
+		if _, err := m.hierarchy.DeleteOne(sc, bson.M{"_id": uri}); err != nil {
+			return nil, err
+		}
+		_, err = m.translations.DeleteMany(sc, bson.M{"uri": uri})
+		return nil, err
+	})
+	return err
+}
+
+// replacePage stores the page, keeping any page it replaces as a previous version. It returns true if
+// no page was replaced. It must be called within a transaction so that the version and page are stored together.
+func (m *Mongo) replacePage(ctx context.Context, page *models.Page) (bool, error) {
+	doc, err := newPageDocument(page)
+	if err != nil {
+		return false, err
+	}
+
+	var existing pageDocument
+	err = m.pages.FindOne(ctx, bson.M{"_id": page.URI}).Decode(&existing)
+	created := err == mongo.ErrNoDocuments
+	if err != nil && !created {
+		return false, err
+	}
+
+	if !created {
+		if err := m.archivePage(ctx, &existing, page); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := m.pages.ReplaceOne(ctx, bson.M{"_id": page.URI}, doc, options.Replace().SetUpsert(true)); err != nil {
+		return false, err
+	}
+	return created, m.pageStored(ctx, page)
+}
+
+// pageStored adds a page that has been stored to the hierarchy, and removes any redirect from its URI as the page
+// now answers requests for it. It must be called within the transaction that stored the page.
+func (m *Mongo) pageStored(ctx context.Context, page *models.Page) error {
+	if err := m.indexPage(ctx, page); err != nil {
+		return err
+	}
+	_, err := m.redirects.DeleteOne(ctx, bson.M{"_id": page.URI})
+	return err
+}
+
+// archivePage stores the existing page as the next previous version of its URI, as it is replaced by the next page
+func (m *Mongo) archivePage(ctx context.Context, existing *pageDocument, next *models.Page) error {
+	previous, err := existing.toPage()
+	if err != nil {
+		return err
+	}
+
+	count, err := m.versions.CountDocuments(ctx, bson.M{"page_uri": previous.URI})
+	if err != nil {
+		return err
+	}
+
+	doc, err := newVersionDocument(models.NewPageVersion(previous, next, int(count)+1, next.LastUpdated))
+	if err != nil {
+		return err
+	}
+	_, err = m.versions.InsertOne(ctx, doc)
+	return err
+}
+
+// newPageDocument converts a page into its MongoDB representation, storing the page
+// JSON as a BSON document so that it can be queried, and as given so that it is returned unchanged
+func newPageDocument(page *models.Page) (*pageDocument, error) {
+	var data bson.D
+	if err := bson.UnmarshalExtJSON(page.Data, false, &data); err != nil {
+		return nil, err
+	}
+	release, err := newReleaseFields(page)
+	if err != nil {
+		return nil, err
+	}
+	return &pageDocument{
+		URI:         page.URI,
+		Type:        page.Type,
+		Data:        data,
+		Raw:         string(page.Data),
+		LastUpdated: page.LastUpdated,
+		Release:     release,
+	}, nil
+}
+
+// toPage converts a stored document back into a page
+func (doc *pageDocument) toPage() (*models.Page, error) {
+	data, err := storedJSON(doc.Raw, doc.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Page{
+		URI:         doc.URI,
+		Type:        doc.Type,
+		Data:        data,
+		LastUpdated: doc.LastUpdated.UTC(),
+	}, nil
+}
+
+// storedJSON returns the page JSON of a document as it was given. Documents stored before the JSON was kept as
+// given have only its BSON, which is converted back.
+func storedJSON(raw string, data bson.D) (json.RawMessage, error) {
+	if raw != "" {
+		return json.RawMessage(raw), nil
+	}
+	return bson.MarshalExtJSON(data, false, false)
+}
+
+// isDuplicateKeyError returns true if the error was caused by a unique index violation
+func isDuplicateKeyError(err error) bool {
+	var writeErr mongo.WriteException
+	if errors.As(err, &writeErr) {
+		for _, e := range writeErr.WriteErrors {
+			if e.Code == 11000 {
+				return true
+			}
+		}
+	}
+	return false
+}
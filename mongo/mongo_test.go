@@ -0,0 +1,388 @@
+package mongo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// awkwardJSON is page data that converting to BSON and back would rewrite, with decimals that end in zero,
+// exponents, escapes and keys out of order
+const awkwardJSON = `{"type":"static_page","weight":1.50,"size":1e3,"description":{"title":"Caf\u00e9 \u003cprices\u003e","summary":"\/economy"},"b":0.10,"a":-2E-2}`
+
+// roundTrip encodes the document as BSON and decodes it into out, as storing and reading it from MongoDB does
+func roundTrip(doc interface{}, out interface{}) {
+	b, err := bson.Marshal(doc)
+	So(err, ShouldBeNil)
+	So(bson.Unmarshal(b, out), ShouldBeNil)
+}
+
+func TestPageDocument(t *testing.T) {
+	Convey("Given a page containing nested JSON", t, func() {
+		page := &models.Page{
+			URI:         "/economy/inflationandpriceindices/bulletins/consumerpriceinflation/latest",
+			Type:        models.PageTypeBulletin,
+			Data:        json.RawMessage(`{"type":"bulletin","description":{"title":"Consumer price inflation","keywords":["cpi","cpih"]},"sections":[{"title":"Main points","markdown":"* CPIH rose by 0.7%"}],"weight":3}`),
+			LastUpdated: time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC),
+		}
+
+		Convey("When it is converted to a document and back", func() {
+			doc, err := newPageDocument(page)
+			So(err, ShouldBeNil)
+			converted, err := doc.toPage()
+			So(err, ShouldBeNil)
+
+			Convey("Then the page is unchanged", func() {
+				So(converted.URI, ShouldEqual, page.URI)
+				So(converted.Type, ShouldEqual, page.Type)
+				So(converted.LastUpdated, ShouldEqual, page.LastUpdated)
+				So(string(converted.Data), ShouldEqual, string(page.Data))
+			})
+		})
+	})
+
+	Convey("Given a page whose JSON would be rewritten by converting it to BSON", t, func() {
+		page := &models.Page{URI: "/economy", Type: models.PageTypeStaticPage, Data: json.RawMessage(awkwardJSON)}
+
+		Convey("When it is stored and read back", func() {
+			doc, err := newPageDocument(page)
+			So(err, ShouldBeNil)
+			var stored pageDocument
+			roundTrip(doc, &stored)
+			converted, err := stored.toPage()
+			So(err, ShouldBeNil)
+
+			Convey("Then its JSON is returned as given, with the same ETag and audit hash", func() {
+				So(string(converted.Data), ShouldEqual, awkwardJSON)
+				So(converted.ETag(), ShouldEqual, page.ETag())
+				So(models.HashPage(converted), ShouldEqual, models.HashPage(page))
+			})
+
+			Convey("Then its data can still be queried", func() {
+				So(stored.Data.Map()["weight"], ShouldEqual, 1.5)
+			})
+		})
+
+		Convey("When it was stored before its JSON was kept as given", func() {
+			doc, err := newPageDocument(page)
+			So(err, ShouldBeNil)
+			doc.Raw = ""
+			converted, err := doc.toPage()
+
+			Convey("Then its JSON is converted back from its data", func() {
+				So(err, ShouldBeNil)
+				So(string(converted.Data), ShouldContainSubstring, `"weight":1.5,`)
+			})
+		})
+	})
+
+	Convey("Given a page whose data is not a JSON object", t, func() {
+		page := &models.Page{URI: "/economy", Data: json.RawMessage(`[1,2,3]`)}
+
+		Convey("Then it cannot be converted to a document", func() {
+			_, err := newPageDocument(page)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestVersionDocument(t *testing.T) {
+	Convey("Given a previous version of a page", t, func() {
+		version := &models.PageVersion{
+			URI:              "/economy/previous/v1",
+			PageURI:          "/economy",
+			Version:          1,
+			Type:             models.PageTypeStaticPage,
+			Data:             json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`),
+			LastUpdated:      time.Date(2021, 3, 17, 7, 0, 0, 0, time.UTC),
+			SupersededAt:     time.Date(2021, 3, 18, 9, 30, 0, 0, time.UTC),
+			CorrectionNotice: "Title corrected",
+		}
+
+		Convey("When it is converted to a document and back", func() {
+			doc, err := newVersionDocument(version)
+			So(err, ShouldBeNil)
+			converted, err := doc.toVersion()
+			So(err, ShouldBeNil)
+
+			Convey("Then the version is unchanged", func() {
+				So(converted, ShouldResemble, version)
+			})
+		})
+
+		Convey("When its JSON would be rewritten by converting it to BSON, and it is stored and read back", func() {
+			version.Data = json.RawMessage(awkwardJSON)
+			doc, err := newVersionDocument(version)
+			So(err, ShouldBeNil)
+			var stored versionDocument
+			roundTrip(doc, &stored)
+			converted, err := stored.toVersion()
+
+			Convey("Then its JSON is returned as given", func() {
+				So(err, ShouldBeNil)
+				So(string(converted.Data), ShouldEqual, awkwardJSON)
+			})
+		})
+
+		Convey("When it is read back without its data", func() {
+			doc, err := newVersionDocument(version)
+			So(err, ShouldBeNil)
+			doc.Data, doc.Raw = nil, ""
+			converted, err := doc.toVersion()
+			So(err, ShouldBeNil)
+
+			Convey("Then the version has no data", func() {
+				So(converted.Data, ShouldBeNil)
+				So(converted.CorrectionNotice, ShouldEqual, version.CorrectionNotice)
+			})
+		})
+	})
+}
+
+func TestAuditQuery(t *testing.T) {
+	Convey("Given an empty audit filter", t, func() {
+		filter := models.AuditFilter{}
+
+		Convey("Then the query matches every record", func() {
+			So(auditQuery(filter), ShouldResemble, bson.M{})
+		})
+	})
+
+	Convey("Given an audit filter with every condition", t, func() {
+		from := time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)
+		filter := models.AuditFilter{URI: "/economy", User: "publisher@ons.gov.uk", From: from, To: to}
+
+		Convey("Then the query includes every condition", func() {
+			So(auditQuery(filter), ShouldResemble, bson.M{
+				"uri":       "/economy",
+				"user":      "publisher@ons.gov.uk",
+				"timestamp": bson.M{"$gte": from, "$lte": to},
+			})
+		})
+	})
+}
+
+func TestTranslationDocument(t *testing.T) {
+	Convey("Given a Welsh translation of a page", t, func() {
+		page := &models.Page{
+			URI:         "/aboutus",
+			Type:        models.PageTypeStaticPage,
+			Data:        json.RawMessage(`{"type":"static_page","description":{"title":"Amdanom ni","language":"cy"}}`),
+			LastUpdated: time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC),
+		}
+
+		Convey("When it is converted to a document and back", func() {
+			doc, err := newTranslationDocument(models.LanguageWelsh, page)
+			So(err, ShouldBeNil)
+			roundTripped, err := doc.toPage()
+			So(err, ShouldBeNil)
+
+			Convey("Then it is stored against its URI and language, and the page is unchanged", func() {
+				So(doc.ID, ShouldEqual, "cy/aboutus")
+				So(doc.URI, ShouldEqual, "/aboutus")
+				So(doc.Lang, ShouldEqual, models.LanguageWelsh)
+				So(roundTripped, ShouldResemble, page)
+			})
+		})
+
+		Convey("When its JSON would be rewritten by converting it to BSON, and it is stored and read back", func() {
+			page.Data = json.RawMessage(awkwardJSON)
+			doc, err := newTranslationDocument(models.LanguageWelsh, page)
+			So(err, ShouldBeNil)
+			var stored translationDocument
+			roundTrip(doc, &stored)
+			roundTripped, err := stored.toPage()
+
+			Convey("Then its JSON is returned as given", func() {
+				So(err, ShouldBeNil)
+				So(string(roundTripped.Data), ShouldEqual, awkwardJSON)
+			})
+		})
+	})
+}
+
+func TestDraftDocument(t *testing.T) {
+	Convey("Given a draft of a page", t, func() {
+		page := &models.Page{
+			URI:         "/economy",
+			Type:        models.PageTypeStaticPage,
+			Data:        json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`),
+			LastUpdated: time.Date(2021, 3, 17, 9, 30, 0, 0, time.UTC),
+		}
+
+		Convey("When it is converted to a document in a collection and back", func() {
+			doc, err := newDraftDocument("123", page)
+			So(err, ShouldBeNil)
+			roundTripped, err := doc.toPage()
+			So(err, ShouldBeNil)
+
+			Convey("Then it is stored against its collection and URI, with the same ETag", func() {
+				So(doc.ID, ShouldEqual, draftID("123", "/economy"))
+				So(doc.CollectionID, ShouldEqual, "123")
+				So(roundTripped.ETag(), ShouldEqual, page.ETag())
+			})
+		})
+
+		Convey("When its JSON would be rewritten by converting it to BSON, and it is stored and read back", func() {
+			page.Data = json.RawMessage(awkwardJSON)
+			doc, err := newDraftDocument("123", page)
+			So(err, ShouldBeNil)
+			var stored draftDocument
+			roundTrip(doc, &stored)
+			roundTripped, err := stored.toPage()
+			So(err, ShouldBeNil)
+
+			Convey("Then its JSON is returned as given, with the same ETag", func() {
+				So(string(roundTripped.Data), ShouldEqual, awkwardJSON)
+				So(roundTripped.ETag(), ShouldEqual, page.ETag())
+			})
+		})
+	})
+}
+
+func TestUntranslatedPipeline(t *testing.T) {
+	Convey("Given the pipeline for pages that have not been translated into Welsh", t, func() {
+		pipeline := untranslatedPipeline("translations", models.LanguageWelsh)
+
+		Convey("Then it joins pages to their translations and keeps those without a Welsh one", func() {
+			So(pipeline, ShouldHaveLength, 4)
+			So(pipeline[0][0].Key, ShouldEqual, "$lookup")
+			So(pipeline[1][0].Value, ShouldResemble, bson.M{"translations.lang": bson.M{"$ne": models.LanguageWelsh}})
+		})
+	})
+}
+
+func TestHierarchyDocument(t *testing.T) {
+	Convey("Given a page below another in the taxonomy", t, func() {
+		page := &models.Page{
+			URI:  "/economy/inflationandpriceindices",
+			Type: models.PageTypeStaticPage,
+			Data: json.RawMessage(`{"type":"static_page","description":{"title":"Inflation and price indices"}}`),
+		}
+
+		Convey("When it is indexed", func() {
+			doc, err := newHierarchyDocument(page)
+			So(err, ShouldBeNil)
+
+			Convey("Then it is summarised under its parent", func() {
+				So(doc, ShouldResemble, &hierarchyDocument{
+					URI:    "/economy/inflationandpriceindices",
+					Parent: "/economy",
+					Type:   models.PageTypeStaticPage,
+					Title:  "Inflation and price indices",
+				})
+			})
+		})
+	})
+}
+
+func TestSubtreeFilter(t *testing.T) {
+	Convey("Given the filter for a subtree whose URI contains regular expression characters", t, func() {
+		filter := subtreeFilter("uri", "/economy/gdp+")
+
+		Convey("Then it matches the URI or any URI below it, escaping the URI", func() {
+			So(filter, ShouldResemble, bson.M{"$or": bson.A{
+				bson.M{"uri": "/economy/gdp+"},
+				bson.M{"uri": bson.M{"$regex": `^/economy/gdp\+/`}},
+			}})
+		})
+	})
+}
+
+func TestReleaseDocument(t *testing.T) {
+	Convey("Given a postponed release page", t, func() {
+		page := &models.Page{
+			URI:  "/releases/cpi",
+			Type: models.PageTypeRelease,
+			Data: json.RawMessage(`{"type":"release","description":{"title":"CPI","releaseDate":"2021-04-28T06:00:00Z","finalised":true},"dateChanges":[{"previousDate":"2021-04-21T06:00:00Z","changeNotice":"Data delayed"}]}`),
+		}
+
+		Convey("When it is converted to a document", func() {
+			doc, err := newPageDocument(page)
+
+			Convey("Then its release date and status are stored alongside its data", func() {
+				So(err, ShouldBeNil)
+				So(doc.Release, ShouldResemble, &releaseFields{Date: time.Date(2021, 4, 28, 6, 0, 0, 0, time.UTC), Status: models.ReleaseStatusPostponed})
+			})
+		})
+	})
+
+	Convey("Given a page that is not a release", t, func() {
+		page := &models.Page{URI: "/economy", Type: models.PageTypeStaticPage, Data: json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`)}
+
+		Convey("Then no release fields are stored with it", func() {
+			doc, err := newPageDocument(page)
+			So(err, ShouldBeNil)
+			So(doc.Release, ShouldBeNil)
+		})
+	})
+}
+
+func TestReleasesQuery(t *testing.T) {
+	Convey("Given a filter on status and a range of dates", t, func() {
+		from := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC)
+		filter := models.ReleaseFilter{Status: models.ReleaseStatusConfirmed, From: from, To: to, Limit: 10}
+
+		Convey("Then the query selects releases with that status between the dates", func() {
+			So(releasesQuery(filter), ShouldResemble, bson.M{
+				"type":           models.PageTypeRelease,
+				"release.status": models.ReleaseStatusConfirmed,
+				"release.date":   bson.M{"$gte": from, "$lte": to},
+			})
+		})
+	})
+
+	Convey("Given an empty filter", t, func() {
+		Convey("Then the query selects every release", func() {
+			So(releasesQuery(models.ReleaseFilter{}), ShouldResemble, bson.M{"type": models.PageTypeRelease})
+		})
+	})
+}
+
+func TestTimeseriesQuery(t *testing.T) {
+	Convey("Given a CDID and the dataset it is from", t, func() {
+		Convey("Then the query selects the timeseries with that CDID from the dataset", func() {
+			So(timeseriesQuery("D7G7", "MM23"), ShouldResemble, bson.M{
+				"type":                       models.PageTypeTimeseries,
+				"data.description.cdid":      "D7G7",
+				"data.description.datasetId": "MM23",
+			})
+		})
+	})
+
+	Convey("Given a CDID alone", t, func() {
+		Convey("Then the query selects the timeseries with that CDID from any dataset", func() {
+			So(timeseriesQuery("D7G7", ""), ShouldResemble, bson.M{"type": models.PageTypeTimeseries, "data.description.cdid": "D7G7"})
+		})
+	})
+}
+
+func TestTimeseriesValuesUpdate(t *testing.T) {
+	Convey("Given a timeseries page with annual and monthly observations", t, func() {
+		lastUpdated := time.Date(2021, 4, 21, 6, 0, 0, 0, time.UTC)
+		doc, err := newPageDocument(&models.Page{
+			URI:         "/timeseries/d7g7/mm23",
+			Type:        models.PageTypeTimeseries,
+			Data:        json.RawMessage(`{"type":"timeseries","description":{"title":"CPI","cdid":"D7G7"},"years":[{"date":"2020","value":"0.9"}],"months":[{"date":"2021 MAR","value":"0.7"}]}`),
+			LastUpdated: lastUpdated,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("Then the update sets only its observations and the page JSON, removing quarters as it has none", func() {
+			update := timeseriesValuesUpdate(doc)
+			set := update["$set"].(bson.M)
+			So(set, ShouldHaveLength, 4)
+			So(set["last_updated"], ShouldEqual, lastUpdated)
+			So(set["raw"], ShouldEqual, doc.Raw)
+			So(set, ShouldContainKey, "data.years")
+			So(set, ShouldContainKey, "data.months")
+			So(update["$unset"], ShouldResemble, bson.M{"data.quarters": ""})
+		})
+	})
+}
@@ -0,0 +1,23 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// newCommandMonitor returns a monitor that records how long each command sent to MongoDB takes, by the name of
+// the command, e.g. find or update
+func newCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			metrics.ObserveStoreOperation(e.CommandName, time.Duration(e.DurationNanos), nil)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			metrics.ObserveStoreOperation(e.CommandName, time.Duration(e.DurationNanos), errors.New(e.Failure))
+		},
+	}
+}
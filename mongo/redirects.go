@@ -0,0 +1,147 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// redirectDocument is the representation of a redirect as stored in MongoDB
+type redirectDocument struct {
+	From        string    `bson:"_id"`
+	To          string    `bson:"to"`
+	LastUpdated time.Time `bson:"last_updated"`
+}
+
+// GetRedirect returns the redirect from the provided URI
+func (m *Mongo) GetRedirect(ctx context.Context, from string) (*models.Redirect, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	var doc redirectDocument
+	if err := m.redirects.FindOne(ctx, bson.M{"_id": from}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrRedirectNotFound
+		}
+		return nil, err
+	}
+	return doc.toRedirect(), nil
+}
+
+// GetRedirects returns every redirect, by the URI it is from
+func (m *Mongo) GetRedirects(ctx context.Context) ([]*models.Redirect, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	cursor, err := m.redirects.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []redirectDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	redirects := make([]*models.Redirect, len(docs))
+	for i := range docs {
+		redirects[i] = docs[i].toRedirect()
+	}
+	return redirects, nil
+}
+
+// UpsertRedirect creates or replaces the redirect from its URI, returning true if a new redirect was created. A
+// redirect to a URI that is itself redirected is stored as a redirect to the end of the chain, and redirects that
+// led to its URI are changed to lead to the same place. The redirect is updated to where it leads once stored.
+// Redirects that would lead back to their own URI are rejected.
+func (m *Mongo) UpsertRedirect(ctx context.Context, redirect *models.Redirect) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	created, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		err := m.redirects.FindOne(sc, bson.M{"_id": redirect.From}).Err()
+		created := err == mongo.ErrNoDocuments
+		if err != nil && !created {
+			return false, err
+		}
+		return created, m.addRedirect(sc, redirect)
+	})
+	if err != nil {
+		return false, err
+	}
+	return created.(bool), nil
+}
+
+// UpsertRedirects creates or replaces each of the redirects as UpsertRedirect does. The redirects are stored in a
+// single transaction, so either every redirect is stored or none are.
+func (m *Mongo) UpsertRedirects(ctx context.Context, redirects []*models.Redirect) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		for _, redirect := range redirects {
+			if err := m.addRedirect(sc, redirect); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// DeleteRedirect removes the redirect from the provided URI
+func (m *Mongo) DeleteRedirect(ctx context.Context, from string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	res, err := m.redirects.DeleteOne(ctx, bson.M{"_id": from})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return apierrors.ErrRedirectNotFound
+	}
+	return nil
+}
+
+// addRedirect follows the redirect to the end of any chain it starts and stores it, changing the redirects that led
+// to its URI to lead to the same place. It fails if the redirect would lead back to its own URI or if its URI has a
+// page. It must be called within a transaction so that the redirects are changed together.
+func (m *Mongo) addRedirect(ctx context.Context, redirect *models.Redirect) error {
+	if err := m.pages.FindOne(ctx, bson.M{"_id": redirect.From}).Err(); err != mongo.ErrNoDocuments {
+		if err == nil {
+			err = apierrors.ErrRedirectFromPage
+		}
+		return err
+	}
+
+	var next redirectDocument
+	err := m.redirects.FindOne(ctx, bson.M{"_id": redirect.To}).Decode(&next)
+	switch err {
+	case nil:
+		redirect.To = next.To
+	case mongo.ErrNoDocuments:
+	default:
+		return err
+	}
+	if redirect.To == redirect.From {
+		return apierrors.ErrRedirectLoop
+	}
+
+	update := bson.M{"$set": bson.M{"to": redirect.To, "last_updated": redirect.LastUpdated}}
+	if _, err := m.redirects.UpdateMany(ctx, bson.M{"to": redirect.From}, update); err != nil {
+		return err
+	}
+	doc := redirectDocument(*redirect)
+	_, err = m.redirects.ReplaceOne(ctx, bson.M{"_id": doc.From}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+// toRedirect converts a stored document back into a redirect
+func (doc *redirectDocument) toRedirect() *models.Redirect {
+	return &models.Redirect{From: doc.From, To: doc.To, LastUpdated: doc.LastUpdated.UTC()}
+}
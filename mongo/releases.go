@@ -0,0 +1,129 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// releaseFields are stored alongside the data of a release page, so that the release calendar can be filtered
+// and sorted without reading the data of every release
+type releaseFields struct {
+	Date   time.Time            `bson:"date"`
+	Status models.ReleaseStatus `bson:"status"`
+}
+
+// GetReleases returns the page of releases matching the filter, in order of release date, along with the total
+// number of releases that match it
+func (m *Mongo) GetReleases(ctx context.Context, filter models.ReleaseFilter) ([]*models.ReleaseSummary, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	query := releasesQuery(filter)
+	total, err := m.pages.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "release.date", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(filter.Offset))
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cursor, err := m.pages.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	releases := []*models.ReleaseSummary{}
+	for cursor.Next(ctx) {
+		var doc pageDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		page, err := doc.toPage()
+		if err != nil {
+			return nil, 0, err
+		}
+		release, err := models.SummariseRelease(page)
+		if err != nil {
+			return nil, 0, err
+		}
+		releases = append(releases, release)
+	}
+	return releases, int(total), cursor.Err()
+}
+
+// releasesQuery returns the selector for the release pages matching the filter
+func releasesQuery(filter models.ReleaseFilter) bson.M {
+	query := bson.M{"type": models.PageTypeRelease}
+	if filter.Status != "" {
+		query["release.status"] = filter.Status
+	}
+	date := bson.M{}
+	if !filter.From.IsZero() {
+		date["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		date["$lte"] = filter.To
+	}
+	if len(date) > 0 {
+		query["release.date"] = date
+	}
+	return query
+}
+
+// newReleaseFields returns the fields to store alongside the page if it is a release, or nil if it is not
+func newReleaseFields(page *models.Page) (*releaseFields, error) {
+	if page.Type != models.PageTypeRelease {
+		return nil, nil
+	}
+	release, err := models.SummariseRelease(page)
+	if err != nil {
+		return nil, err
+	}
+	return &releaseFields{Date: release.ReleaseDate, Status: release.Status}, nil
+}
+
+// indexReleases adds the release fields to any release pages stored without them, e.g. before the release
+// calendar was introduced. Each page is updated within the query timeout, but finding them is not bound by it.
+func (m *Mongo) indexReleases(ctx context.Context) error {
+	cursor, err := m.pages.Find(ctx, bson.M{"type": models.PageTypeRelease, "release": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		var doc pageDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		page, err := doc.toPage()
+		if err != nil {
+			return err
+		}
+		release, err := newReleaseFields(page)
+		if err != nil {
+			return err
+		}
+		updateCtx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+		_, err = m.pages.UpdateOne(updateCtx, bson.M{"_id": doc.URI}, bson.M{"$set": bson.M{"release": release}})
+		cancel()
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	if count > 0 {
+		log.Event(ctx, "indexed stored releases", log.INFO, log.Data{"releases": count})
+	}
+	return cursor.Err()
+}
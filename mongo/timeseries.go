@@ -0,0 +1,103 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// timeseriesValueFields are the fields of the data of a timeseries page that hold its observations
+var timeseriesValueFields = []string{"years", "quarters", "months"}
+
+// GetTimeseries returns the timeseries page with the CDID, from the source dataset if one is given.
+// ErrTimeseriesAmbiguous is returned if no dataset is given and the CDID is published in more than one.
+func (m *Mongo) GetTimeseries(ctx context.Context, cdid, datasetID string) (*models.Page, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	cursor, err := m.pages.Find(ctx, timeseriesQuery(cdid, datasetID), options.Find().SetLimit(2))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []pageDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	switch len(docs) {
+	case 0:
+		return nil, apierrors.ErrTimeseriesNotFound
+	case 1:
+		return docs[0].toPage()
+	}
+	return nil, apierrors.ErrTimeseriesAmbiguous
+}
+
+// UpdateTimeseriesValues stores the timeseries page in place of the one at its URI, changing only its observations.
+// The page it replaces is not kept as a previous version, as revisions to data are not corrections. The page is only
+// replaced if the stored page still has the ETag given, so that concurrent updates cannot lose each other's
+// observations, and only the observations and the page JSON they belong to are written, rather than the whole page.
+func (m *Mongo) UpdateTimeseriesValues(ctx context.Context, page *models.Page, etag string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newPageDocument(page)
+	if err != nil {
+		return err
+	}
+
+	selector := bson.M{"_id": page.URI, "type": models.PageTypeTimeseries}
+	_, err = m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var existing pageDocument
+		if err := m.pages.FindOne(sc, selector).Decode(&existing); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, apierrors.ErrTimeseriesNotFound
+			}
+			return nil, err
+		}
+		if err := checkETag(existing.toPage, etag); err != nil {
+			return nil, err
+		}
+		return m.pages.UpdateOne(sc, selector, timeseriesValuesUpdate(doc))
+	})
+	return err
+}
+
+// timeseriesQuery returns the selector for timeseries pages with the CDID, from the source dataset if one is given
+func timeseriesQuery(cdid, datasetID string) bson.M {
+	query := bson.M{"type": models.PageTypeTimeseries, "data.description.cdid": cdid}
+	if datasetID != "" {
+		query["data.description.datasetId"] = datasetID
+	}
+	return query
+}
+
+// timeseriesValuesUpdate returns the update that sets the observations of a stored timeseries to those of the
+// document, removing any frequency that the document has no observations for
+func timeseriesValuesUpdate(doc *pageDocument) bson.M {
+	values := make(map[string]interface{})
+	for _, e := range doc.Data {
+		values[e.Key] = e.Value
+	}
+
+	set := bson.M{"last_updated": doc.LastUpdated, "raw": doc.Raw}
+	unset := bson.M{}
+	for _, field := range timeseriesValueFields {
+		if value, ok := values[field]; ok {
+			set["data."+field] = value
+		} else {
+			unset["data."+field] = ""
+		}
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// translationDocument is the representation of a translated page as stored in MongoDB
+type translationDocument struct {
+	ID          string          `bson:"_id"`
+	URI         string          `bson:"uri"`
+	Lang        models.Language `bson:"lang"`
+	Type        models.PageType `bson:"type"`
+	Data        bson.D          `bson:"data"`
+	Raw         string          `bson:"raw,omitempty"`
+	LastUpdated time.Time       `bson:"last_updated"`
+}
+
+// GetTranslation returns the translation of the page at the provided URI into the language
+func (m *Mongo) GetTranslation(ctx context.Context, uri string, lang models.Language) (*models.Page, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	var doc translationDocument
+	if err := m.translations.FindOne(ctx, bson.M{"_id": translationID(uri, lang)}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrTranslationNotFound
+		}
+		return nil, err
+	}
+	return doc.toPage()
+}
+
+// CreateTranslation stores a new translation of a page, failing if the page already has a translation into the language
+func (m *Mongo) CreateTranslation(ctx context.Context, lang models.Language, page *models.Page) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newTranslationDocument(lang, page)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.translations.InsertOne(ctx, doc); err != nil {
+		if isDuplicateKeyError(err) {
+			return apierrors.ErrTranslationAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// UpsertTranslation creates or replaces the translation of a page into the language, returning true if a new
+// translation was created. Previous versions of translations are not kept.
+func (m *Mongo) UpsertTranslation(ctx context.Context, lang models.Language, page *models.Page) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newTranslationDocument(lang, page)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := m.translations.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+// ReplaceTranslation replaces the translation of a page into the language only if the stored translation still has
+// the ETag, so that changes made since it was read are not overwritten
+func (m *Mongo) ReplaceTranslation(ctx context.Context, lang models.Language, page *models.Page, etag string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	doc, err := newTranslationDocument(lang, page)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.withTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var existing translationDocument
+		if err := m.translations.FindOne(sc, bson.M{"_id": doc.ID}).Decode(&existing); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, apierrors.ErrTranslationNotFound
+			}
+			return nil, err
+		}
+		if err := checkETag(existing.toPage, etag); err != nil {
+			return nil, err
+		}
+		_, err := m.translations.ReplaceOne(sc, bson.M{"_id": doc.ID}, doc)
+		return nil, err
+	})
+	return err
+}
+
+// DeleteTranslation removes the translation of the page at the provided URI into the language
+func (m *Mongo) DeleteTranslation(ctx context.Context, uri string, lang models.Language) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	res, err := m.translations.DeleteOne(ctx, bson.M{"_id": translationID(uri, lang)})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return apierrors.ErrTranslationNotFound
+	}
+	return nil
+}
+
+// GetUntranslatedPages returns a summary of every page that has not been translated into the language, by URI
+func (m *Mongo) GetUntranslatedPages(ctx context.Context, lang models.Language) ([]*models.PageSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	cursor, err := m.pages.Aggregate(ctx, untranslatedPipeline(m.TranslationsCollection, lang))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []struct {
+		URI   string          `bson:"_id"`
+		Type  models.PageType `bson:"type"`
+		Title string          `bson:"title"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*models.PageSummary, len(docs))
+	for i, doc := range docs {
+		summaries[i] = &models.PageSummary{URI: doc.URI, Type: doc.Type, Title: doc.Title}
+	}
+	return summaries, nil
+}
+
+// untranslatedPipeline returns the aggregation of pages that summarises those without a translation into the
+// language. Each page is joined to its translations, keeping those that have none in the language.
+func untranslatedPipeline(translationsCollection string, lang models.Language) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: translationsCollection},
+			{Key: "localField", Value: "_id"},
+			{Key: "foreignField", Value: "uri"},
+			{Key: "as", Value: "translations"},
+		}}},
+		{{Key: "$match", Value: bson.M{"translations.lang": bson.M{"$ne": lang}}}},
+		{{Key: "$project", Value: bson.M{"type": 1, "title": "$data.description.title"}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+}
+
+// translationID returns the ID of the translation of the page at the URI into the language
+func translationID(uri string, lang models.Language) string {
+	return string(lang) + uri
+}
+
+// newTranslationDocument converts a translated page into its MongoDB representation
+func newTranslationDocument(lang models.Language, page *models.Page) (*translationDocument, error) {
+	pageDoc, err := newPageDocument(page)
+	if err != nil {
+		return nil, err
+	}
+	return &translationDocument{
+		ID:          translationID(page.URI, lang),
+		URI:         page.URI,
+		Lang:        lang,
+		Type:        page.Type,
+		Data:        pageDoc.Data,
+		Raw:         pageDoc.Raw,
+		LastUpdated: page.LastUpdated,
+	}, nil
+}
+
+// toPage converts a stored translation back into a page
+func (doc *translationDocument) toPage() (*models.Page, error) {
+	page := &pageDocument{URI: doc.URI, Type: doc.Type, Data: doc.Data, Raw: doc.Raw, LastUpdated: doc.LastUpdated}
+	return page.toPage()
+}
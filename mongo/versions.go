@@ -0,0 +1,112 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// versionDocument is the representation of a previous version of a page as stored in MongoDB
+type versionDocument struct {
+	URI              string          `bson:"_id"`
+	PageURI          string          `bson:"page_uri"`
+	Version          int             `bson:"version"`
+	Type             models.PageType `bson:"type"`
+	Data             bson.D          `bson:"data"`
+	Raw              string          `bson:"raw,omitempty"`
+	LastUpdated      time.Time       `bson:"last_updated"`
+	SupersededAt     time.Time       `bson:"superseded_at"`
+	CorrectionNotice string          `bson:"correction_notice,omitempty"`
+}
+
+// GetPageVersions returns every previous version of the page at the provided URI, oldest first
+func (m *Mongo) GetPageVersions(ctx context.Context, uri string) ([]*models.PageVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.M{"version": 1}).SetProjection(bson.M{"data": 0, "raw": 0})
+	cursor, err := m.versions.Find(ctx, bson.M{"page_uri": uri}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []versionDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		// a page that has never been replaced has no versions, but a page that does not exist is not found
+		if _, err := m.GetPage(ctx, uri); err != nil {
+			return nil, err
+		}
+	}
+
+	versions := make([]*models.PageVersion, len(docs))
+	for i := range docs {
+		if versions[i], err = docs[i].toVersion(); err != nil {
+			return nil, err
+		}
+	}
+	return versions, nil
+}
+
+// GetPageVersion returns a previous version of the page at the provided URI
+func (m *Mongo) GetPageVersion(ctx context.Context, uri string, version int) (*models.PageVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	var doc versionDocument
+	if err := m.versions.FindOne(ctx, bson.M{"page_uri": uri, "version": version}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apierrors.ErrVersionNotFound
+		}
+		return nil, err
+	}
+	return doc.toVersion()
+}
+
+// newVersionDocument converts a version into its MongoDB representation
+func newVersionDocument(version *models.PageVersion) (*versionDocument, error) {
+	var data bson.D
+	if err := bson.UnmarshalExtJSON(version.Data, false, &data); err != nil {
+		return nil, err
+	}
+	return &versionDocument{
+		URI:              version.URI,
+		PageURI:          version.PageURI,
+		Version:          version.Version,
+		Type:             version.Type,
+		Data:             data,
+		Raw:              string(version.Data),
+		LastUpdated:      version.LastUpdated,
+		SupersededAt:     version.SupersededAt,
+		CorrectionNotice: version.CorrectionNotice,
+	}, nil
+}
+
+// toVersion converts a stored document back into a version. Documents read without their data have no data.
+func (doc *versionDocument) toVersion() (*models.PageVersion, error) {
+	version := &models.PageVersion{
+		URI:              doc.URI,
+		PageURI:          doc.PageURI,
+		Version:          doc.Version,
+		Type:             doc.Type,
+		LastUpdated:      doc.LastUpdated.UTC(),
+		SupersededAt:     doc.SupersededAt.UTC(),
+		CorrectionNotice: doc.CorrectionNotice,
+	}
+	if doc.Data != nil || doc.Raw != "" {
+		data, err := storedJSON(doc.Raw, doc.Data)
+		if err != nil {
+			return nil, err
+		}
+		version.Data = data
+	}
+	return version, nil
+}
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/models"
+)
+
+//go:generate moq -out mock/store.go -pkg mock . Store
+//go:generate moq -out mock/auditStore.go -pkg mock . AuditStore
+//go:generate moq -out mock/warmer.go -pkg mock . Warmer
+//go:generate moq -out mock/eventProducer.go -pkg mock . EventProducer
+
+// Store defines the required methods from the store of collections, their draft pages and the published pages
+// that the drafts replace
+type Store interface {
+	GetCollection(ctx context.Context, id string) (*models.Collection, error)
+	GetScheduledCollections(ctx context.Context) ([]*models.Collection, error)
+	GetDraftPage(ctx context.Context, collectionID, uri string) (*models.Page, error)
+	GetPage(ctx context.Context, uri string) (*models.Page, error)
+	PublishCollection(ctx context.Context, collectionID string, publishedAt time.Time) error
+}
+
+// AuditStore defines the required methods from the store of audit records of changes to content
+type AuditStore interface {
+	AddAuditRecord(ctx context.Context, record *models.AuditRecord) error
+}
+
+// Warmer defines the required methods to prepare a collection shortly before it is published
+type Warmer interface {
+	Warm(ctx context.Context, collectionID string) error
+}
+
+// EventProducer defines the required methods to notify other services that content has been published
+type EventProducer interface {
+	ContentPublished(ctx context.Context, e *event.ContentPublished) error
+}
+
+// DraftStore defines the required methods from the store of collections and their draft pages
+type DraftStore interface {
+	GetCollection(ctx context.Context, id string) (*models.Collection, error)
+	GetDraftPage(ctx context.Context, collectionID, uri string) (*models.Page, error)
+}
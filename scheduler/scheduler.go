@@ -0,0 +1,336 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/audit"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// identity is the caller that scheduled publishes are audited as made by
+var identity = &auth.Identity{ID: "dp-content-api-scheduler", Service: true}
+
+// Scheduler publishes collections at their publish date. A single goroutine sleeps until the
+// next scheduled event, warming each collection shortly before publishing it.
+type Scheduler struct {
+	store         Store
+	auditStore    AuditStore
+	warmer        Warmer
+	eventProducer EventProducer
+	warmUp        time.Duration
+	retryInterval time.Duration
+
+	mutex   sync.Mutex
+	jobs    map[string]*job
+	wake    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+	started bool
+	closed  bool
+}
+
+// job is a collection waiting to be published. The collection is published with the date it was scheduled for,
+// even if the attempt due at that date fails and it is published by a later attempt.
+type job struct {
+	collectionID string
+	warmAt       time.Time
+	publishAt    time.Time
+	nextAttempt  time.Time
+	warmed       bool
+}
+
+// New returns a scheduler that publishes collections to the provided store, auditing the publication of each page
+// and sending an event for it. If a warmer is provided, it is called for each collection the warm up period before
+// the collection is published.
+func New(store Store, auditStore AuditStore, warmer Warmer, eventProducer EventProducer, warmUp, retryInterval time.Duration) *Scheduler {
+	return &Scheduler{
+		store:         tracedStore{store: store},
+		auditStore:    tracedAuditStore{store: auditStore},
+		warmer:        warmer,
+		eventProducer: eventProducer,
+		warmUp:        warmUp,
+		retryInterval: retryInterval,
+		jobs:          make(map[string]*job),
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// Start reloads the schedule of every collection waiting to be published and starts the publisher goroutine.
+// Collections whose publish date passed while the service was stopped are published straight away.
+func (s *Scheduler) Start(ctx context.Context) error {
+	collections, err := s.store.GetScheduledCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, collection := range collections {
+		s.Schedule(ctx, collection)
+	}
+	log.Event(ctx, "scheduled collections loaded", log.INFO, log.Data{"count": len(collections)})
+
+	s.mutex.Lock()
+	s.started = true
+	s.mutex.Unlock()
+
+	go s.run()
+	return nil
+}
+
+// Schedule publishes the collection at its publish date, replacing any existing schedule for it.
+// Collections without a publish date are removed from the schedule.
+func (s *Scheduler) Schedule(ctx context.Context, collection *models.Collection) {
+	if collection.PublishDate == nil {
+		s.Cancel(ctx, collection.ID)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return
+	}
+
+	publishAt := collection.PublishDate.UTC()
+	s.jobs[collection.ID] = &job{
+		collectionID: collection.ID,
+		warmAt:       publishAt.Add(-s.warmUp),
+		publishAt:    publishAt,
+		nextAttempt:  publishAt,
+		warmed:       s.warmer == nil,
+	}
+	s.notify()
+
+	log.Event(ctx, "collection scheduled", log.INFO, log.Data{"collection_id": collection.ID, "publish_date": publishAt})
+}
+
+// Cancel removes a collection from the schedule
+func (s *Scheduler) Cancel(ctx context.Context, collectionID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.jobs[collectionID]; ok {
+		delete(s.jobs, collectionID)
+		s.notify()
+		log.Event(ctx, "collection schedule cancelled", log.INFO, log.Data{"collection_id": collectionID})
+	}
+}
+
+// NextPublish returns the earliest time that a collection waiting to be published will be published, so that
+// responses can be cached only until content may change
+func (s *Scheduler) NextPublish() (time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var next time.Time
+	for _, j := range s.jobs {
+		if next.IsZero() || j.nextAttempt.Before(next) {
+			next = j.nextAttempt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// Close stops the publisher goroutine, waiting for any publish that is in progress to finish
+func (s *Scheduler) Close(ctx context.Context) error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.closed = true
+	started := s.started
+	close(s.done)
+	s.mutex.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notify wakes the publisher goroutine so that it recalculates the time of the next event.
+// The caller must hold the lock.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run is the publisher goroutine, which sleeps until the next event is due
+func (s *Scheduler) run() {
+	defer close(s.stopped)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		next, ok := s.nextEvent()
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var fire <-chan time.Time
+		if ok {
+			timer.Reset(time.Until(next))
+			fire = timer.C
+		}
+
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		case <-fire:
+			s.runDue(context.Background())
+		}
+	}
+}
+
+// nextEvent returns the time at which the next collection is due to be warmed or published
+func (s *Scheduler) nextEvent() (time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var next time.Time
+	for _, j := range s.jobs {
+		at := j.nextAttempt
+		if !j.warmed {
+			at = j.warmAt
+		}
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// runDue warms and publishes every collection whose time has come
+func (s *Scheduler) runDue(ctx context.Context) {
+	now := time.Now().UTC()
+
+	var warm, publish []*job
+	s.mutex.Lock()
+	for _, j := range s.jobs {
+		switch {
+		case !j.nextAttempt.After(now):
+			publish = append(publish, j)
+		case !j.warmed && !j.warmAt.After(now):
+			j.warmed = true
+			warm = append(warm, j)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, j := range warm {
+		s.warmCollection(ctx, j)
+	}
+	for _, j := range publish {
+		s.publishCollection(ctx, j)
+	}
+}
+
+// warmCollection prepares a collection for publishing. Failing to warm a collection does not prevent it being published.
+func (s *Scheduler) warmCollection(ctx context.Context, j *job) {
+	logData := log.Data{"collection_id": j.collectionID, "publish_date": j.publishAt}
+
+	ctx, cancel := context.WithDeadline(ctx, j.publishAt)
+	defer cancel()
+
+	if err := s.warmer.Warm(ctx, j.collectionID); err != nil {
+		log.Event(ctx, "warming collection failed", log.WARN, log.Error(err), logData)
+		return
+	}
+	log.Event(ctx, "collection warmed", log.INFO, logData)
+}
+
+// publishCollection publishes a scheduled collection, retrying later if the store fails. Each attempt is traced as
+// a span of its own, as it is not made within a request.
+func (s *Scheduler) publishCollection(ctx context.Context, j *job) {
+	id := j.collectionID
+	logData := log.Data{"collection_id": id, "publish_date": j.publishAt}
+
+	ctx, span := tracing.Start(ctx, "Scheduler.PublishCollection", collectionAttribute(id))
+	ctx = auth.WithIdentity(ctx, identity)
+	started := time.Now()
+	collection, err := s.publish(ctx, id, j.publishAt)
+	metrics.ObservePublish(metrics.TriggerScheduled, started, err)
+	if err == nil {
+		log.Event(ctx, "scheduled collection published", log.INFO, logData)
+		s.sendEvents(ctx, collection, logData)
+	}
+	tracing.End(span, err)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the collection may have been rescheduled or cancelled while it was being published
+	if s.jobs[id] != j {
+		return
+	}
+
+	switch err {
+	case nil:
+	case apierrors.ErrCollectionNotFound, apierrors.ErrCollectionPublished, apierrors.ErrCollectionNotPublishable:
+		log.Event(ctx, "scheduled collection could not be published", log.ERROR, log.Error(err), logData)
+	default:
+		j.nextAttempt = time.Now().UTC().Add(s.retryInterval)
+		logData["next_attempt"] = j.nextAttempt
+		log.Event(ctx, "publishing scheduled collection failed, will retry", log.ERROR, log.Error(err), logData)
+		return
+	}
+	delete(s.jobs, id)
+}
+
+// publish audits the publication of every page in the collection and publishes it, recording the publication as
+// failed if the store fails to make it. The collection is returned as it was published, so that it need not be
+// read again before its pages are announced.
+func (s *Scheduler) publish(ctx context.Context, id string, publishedAt time.Time) (*models.Collection, error) {
+	collection, err := s.store.GetCollection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case collection.State == models.CollectionStatePublished:
+		return nil, apierrors.ErrCollectionPublished
+	case !collection.IsPublishable():
+		return nil, apierrors.ErrCollectionNotPublishable
+	}
+
+	records, err := audit.Publish(ctx, s.auditStore, s.store, s.store, collection)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.PublishCollection(ctx, id, publishedAt); err != nil {
+		audit.Failed(ctx, s.auditStore, records...)
+		return nil, err
+	}
+	return collection.Published(publishedAt), nil
+}
+
+// sendEvents notifies other services of every page in a published collection. The collection has already
+// been published, so failures are logged rather than retried.
+func (s *Scheduler) sendEvents(ctx context.Context, collection *models.Collection, logData log.Data) {
+	for _, e := range event.CollectionPublished(collection) {
+		if err := s.eventProducer.ContentPublished(ctx, e); err != nil {
+			log.Event(ctx, "sending content published event failed", log.ERROR, log.Error(err), logData)
+		}
+	}
+}
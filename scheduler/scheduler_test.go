@@ -0,0 +1,334 @@
+package scheduler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/apierrors"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/memory"
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/scheduler"
+	"github.com/ONSdigital/dp-content-api/scheduler/mock"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	ctx      = context.Background()
+	errStore = errors.New("store is unavailable")
+)
+
+// newReadyCollection stores a collection containing a single reviewed page, ready to be published at the provided time
+func newReadyCollection(store *memory.Store, id string, publishDate time.Time) *models.Collection {
+	collection := &models.Collection{
+		ID:          id,
+		Name:        "March 2021 inflation",
+		State:       models.CollectionStateInProgress,
+		PublishDate: &publishDate,
+	}
+	So(store.CreateCollection(ctx, collection), ShouldBeNil)
+	So(store.UpsertDraftPage(ctx, id, &models.Page{
+		URI:  "/economy/" + id,
+		Type: models.PageTypeStaticPage,
+		Data: json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`),
+	}), ShouldBeNil)
+	So(store.UpdateItemState(ctx, id, "/economy/"+id, models.ItemStateReviewed), ShouldBeNil)
+	return collection
+}
+
+func newEventProducer() *mock.EventProducerMock {
+	return &mock.EventProducerMock{
+		ContentPublishedFunc: func(ctx context.Context, e *event.ContentPublished) error { return nil },
+	}
+}
+
+func isPublished(store *memory.Store, id string) func() bool {
+	return func() bool {
+		collection, err := store.GetCollection(ctx, id)
+		return err == nil && collection.State == models.CollectionStatePublished
+	}
+}
+
+// eventually polls the condition until it is true or the timeout expires
+func eventually(condition func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return condition()
+}
+
+func TestScheduler(t *testing.T) {
+	Convey("Given a started scheduler with a warmer", t, func() {
+		store := memory.New()
+		var warmed sync.Map
+		warmer := &mock.WarmerMock{
+			WarmFunc: func(ctx context.Context, collectionID string) error {
+				warmed.Store(collectionID, time.Now())
+				return nil
+			},
+		}
+		events := newEventProducer()
+		s := scheduler.New(store, store, warmer, events, 50*time.Millisecond, 10*time.Millisecond)
+		So(s.Start(ctx), ShouldBeNil)
+		defer s.Close(ctx)
+
+		Convey("When a collection is scheduled", func() {
+			publishDate := time.Now().Add(100 * time.Millisecond)
+			s.Schedule(ctx, newReadyCollection(store, "123", publishDate))
+
+			Convey("Then it is not published before its publish date", func() {
+				So(isPublished(store, "123")(), ShouldBeFalse)
+			})
+
+			Convey("Then it is warmed before being published at its publish date", func() {
+				So(eventually(isPublished(store, "123"), time.Second), ShouldBeTrue)
+				warmedAt, ok := warmed.Load("123")
+				So(ok, ShouldBeTrue)
+				So(warmedAt.(time.Time), ShouldHappenBefore, publishDate)
+
+				collection, err := store.GetCollection(ctx, "123")
+				So(err, ShouldBeNil)
+				So(collection.PublishedAt.Equal(publishDate), ShouldBeTrue)
+				page, err := store.GetPage(ctx, "/economy/123")
+				So(err, ShouldBeNil)
+				So(page.Type, ShouldEqual, models.PageTypeStaticPage)
+
+				So(eventually(func() bool { return len(events.ContentPublishedCalls()) == 1 }, time.Second), ShouldBeTrue)
+				e := events.ContentPublishedCalls()[0].E
+				So(e.URI, ShouldEqual, "/economy/123")
+				So(e.Type, ShouldEqual, models.PageTypeStaticPage)
+				So(e.CollectionID, ShouldEqual, "123")
+				So(e.Timestamp.Equal(publishDate), ShouldBeTrue)
+			})
+
+			Convey("Then the publication of its page is audited as made by the scheduler", func() {
+				So(eventually(isPublished(store, "123"), time.Second), ShouldBeTrue)
+				records, _, err := store.GetAuditRecords(ctx, models.AuditFilter{URI: "/economy/123"})
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 1)
+				So(records[0].Action, ShouldEqual, models.AuditActionPublish)
+				So(records[0].CollectionID, ShouldEqual, "123")
+				So(records[0].User, ShouldEqual, "dp-content-api-scheduler")
+				So(records[0].Service, ShouldBeTrue)
+				So(records[0].BeforeHash, ShouldBeEmpty)
+				So(records[0].AfterHash, ShouldNotBeEmpty)
+			})
+		})
+
+		Convey("When collections are scheduled at different dates", func() {
+			_, ok := s.NextPublish()
+			So(ok, ShouldBeFalse)
+
+			first := time.Now().Add(time.Hour).UTC()
+			s.Schedule(ctx, newReadyCollection(store, "123", first.Add(time.Hour)))
+			s.Schedule(ctx, newReadyCollection(store, "456", first))
+
+			Convey("Then the next publish is at the earliest date", func() {
+				next, ok := s.NextPublish()
+				So(ok, ShouldBeTrue)
+				So(next, ShouldEqual, first)
+			})
+
+			Convey("Then the next publish moves on when the earliest is cancelled", func() {
+				s.Cancel(ctx, "456")
+				next, ok := s.NextPublish()
+				So(ok, ShouldBeTrue)
+				So(next, ShouldEqual, first.Add(time.Hour))
+			})
+		})
+
+		Convey("When a scheduled collection is cancelled", func() {
+			s.Schedule(ctx, newReadyCollection(store, "123", time.Now().Add(20*time.Millisecond)))
+			s.Cancel(ctx, "123")
+
+			Convey("Then it is not published", func() {
+				time.Sleep(100 * time.Millisecond)
+				So(isPublished(store, "123")(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When a collection is scheduled in the past", func() {
+			s.Schedule(ctx, newReadyCollection(store, "123", time.Now().Add(-time.Minute)))
+
+			Convey("Then it is published straight away", func() {
+				So(eventually(isPublished(store, "123"), time.Second), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a store containing a collection scheduled before the service restarted", t, func() {
+		store := memory.New()
+		newReadyCollection(store, "123", time.Now().Add(20*time.Millisecond))
+		s := scheduler.New(store, store, nil, newEventProducer(), 0, time.Second)
+
+		Convey("When the scheduler is started", func() {
+			So(s.Start(ctx), ShouldBeNil)
+			defer s.Close(ctx)
+
+			Convey("Then the collection is published at its publish date", func() {
+				So(eventually(isPublished(store, "123"), time.Second), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a store that fails the first time a collection is published", t, func() {
+		var mutex sync.Mutex
+		attempts := 0
+		store := &mock.StoreMock{
+			GetScheduledCollectionsFunc: func(ctx context.Context) ([]*models.Collection, error) { return nil, nil },
+			PublishCollectionFunc: func(ctx context.Context, collectionID string, publishedAt time.Time) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				attempts++
+				if attempts == 1 {
+					return errStore
+				}
+				return nil
+			},
+			GetCollectionFunc: func(ctx context.Context, id string) (*models.Collection, error) {
+				items := []models.CollectionItem{{URI: "/economy", State: models.ItemStateReviewed}}
+				return &models.Collection{ID: id, State: models.CollectionStateInProgress, Items: items}, nil
+			},
+			GetDraftPageFunc: func(ctx context.Context, collectionID, uri string) (*models.Page, error) {
+				return &models.Page{URI: uri, Data: json.RawMessage(`{"type":"static_page","description":{"title":"Economy"}}`)}, nil
+			},
+			GetPageFunc: func(ctx context.Context, uri string) (*models.Page, error) { return nil, apierrors.ErrPageNotFound },
+		}
+		auditStore := memory.New()
+		events := newEventProducer()
+		s := scheduler.New(store, auditStore, nil, events, 0, 10*time.Millisecond)
+		So(s.Start(ctx), ShouldBeNil)
+		defer s.Close(ctx)
+
+		Convey("When a collection is scheduled", func() {
+			publishDate := time.Now()
+			s.Schedule(ctx, &models.Collection{ID: "123", PublishDate: &publishDate})
+
+			Convey("Then publishing is retried", func() {
+				So(eventually(func() bool { return len(store.PublishCollectionCalls()) == 2 }, time.Second), ShouldBeTrue)
+				time.Sleep(50 * time.Millisecond)
+				So(store.PublishCollectionCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("Then the retry publishes it with the date it was scheduled for", func() {
+				So(eventually(func() bool { return len(store.PublishCollectionCalls()) == 2 }, time.Second), ShouldBeTrue)
+				So(store.PublishCollectionCalls()[1].PublishedAt.Equal(publishDate), ShouldBeTrue)
+			})
+
+			Convey("Then its page is announced once it is published, without reading the collection again", func() {
+				So(eventually(func() bool { return len(events.ContentPublishedCalls()) == 1 }, time.Second), ShouldBeTrue)
+				So(events.ContentPublishedCalls()[0].E.URI, ShouldEqual, "/economy")
+				So(events.ContentPublishedCalls()[0].E.Timestamp, ShouldEqual, store.PublishCollectionCalls()[1].PublishedAt)
+				So(store.GetCollectionCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("Then the failed publication is recorded as failed in the audit records", func() {
+				So(eventually(func() bool { return len(store.PublishCollectionCalls()) == 2 }, time.Second), ShouldBeTrue)
+				records, _, err := auditStore.GetAuditRecords(ctx, models.AuditFilter{})
+				So(err, ShouldBeNil)
+				So(records, ShouldHaveLength, 3)
+				So(records[2].Failed, ShouldBeFalse)
+				So(records[1].Failed, ShouldBeTrue)
+				So(records[1].BeforeHash, ShouldEqual, records[2].BeforeHash)
+				So(records[0].Failed, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a store that cannot load the schedule", t, func() {
+		store := &mock.StoreMock{
+			GetScheduledCollectionsFunc: func(ctx context.Context) ([]*models.Collection, error) { return nil, errStore },
+		}
+
+		Convey("Then the scheduler fails to start", func() {
+			So(scheduler.New(store, &mock.AuditStoreMock{}, nil, newEventProducer(), 0, time.Second).Start(ctx), ShouldEqual, errStore)
+		})
+	})
+
+	Convey("Given a closed scheduler", t, func() {
+		store := memory.New()
+		s := scheduler.New(store, store, nil, newEventProducer(), 0, time.Second)
+		So(s.Start(ctx), ShouldBeNil)
+		So(s.Close(ctx), ShouldBeNil)
+
+		Convey("When a collection is scheduled", func() {
+			s.Schedule(ctx, newReadyCollection(store, "123", time.Now()))
+
+			Convey("Then it is not published", func() {
+				time.Sleep(50 * time.Millisecond)
+				So(isPublished(store, "123")(), ShouldBeFalse)
+			})
+		})
+
+		Convey("Then closing it again succeeds", func() {
+			So(s.Close(ctx), ShouldBeNil)
+		})
+	})
+}
+
+func TestDraftWarmer(t *testing.T) {
+	Convey("Given a collection containing a draft page", t, func() {
+		store := memory.New()
+		newReadyCollection(store, "123", time.Now().Add(time.Hour))
+		w := scheduler.NewDraftWarmer(store)
+
+		Convey("Then it can be warmed", func() {
+			So(w.Warm(ctx, "123"), ShouldBeNil)
+		})
+
+		Convey("Then a collection that does not exist cannot be warmed", func() {
+			So(w.Warm(ctx, "456"), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSchedulerTracing(t *testing.T) {
+	Convey("Given spans are recorded in memory", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		defer otel.SetTracerProvider(previous)
+
+		store := memory.New()
+		s := scheduler.New(store, store, nil, newEventProducer(), 0, time.Second)
+		So(s.Start(ctx), ShouldBeNil)
+		defer s.Close(ctx)
+
+		Convey("When a scheduled collection is published", func() {
+			s.Schedule(ctx, newReadyCollection(store, "123", time.Now()))
+			So(eventually(isPublished(store, "123"), time.Second), ShouldBeTrue)
+
+			Convey("Then the publish is traced, with a child span for each store operation it made", func() {
+				So(eventually(func() bool {
+					for _, span := range recorder.Ended() {
+						if span.Name() == "Scheduler.PublishCollection" {
+							return true
+						}
+					}
+					return false
+				}, time.Second), ShouldBeTrue)
+
+				spans := map[string]sdktrace.ReadOnlySpan{}
+				for _, span := range recorder.Ended() {
+					spans[span.Name()] = span
+				}
+				publish := spans["Scheduler.PublishCollection"]
+				for _, name := range []string{"CollectionStore.GetCollection", "AuditStore.AddAuditRecord", "CollectionStore.PublishCollection"} {
+					So(spans, ShouldContainKey, name)
+					So(spans[name].Parent().SpanID(), ShouldEqual, publish.SpanContext().SpanID())
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-content-api/models"
+	"github.com/ONSdigital/dp-content-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedStore records a span for every operation the scheduler makes on the store, within the span of the
+// scheduled publish that made it
+type tracedStore struct {
+	store Store
+}
+
+// collectionAttribute is the attribute that spans are given for the ID of the collection they operate on
+func collectionAttribute(id string) attribute.KeyValue {
+	return attribute.String("collection.id", id)
+}
+
+func (s tracedStore) GetCollection(ctx context.Context, id string) (collection *models.Collection, err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.GetCollection", collectionAttribute(id))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetCollection(ctx, id)
+}
+
+func (s tracedStore) GetScheduledCollections(ctx context.Context) (collections []*models.Collection, err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.GetScheduledCollections")
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetScheduledCollections(ctx)
+}
+
+func (s tracedStore) GetDraftPage(ctx context.Context, collectionID, uri string) (page *models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.GetDraftPage", collectionAttribute(collectionID), attribute.String("content.uri", uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetDraftPage(ctx, collectionID, uri)
+}
+
+func (s tracedStore) GetPage(ctx context.Context, uri string) (page *models.Page, err error) {
+	ctx, span := tracing.Start(ctx, "ContentStore.GetPage", attribute.String("content.uri", uri))
+	defer func() { tracing.End(span, err) }()
+	return s.store.GetPage(ctx, uri)
+}
+
+func (s tracedStore) PublishCollection(ctx context.Context, collectionID string, publishedAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "CollectionStore.PublishCollection", collectionAttribute(collectionID))
+	defer func() { tracing.End(span, err) }()
+	return s.store.PublishCollection(ctx, collectionID, publishedAt)
+}
+
+// tracedAuditStore records a span for every audit record the scheduler adds
+type tracedAuditStore struct {
+	store AuditStore
+}
+
+func (s tracedAuditStore) AddAuditRecord(ctx context.Context, record *models.AuditRecord) (err error) {
+	ctx, span := tracing.Start(ctx, "AuditStore.AddAuditRecord", attribute.String("content.uri", record.URI))
+	defer func() { tracing.End(span, err) }()
+	return s.store.AddAuditRecord(ctx, record)
+}
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"context"
+)
+
+// DraftWarmer warms a collection by reading each of its draft pages, so that they are held in
+// the store's working set when the collection is published
+type DraftWarmer struct {
+	store DraftStore
+}
+
+// NewDraftWarmer returns a warmer that reads draft pages from the provided store
+func NewDraftWarmer(store DraftStore) *DraftWarmer {
+	return &DraftWarmer{store: store}
+}
+
+// Warm reads every draft page in the collection
+func (w *DraftWarmer) Warm(ctx context.Context, collectionID string) error {
+	collection, err := w.store.GetCollection(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	for _, item := range collection.Items {
+		if _, err := w.store.GetDraftPage(ctx, collection.ID, item.URI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,9 +1,14 @@
 package service
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/ONSdigital/dp-content-api/auth"
 	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/kafka"
+	"github.com/ONSdigital/dp-content-api/mongo"
+	"github.com/ONSdigital/dp-content-api/tracing"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/http"
@@ -11,15 +16,21 @@ import (
 
 // ExternalServiceList holds the initialiser and initialisation state of external services.
 type ExternalServiceList struct {
-	HealthCheck bool
-	Init        Initialiser
+	HealthCheck    bool
+	MongoDB        bool
+	KafkaProducer  bool
+	TracerProvider bool
+	Init           Initialiser
 }
 
 // NewServiceList creates a new service list with the provided initialiser
 func NewServiceList(initialiser Initialiser) *ExternalServiceList {
 	return &ExternalServiceList{
-		HealthCheck: false,
-		Init:        initialiser,
+		HealthCheck:    false,
+		MongoDB:        false,
+		KafkaProducer:  false,
+		TracerProvider: false,
+		Init:           initialiser,
 	}
 }
 
@@ -42,6 +53,41 @@ func (e *ExternalServiceList) GetHealthCheck(cfg *config.Config, buildTime, gitC
 	return hc, nil
 }
 
+// GetMongoDB creates a MongoDB content store and sets the MongoDB flag to true
+func (e *ExternalServiceList) GetMongoDB(ctx context.Context, cfg *config.Config) (MongoDB, error) {
+	mongoDB, err := e.Init.DoGetMongoDB(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	e.MongoDB = true
+	return mongoDB, nil
+}
+
+// GetKafkaProducer creates a Kafka producer and sets the KafkaProducer flag to true
+func (e *ExternalServiceList) GetKafkaProducer(ctx context.Context, cfg *config.Config) (KafkaProducer, error) {
+	producer, err := e.Init.DoGetKafkaProducer(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	e.KafkaProducer = true
+	return producer, nil
+}
+
+// GetIdentityClient creates the client that identifies the callers of the API
+func (e *ExternalServiceList) GetIdentityClient(cfg *config.Config) IdentityClient {
+	return e.Init.DoGetIdentityClient(cfg)
+}
+
+// GetTracerProvider creates the provider that spans are recorded with and sets the TracerProvider flag to true
+func (e *ExternalServiceList) GetTracerProvider(ctx context.Context, cfg *config.Config) (TracerProvider, error) {
+	provider, err := e.Init.DoGetTracerProvider(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	e.TracerProvider = true
+	return provider, nil
+}
+
 // DoGetHTTPServer creates an HTTP Server with the provided bind address and router
 func (e *Init) DoGetHTTPServer(bindAddr string, router http.Handler) HTTPServer {
 	s := dphttp.NewServer(bindAddr, router)
@@ -58,3 +104,24 @@ func (e *Init) DoGetHealthCheck(cfg *config.Config, buildTime, gitCommit, versio
 	hc := healthcheck.New(versionInfo, cfg.HealthCheckCriticalTimeout, cfg.HealthCheckInterval)
 	return &hc, nil
 }
+
+// DoGetMongoDB creates a MongoDB content store and connects to it
+func (e *Init) DoGetMongoDB(ctx context.Context, cfg *config.Config) (MongoDB, error) {
+	return mongo.New(ctx, cfg.MongoConfig)
+}
+
+// DoGetKafkaProducer creates a Kafka producer for the content event topics
+func (e *Init) DoGetKafkaProducer(ctx context.Context, cfg *config.Config) (KafkaProducer, error) {
+	kafkaCfg := cfg.KafkaConfig
+	return kafka.NewProducer(ctx, kafkaCfg.Brokers, kafkaCfg.Version, kafkaCfg.ContentPublishedTopic, kafkaCfg.ContentDeletedTopic)
+}
+
+// DoGetIdentityClient creates a client that identifies callers with Zebedee
+func (e *Init) DoGetIdentityClient(cfg *config.Config) IdentityClient {
+	return auth.NewZebedeeClient(cfg.ZebedeeURL)
+}
+
+// DoGetTracerProvider creates a tracer provider that sends spans with the configured exporter
+func (e *Init) DoGetTracerProvider(ctx context.Context, cfg *config.Config) (TracerProvider, error) {
+	return tracing.NewTracerProvider(ctx, cfg)
+}
@@ -4,18 +4,30 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/auth"
 	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/scheduler"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate moq -out mock/initialiser.go -pkg mock . Initialiser
 //go:generate moq -out mock/server.go -pkg mock . HTTPServer
 //go:generate moq -out mock/healthCheck.go -pkg mock . HealthChecker
+//go:generate moq -out mock/mongo.go -pkg mock . MongoDB
+//go:generate moq -out mock/kafkaProducer.go -pkg mock . KafkaProducer
+//go:generate moq -out mock/identityClient.go -pkg mock . IdentityClient
 
 // Initialiser defines the methods to initialise external services
 type Initialiser interface {
 	DoGetHTTPServer(bindAddr string, router http.Handler) HTTPServer
 	DoGetHealthCheck(cfg *config.Config, buildTime, gitCommit, version string) (HealthChecker, error)
+	DoGetMongoDB(ctx context.Context, cfg *config.Config) (MongoDB, error)
+	DoGetKafkaProducer(ctx context.Context, cfg *config.Config) (KafkaProducer, error)
+	DoGetIdentityClient(cfg *config.Config) IdentityClient
+	DoGetTracerProvider(ctx context.Context, cfg *config.Config) (TracerProvider, error)
 }
 
 // HTTPServer defines the required methods from the HTTP server
@@ -31,3 +43,33 @@ type HealthChecker interface {
 	Stop()
 	AddCheck(name string, checker healthcheck.Checker) (err error)
 }
+
+// MongoDB defines the required methods from the MongoDB content store
+type MongoDB interface {
+	api.ContentStore
+	api.CollectionStore
+	api.AuditStore
+	api.RedirectStore
+	scheduler.Store
+	Checker(ctx context.Context, state *healthcheck.CheckState) error
+	Close(ctx context.Context) error
+}
+
+// KafkaProducer defines the required methods from the Kafka producer that content events are sent with
+type KafkaProducer interface {
+	event.Sender
+	Checker(ctx context.Context, state *healthcheck.CheckState) error
+	Close(ctx context.Context) error
+}
+
+// IdentityClient defines the required methods from the client that identifies the callers of the API
+type IdentityClient interface {
+	auth.IdentityClient
+	Checker(ctx context.Context, state *healthcheck.CheckState) error
+}
+
+// TracerProvider defines the required methods from the provider that spans are recorded with
+type TracerProvider interface {
+	trace.TracerProvider
+	Shutdown(ctx context.Context) error
+}
@@ -4,6 +4,7 @@
 package mock
 
 import (
+	"context"
 	"net/http"
 	"sync"
 
@@ -27,11 +28,17 @@ var _ service.Initialiser = &InitialiserMock{}
 //             DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 // 	               panic("mock out the DoGetHealthCheck method")
 //             },
-//             DoGetS3UploadedFunc: func(ctx context.Context, cfg *config.Config) (api.S3Clienter, error) {
-// 	               panic("mock out the DoGetS3Uploaded method")
+//             DoGetIdentityClientFunc: func(cfg *config.Config) service.IdentityClient {
+// 	               panic("mock out the DoGetIdentityClient method")
 //             },
-//             DoGetVaultFunc: func(ctx context.Context, cfg *config.Config) (api.VaultClienter, error) {
-// 	               panic("mock out the DoGetVault method")
+//             DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+// 	               panic("mock out the DoGetKafkaProducer method")
+//             },
+//             DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.MongoDB, error) {
+// 	               panic("mock out the DoGetMongoDB method")
+//             },
+//             DoGetTracerProviderFunc: func(ctx context.Context, cfg *config.Config) (service.TracerProvider, error) {
+// 	               panic("mock out the DoGetTracerProvider method")
 //             },
 //         }
 //
@@ -46,6 +53,18 @@ type InitialiserMock struct {
 	// DoGetHealthCheckFunc mocks the DoGetHealthCheck method.
 	DoGetHealthCheckFunc func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error)
 
+	// DoGetIdentityClientFunc mocks the DoGetIdentityClient method.
+	DoGetIdentityClientFunc func(cfg *config.Config) service.IdentityClient
+
+	// DoGetKafkaProducerFunc mocks the DoGetKafkaProducer method.
+	DoGetKafkaProducerFunc func(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error)
+
+	// DoGetMongoDBFunc mocks the DoGetMongoDB method.
+	DoGetMongoDBFunc func(ctx context.Context, cfg *config.Config) (service.MongoDB, error)
+
+	// DoGetTracerProviderFunc mocks the DoGetTracerProvider method.
+	DoGetTracerProviderFunc func(ctx context.Context, cfg *config.Config) (service.TracerProvider, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// DoGetHTTPServer holds details about calls to the DoGetHTTPServer method.
@@ -66,9 +85,39 @@ type InitialiserMock struct {
 			// Version is the version argument value.
 			Version string
 		}
+		// DoGetIdentityClient holds details about calls to the DoGetIdentityClient method.
+		DoGetIdentityClient []struct {
+			// Cfg is the cfg argument value.
+			Cfg *config.Config
+		}
+		// DoGetKafkaProducer holds details about calls to the DoGetKafkaProducer method.
+		DoGetKafkaProducer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg *config.Config
+		}
+		// DoGetMongoDB holds details about calls to the DoGetMongoDB method.
+		DoGetMongoDB []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg *config.Config
+		}
+		// DoGetTracerProvider holds details about calls to the DoGetTracerProvider method.
+		DoGetTracerProvider []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg *config.Config
+		}
 	}
-	lockDoGetHTTPServer  sync.RWMutex
-	lockDoGetHealthCheck sync.RWMutex
+	lockDoGetHTTPServer     sync.RWMutex
+	lockDoGetHealthCheck    sync.RWMutex
+	lockDoGetIdentityClient sync.RWMutex
+	lockDoGetKafkaProducer  sync.RWMutex
+	lockDoGetMongoDB        sync.RWMutex
+	lockDoGetTracerProvider sync.RWMutex
 }
 
 // DoGetHTTPServer calls DoGetHTTPServerFunc.
@@ -148,3 +197,139 @@ func (mock *InitialiserMock) DoGetHealthCheckCalls() []struct {
 	mock.lockDoGetHealthCheck.RUnlock()
 	return calls
 }
+
+// DoGetIdentityClient calls DoGetIdentityClientFunc.
+func (mock *InitialiserMock) DoGetIdentityClient(cfg *config.Config) service.IdentityClient {
+	if mock.DoGetIdentityClientFunc == nil {
+		panic("InitialiserMock.DoGetIdentityClientFunc: method is nil but Initialiser.DoGetIdentityClient was just called")
+	}
+	callInfo := struct {
+		Cfg *config.Config
+	}{
+		Cfg: cfg,
+	}
+	mock.lockDoGetIdentityClient.Lock()
+	mock.calls.DoGetIdentityClient = append(mock.calls.DoGetIdentityClient, callInfo)
+	mock.lockDoGetIdentityClient.Unlock()
+	return mock.DoGetIdentityClientFunc(cfg)
+}
+
+// DoGetIdentityClientCalls gets all the calls that were made to DoGetIdentityClient.
+// Check the length with:
+//     len(mockedInitialiser.DoGetIdentityClientCalls())
+func (mock *InitialiserMock) DoGetIdentityClientCalls() []struct {
+	Cfg *config.Config
+} {
+	var calls []struct {
+		Cfg *config.Config
+	}
+	mock.lockDoGetIdentityClient.RLock()
+	calls = mock.calls.DoGetIdentityClient
+	mock.lockDoGetIdentityClient.RUnlock()
+	return calls
+}
+
+// DoGetKafkaProducer calls DoGetKafkaProducerFunc.
+func (mock *InitialiserMock) DoGetKafkaProducer(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+	if mock.DoGetKafkaProducerFunc == nil {
+		panic("InitialiserMock.DoGetKafkaProducerFunc: method is nil but Initialiser.DoGetKafkaProducer was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}{
+		Ctx: ctx,
+		Cfg: cfg,
+	}
+	mock.lockDoGetKafkaProducer.Lock()
+	mock.calls.DoGetKafkaProducer = append(mock.calls.DoGetKafkaProducer, callInfo)
+	mock.lockDoGetKafkaProducer.Unlock()
+	return mock.DoGetKafkaProducerFunc(ctx, cfg)
+}
+
+// DoGetKafkaProducerCalls gets all the calls that were made to DoGetKafkaProducer.
+// Check the length with:
+//     len(mockedInitialiser.DoGetKafkaProducerCalls())
+func (mock *InitialiserMock) DoGetKafkaProducerCalls() []struct {
+	Ctx context.Context
+	Cfg *config.Config
+} {
+	var calls []struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}
+	mock.lockDoGetKafkaProducer.RLock()
+	calls = mock.calls.DoGetKafkaProducer
+	mock.lockDoGetKafkaProducer.RUnlock()
+	return calls
+}
+
+// DoGetMongoDB calls DoGetMongoDBFunc.
+func (mock *InitialiserMock) DoGetMongoDB(ctx context.Context, cfg *config.Config) (service.MongoDB, error) {
+	if mock.DoGetMongoDBFunc == nil {
+		panic("InitialiserMock.DoGetMongoDBFunc: method is nil but Initialiser.DoGetMongoDB was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}{
+		Ctx: ctx,
+		Cfg: cfg,
+	}
+	mock.lockDoGetMongoDB.Lock()
+	mock.calls.DoGetMongoDB = append(mock.calls.DoGetMongoDB, callInfo)
+	mock.lockDoGetMongoDB.Unlock()
+	return mock.DoGetMongoDBFunc(ctx, cfg)
+}
+
+// DoGetMongoDBCalls gets all the calls that were made to DoGetMongoDB.
+// Check the length with:
+//     len(mockedInitialiser.DoGetMongoDBCalls())
+func (mock *InitialiserMock) DoGetMongoDBCalls() []struct {
+	Ctx context.Context
+	Cfg *config.Config
+} {
+	var calls []struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}
+	mock.lockDoGetMongoDB.RLock()
+	calls = mock.calls.DoGetMongoDB
+	mock.lockDoGetMongoDB.RUnlock()
+	return calls
+}
+
+// DoGetTracerProvider calls DoGetTracerProviderFunc.
+func (mock *InitialiserMock) DoGetTracerProvider(ctx context.Context, cfg *config.Config) (service.TracerProvider, error) {
+	if mock.DoGetTracerProviderFunc == nil {
+		panic("InitialiserMock.DoGetTracerProviderFunc: method is nil but Initialiser.DoGetTracerProvider was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}{
+		Ctx: ctx,
+		Cfg: cfg,
+	}
+	mock.lockDoGetTracerProvider.Lock()
+	mock.calls.DoGetTracerProvider = append(mock.calls.DoGetTracerProvider, callInfo)
+	mock.lockDoGetTracerProvider.Unlock()
+	return mock.DoGetTracerProviderFunc(ctx, cfg)
+}
+
+// DoGetTracerProviderCalls gets all the calls that were made to DoGetTracerProvider.
+// Check the length with:
+//     len(mockedInitialiser.DoGetTracerProviderCalls())
+func (mock *InitialiserMock) DoGetTracerProviderCalls() []struct {
+	Ctx context.Context
+	Cfg *config.Config
+} {
+	var calls []struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}
+	mock.lockDoGetTracerProvider.RLock()
+	calls = mock.calls.DoGetTracerProvider
+	mock.lockDoGetTracerProvider.RUnlock()
+	return calls
+}
@@ -4,38 +4,97 @@ import (
 	"context"
 
 	"github.com/ONSdigital/dp-content-api/api"
+	"github.com/ONSdigital/dp-content-api/auth"
+	"github.com/ONSdigital/dp-content-api/cache"
 	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/event"
+	"github.com/ONSdigital/dp-content-api/metrics"
+	"github.com/ONSdigital/dp-content-api/middleware"
+	"github.com/ONSdigital/dp-content-api/scheduler"
+	"github.com/ONSdigital/dp-content-api/tracing"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
 // Service contains all the configs, server and clients to run the dp-topic-api API
 type Service struct {
-	Config      *config.Config
-	Server      HTTPServer
-	Router      *mux.Router
-	Api         *api.API
-	ServiceList *ExternalServiceList
-	HealthCheck HealthChecker
+	Config         *config.Config
+	Server         HTTPServer
+	Router         *mux.Router
+	Api            *api.API
+	ServiceList    *ExternalServiceList
+	HealthCheck    HealthChecker
+	MongoDB        MongoDB
+	KafkaProducer  KafkaProducer
+	Scheduler      *scheduler.Scheduler
+	TracerProvider TracerProvider
 }
 
 // Run the service
-func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceList, buildTime, gitCommit, version string, svcErrors chan error) (*Service, error) {
+func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceList, buildTime, gitCommit, version string, svcErrors chan error) (svc *Service, err error) {
 
 	log.Event(ctx, "running service", log.INFO)
 
 	log.Event(ctx, "using service configuration", log.Data{"config": cfg}, log.INFO)
 
-	// Get HTTP Server and ... // TODO: Add any middleware that your service requires
+	// Get the tracer provider first, so that every span the service records is sent with it
+	tracerProvider, err := serviceList.GetTracerProvider(ctx, cfg)
+	if err != nil {
+		log.Event(ctx, "could not instantiate tracer provider", log.FATAL, log.Error(err))
+		return nil, err
+	}
+	tracing.SetGlobal(tracerProvider)
+
+	// Shut the tracer provider down if the service fails to start, so that the spans it recorded are not lost
+	defer func() {
+		if err != nil {
+			if shutdownErr := tracerProvider.Shutdown(ctx); shutdownErr != nil {
+				log.Event(ctx, "failed to shutdown tracer provider", log.Error(shutdownErr), log.ERROR)
+			}
+		}
+	}()
+
+	// Get HTTP Server, with the router wrapped in the middleware that every request passes through
 	r := mux.NewRouter()
 
-	s := serviceList.GetHTTPServer(cfg.BindAddr, r)
+	s := serviceList.GetHTTPServer(cfg.BindAddr, middleware.New(cfg).Then(r))
+
+	// Start a span for each request, continuing any trace that the caller propagated
+	r.Use(otelmux.Middleware(cfg.OTServiceName))
+
+	// Record the count and latency of requests to each route, including those refused by the permission checks
+	r.Use(middleware.RouteMetrics)
 
-	// TODO: Add other(s) to serviceList here
+	// Identify the callers of the API, so that the routes that are not public can check their permissions. Callers
+	// are remembered for a short time, so that Zebedee is not asked who they are on every request.
+	identityClient := serviceList.GetIdentityClient(cfg)
+	r.Use(auth.Middleware(auth.NewIdentityCache(identityClient, cfg.IdentityCacheTTL, cfg.IdentityCacheSize)))
+
+	// Get MongoDB
+	mongoDB, err := serviceList.GetMongoDB(ctx, cfg)
+	if err != nil {
+		log.Event(ctx, "could not instantiate mongodb", log.FATAL, log.Error(err))
+		return nil, err
+	}
+
+	// Get Kafka producer
+	kafkaProducer, err := serviceList.GetKafkaProducer(ctx, cfg)
+	if err != nil {
+		log.Event(ctx, "could not instantiate kafka producer", log.FATAL, log.Error(err))
+		return nil, err
+	}
+
+	// Cache published pages in front of MongoDB, removing each page from the cache as its change is sent to Kafka
+	pages := cache.NewPages(cfg.PageCacheSize, cfg.PageCacheMaxBytes, cfg.PageCacheTTL, cfg.MongoConfig.QueryTimeout)
+	eventProducer := cache.NewProducer(event.NewProducer(kafkaProducer, cfg.KafkaConfig.ContentPublishedTopic, cfg.KafkaConfig.ContentDeletedTopic), pages)
+
+	// Get the scheduler that publishes collections at their publish date
+	sched := scheduler.New(mongoDB, mongoDB, scheduler.NewDraftWarmer(mongoDB), eventProducer, cfg.PublishWarmUpPeriod, cfg.PublishRetryInterval)
 
 	// Setup the API
-	a := api.Setup(ctx, r)
+	a := api.Setup(ctx, cfg, r, cache.NewStore(mongoDB, pages), mongoDB, mongoDB, mongoDB, sched, eventProducer)
 
 	hc, err := serviceList.GetHealthCheck(cfg, buildTime, gitCommit, version)
 
@@ -44,13 +103,20 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		return nil, err
 	}
 
-	if err := registerCheckers(ctx, hc); err != nil {
+	if err := registerCheckers(ctx, hc, mongoDB, kafkaProducer, identityClient, pages); err != nil {
 		return nil, errors.Wrap(err, "unable to register checkers")
 	}
 
 	r.StrictSlash(true).Path("/health").HandlerFunc(hc.Handler)
+	r.StrictSlash(true).Path("/metrics").Handler(metrics.Handler())
 	hc.Start(ctx)
 
+	// Reload any collections scheduled before the service last stopped
+	if err := sched.Start(ctx); err != nil {
+		log.Event(ctx, "could not start scheduler", log.FATAL, log.Error(err))
+		return nil, err
+	}
+
 	// Run the http server in a new go-routine
 	go func() {
 		if err := s.ListenAndServe(); err != nil {
@@ -59,12 +125,16 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 	}()
 
 	return &Service{
-		Config:      cfg,
-		Router:      r,
-		Api:         a,
-		HealthCheck: hc,
-		ServiceList: serviceList,
-		Server:      s,
+		Config:         cfg,
+		Router:         r,
+		Api:            a,
+		HealthCheck:    hc,
+		ServiceList:    serviceList,
+		Server:         s,
+		MongoDB:        mongoDB,
+		KafkaProducer:  kafkaProducer,
+		Scheduler:      sched,
+		TracerProvider: tracerProvider,
 	}, nil
 }
 
@@ -85,13 +155,43 @@ func (svc *Service) Close(ctx context.Context) error {
 			svc.HealthCheck.Stop()
 		}
 
+		// stop publishing scheduled collections before the service stops serving them
+		if svc.Scheduler != nil {
+			if err := svc.Scheduler.Close(ctx); err != nil {
+				log.Event(ctx, "failed to stop scheduler", log.Error(err), log.ERROR)
+				hasShutdownError = true
+			}
+		}
+
 		// stop any incoming requests before closing any outbound connections
 		if err := svc.Server.Shutdown(ctx); err != nil {
 			log.Event(ctx, "failed to shutdown http server", log.Error(err), log.ERROR)
 			hasShutdownError = true
 		}
 
-		// TODO: Close other dependencies, in the expected order
+		// close the Kafka producer once no more events can be sent
+		if svc.ServiceList.KafkaProducer {
+			if err := svc.KafkaProducer.Close(ctx); err != nil {
+				log.Event(ctx, "failed to close kafka producer", log.Error(err), log.ERROR)
+				hasShutdownError = true
+			}
+		}
+
+		// close MongoDB once no more requests can be received
+		if svc.ServiceList.MongoDB {
+			if err := svc.MongoDB.Close(ctx); err != nil {
+				log.Event(ctx, "failed to close mongodb", log.Error(err), log.ERROR)
+				hasShutdownError = true
+			}
+		}
+
+		// shut the tracer provider down last, sending any spans recorded while the service stopped
+		if svc.ServiceList.TracerProvider {
+			if err := svc.TracerProvider.Shutdown(ctx); err != nil {
+				log.Event(ctx, "failed to shutdown tracer provider", log.Error(err), log.ERROR)
+				hasShutdownError = true
+			}
+		}
 	}()
 
 	// wait for shutdown success (via cancel) or failure (timeout)
@@ -115,9 +215,36 @@ func (svc *Service) Close(ctx context.Context) error {
 }
 
 func registerCheckers(ctx context.Context,
-	hc HealthChecker) (err error) {
+	hc HealthChecker,
+	mongoDB MongoDB,
+	kafkaProducer KafkaProducer,
+	identityClient IdentityClient,
+	pages *cache.Pages) (err error) {
 
-	// TODO: add other health checks here, as per dp-upload-service
+	hasErrors := false
 
+	if err = hc.AddCheck("mongodb", mongoDB.Checker); err != nil {
+		hasErrors = true
+		log.Event(ctx, "error adding check for mongodb", log.ERROR, log.Error(err))
+	}
+
+	if err = hc.AddCheck("kafka producer", kafkaProducer.Checker); err != nil {
+		hasErrors = true
+		log.Event(ctx, "error adding check for kafka producer", log.ERROR, log.Error(err))
+	}
+
+	if err = hc.AddCheck("zebedee", identityClient.Checker); err != nil {
+		hasErrors = true
+		log.Event(ctx, "error adding check for zebedee", log.ERROR, log.Error(err))
+	}
+
+	if err = hc.AddCheck("page cache", pages.Checker); err != nil {
+		hasErrors = true
+		log.Event(ctx, "error adding check for page cache", log.ERROR, log.Error(err))
+	}
+
+	if hasErrors {
+		return errors.New("Error(s) registering checkers for healthcheck")
+	}
 	return nil
 }
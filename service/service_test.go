@@ -11,12 +11,15 @@ import (
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 
 	"github.com/ONSdigital/dp-content-api/config"
+	"github.com/ONSdigital/dp-content-api/models"
 	"github.com/ONSdigital/dp-content-api/service"
 	"github.com/ONSdigital/dp-content-api/service/mock"
 	serviceMock "github.com/ONSdigital/dp-content-api/service/mock"
 
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 var (
@@ -28,7 +31,11 @@ var (
 )
 
 var (
-	errHealthcheck = errors.New("healthCheck error")
+	errHealthcheck    = errors.New("healthCheck error")
+	errMongoDB        = errors.New("mongoDB error")
+	errKafka          = errors.New("kafka producer error")
+	errScheduler      = errors.New("scheduler error")
+	errTracerProvider = errors.New("tracer provider error")
 )
 
 var funcDoGetHealthcheckErr = func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
@@ -39,6 +46,37 @@ var funcDoGetHTTPServerNil = func(bindAddr string, router http.Handler) service.
 	return nil
 }
 
+var funcDoGetIdentityClient = func(cfg *config.Config) service.IdentityClient {
+	return &serviceMock.IdentityClientMock{}
+}
+
+var funcDoGetTracerProvider = func(ctx context.Context, cfg *config.Config) (service.TracerProvider, error) {
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(tracetest.NewNoopExporter())), nil
+}
+
+// shutdownRecorder is a span processor that records whether the provider it was registered with was shut down
+type shutdownRecorder struct {
+	sdktrace.SpanProcessor
+	shutdown bool
+}
+
+func (r *shutdownRecorder) Shutdown(ctx context.Context) error {
+	r.shutdown = true
+	return r.SpanProcessor.Shutdown(ctx)
+}
+
+var funcDoGetTracerProviderErr = func(ctx context.Context, cfg *config.Config) (service.TracerProvider, error) {
+	return nil, errTracerProvider
+}
+
+var funcDoGetMongoDBErr = func(ctx context.Context, cfg *config.Config) (service.MongoDB, error) {
+	return nil, errMongoDB
+}
+
+var funcDoGetKafkaProducerErr = func(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+	return nil, errKafka
+}
+
 func TestRun(t *testing.T) {
 
 	Convey("Having a set of mocked dependencies", t, func() {
@@ -51,6 +89,12 @@ func TestRun(t *testing.T) {
 			StartFunc:    func(ctx context.Context) {},
 		}
 
+		mongoMock := &serviceMock.MongoDBMock{
+			GetScheduledCollectionsFunc: func(ctx context.Context) ([]*models.Collection, error) { return nil, nil },
+		}
+
+		kafkaProducerMock := &serviceMock.KafkaProducerMock{}
+
 		serverWg := &sync.WaitGroup{}
 		serverMock := &serviceMock.HTTPServerMock{
 			ListenAndServeFunc: func() error {
@@ -78,12 +122,51 @@ func TestRun(t *testing.T) {
 			return failingServerMock
 		}
 
+		funcDoGetMongoDBOk := func(ctx context.Context, cfg *config.Config) (service.MongoDB, error) {
+			return mongoMock, nil
+		}
+
+		funcDoGetKafkaProducerOk := func(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+			return kafkaProducerMock, nil
+		}
+
+		Convey("Given that initialising the tracer provider returns an error", func() {
+
+			// setup (run before each `Convey` at this scope / indentation):
+			initMock := &serviceMock.InitialiserMock{
+				DoGetHTTPServerFunc:     funcDoGetHTTPServerNil,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProviderErr,
+				DoGetHealthCheckFunc:    funcDoGetHealthcheckOk,
+				DoGetMongoDBFunc:        funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc:  funcDoGetKafkaProducerOk,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			_, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error and no other dependency is initialised", func() {
+				So(err, ShouldResemble, errTracerProvider)
+				So(svcList.TracerProvider, ShouldBeFalse)
+				So(svcList.MongoDB, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+
+			Reset(func() {
+				// This reset is run after each `Convey` at the same scope (indentation)
+			})
+		})
+
 		Convey("Given that initialising healthcheck returns an error", func() {
 
 			// setup (run before each `Convey` at this scope / indentation):
 			initMock := &serviceMock.InitialiserMock{
-				DoGetHTTPServerFunc:  funcDoGetHTTPServerNil,
-				DoGetHealthCheckFunc: funcDoGetHealthcheckErr,
+				DoGetHTTPServerFunc:     funcDoGetHTTPServerNil,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
+				DoGetHealthCheckFunc:    funcDoGetHealthcheckErr,
+				DoGetMongoDBFunc:        funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc:  funcDoGetKafkaProducerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -99,12 +182,65 @@ func TestRun(t *testing.T) {
 			})
 		})
 
+		Convey("Given that initialising mongoDB returns an error", func() {
+
+			// setup (run before each `Convey` at this scope / indentation):
+			recorder := &shutdownRecorder{SpanProcessor: sdktrace.NewSimpleSpanProcessor(tracetest.NewNoopExporter())}
+			initMock := &serviceMock.InitialiserMock{
+				DoGetHTTPServerFunc:     funcDoGetHTTPServerNil,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: func(ctx context.Context, cfg *config.Config) (service.TracerProvider, error) {
+					return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), nil
+				},
+				DoGetMongoDBFunc: funcDoGetMongoDBErr,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			_, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error and the flag is not set", func() {
+				So(err, ShouldResemble, errMongoDB)
+				So(svcList.MongoDB, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+
+			Convey("Then the tracer provider is shut down", func() {
+				So(recorder.shutdown, ShouldBeTrue)
+			})
+		})
+
+		Convey("Given that initialising the kafka producer returns an error", func() {
+
+			// setup (run before each `Convey` at this scope / indentation):
+			initMock := &serviceMock.InitialiserMock{
+				DoGetHTTPServerFunc:     funcDoGetHTTPServerNil,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
+				DoGetMongoDBFunc:        funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc:  funcDoGetKafkaProducerErr,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			_, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error and the flag is not set", func() {
+				So(err, ShouldResemble, errKafka)
+				So(svcList.MongoDB, ShouldBeTrue)
+				So(svcList.KafkaProducer, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+		})
+
 		Convey("Given that all dependencies are successfully initialised", func() {
 
 			// setup (run before each `Convey` at this scope / indentation):
 			initMock := &serviceMock.InitialiserMock{
-				DoGetHTTPServerFunc:  funcDoGetHTTPServer,
-				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
+				DoGetHTTPServerFunc:     funcDoGetHTTPServer,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
+				DoGetHealthCheckFunc:    funcDoGetHealthcheckOk,
+				DoGetMongoDBFunc:        funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc:  funcDoGetKafkaProducerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -114,10 +250,17 @@ func TestRun(t *testing.T) {
 			Convey("Then service Run succeeds and all the flags are set", func() {
 				So(err, ShouldBeNil)
 				So(svcList.HealthCheck, ShouldBeTrue)
+				So(svcList.MongoDB, ShouldBeTrue)
+				So(svcList.KafkaProducer, ShouldBeTrue)
+				So(svcList.TracerProvider, ShouldBeTrue)
 			})
 
 			Convey("The checkers are registered and the healthcheck and http server started", func() {
-				So(len(hcMock.AddCheckCalls()), ShouldEqual, 0)
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 4)
+				So(hcMock.AddCheckCalls()[0].Name, ShouldEqual, "mongodb")
+				So(hcMock.AddCheckCalls()[1].Name, ShouldEqual, "kafka producer")
+				So(hcMock.AddCheckCalls()[2].Name, ShouldEqual, "zebedee")
+				So(hcMock.AddCheckCalls()[3].Name, ShouldEqual, "page cache")
 				So(len(initMock.DoGetHTTPServerCalls()), ShouldEqual, 1)
 				So(initMock.DoGetHTTPServerCalls()[0].BindAddr, ShouldEqual, "localhost:26400")
 				So(len(hcMock.StartCalls()), ShouldEqual, 1)
@@ -126,13 +269,38 @@ func TestRun(t *testing.T) {
 				So(len(serverMock.ListenAndServeCalls()), ShouldEqual, 1)
 			})
 
+			Convey("The scheduled collections are reloaded", func() {
+				So(len(mongoMock.GetScheduledCollectionsCalls()), ShouldEqual, 1)
+			})
+
 			Reset(func() {
 				// This reset is run after each `Convey` at the same scope (indentation)
 			})
 		})
 
-		// ADD CODE: put this code in, if you have Checkers to register
-		/*Convey("Given that Checkers cannot be registered", func() {
+		Convey("Given that the scheduled collections cannot be reloaded", func() {
+
+			// setup (run before each `Convey` at this scope / indentation):
+			mongoMock.GetScheduledCollectionsFunc = func(ctx context.Context) ([]*models.Collection, error) { return nil, errScheduler }
+			initMock := &serviceMock.InitialiserMock{
+				DoGetHTTPServerFunc:     funcDoGetHTTPServer,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
+				DoGetHealthCheckFunc:    funcDoGetHealthcheckOk,
+				DoGetMongoDBFunc:        funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc:  funcDoGetKafkaProducerOk,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			_, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error before the http server is started", func() {
+				So(err, ShouldResemble, errScheduler)
+				So(len(serverMock.ListenAndServeCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("Given that Checkers cannot be registered", func() {
 
 			// setup (run before each `Convey` at this scope / indentation):
 			errAddheckFail := errors.New("Error(s) registering checkers for healthcheck")
@@ -142,34 +310,46 @@ func TestRun(t *testing.T) {
 			}
 
 			initMock := &serviceMock.InitialiserMock{
-				DoGetHTTPServerFunc: funcDoGetHTTPServerNil,
+				DoGetHTTPServerFunc:     funcDoGetHTTPServerNil,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
 				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 					return hcMockAddFail, nil
 				},
-				// ADD CODE: add the checkers that you want to register here
+				DoGetMongoDBFunc:       funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc: funcDoGetKafkaProducerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
 			_, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
 
 			Convey("Then service Run fails, but all checks try to register", func() {
-				So(err, ShouldBeNil)
+				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldResemble, fmt.Sprintf("unable to register checkers: %s", errAddheckFail.Error()))
 				So(svcList.HealthCheck, ShouldBeTrue)
-				// ADD CODE: add code to confirm checkers exist
-				So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 0) // ADD CODE: change the '0' to the number of checkers you have registered
+				So(svcList.MongoDB, ShouldBeTrue)
+				So(svcList.KafkaProducer, ShouldBeTrue)
+				So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 4)
+				So(hcMockAddFail.AddCheckCalls()[0].Name, ShouldResemble, "mongodb")
+				So(hcMockAddFail.AddCheckCalls()[1].Name, ShouldResemble, "kafka producer")
+				So(hcMockAddFail.AddCheckCalls()[2].Name, ShouldResemble, "zebedee")
+				So(hcMockAddFail.AddCheckCalls()[3].Name, ShouldResemble, "page cache")
 			})
 			Reset(func() {
 				// This reset is run after each `Convey` at the same scope (indentation)
 			})
-		})*/
+		})
 
 		Convey("Given that all dependencies are successfully initialised but the http server fails", func() {
 
 			// setup (run before each `Convey` at this scope / indentation):
 			initMock := &serviceMock.InitialiserMock{
-				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
-				DoGetHTTPServerFunc:  funcDoGetFailingHTTPSerer,
+				DoGetHealthCheckFunc:    funcDoGetHealthcheckOk,
+				DoGetHTTPServerFunc:     funcDoGetFailingHTTPSerer,
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
+				DoGetMongoDBFunc:        funcDoGetMongoDBOk,
+				DoGetKafkaProducerFunc:  funcDoGetKafkaProducerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -206,6 +386,8 @@ func TestClose(t *testing.T) {
 			StopFunc:     func() { hcStopped = true },
 		}
 
+		serverStopped := false
+
 		// server Shutdown will fail if healthcheck is not stopped
 		serverMock := &mock.HTTPServerMock{
 			ListenAndServeFunc: func() error { return nil },
@@ -213,6 +395,28 @@ func TestClose(t *testing.T) {
 				if !hcStopped {
 					return errors.New("Server stopped before healthcheck")
 				}
+				serverStopped = true
+				return nil
+			},
+		}
+
+		// mongoDB Close will fail if the server is still accepting requests
+		mongoMock := &mock.MongoDBMock{
+			GetScheduledCollectionsFunc: func(ctx context.Context) ([]*models.Collection, error) { return nil, nil },
+			CloseFunc: func(ctx context.Context) error {
+				if !serverStopped {
+					return errors.New("MongoDB closed before http server")
+				}
+				return nil
+			},
+		}
+
+		// kafka producer Close will fail if the server is still accepting requests
+		kafkaProducerMock := &mock.KafkaProducerMock{
+			CloseFunc: func(ctx context.Context) error {
+				if !serverStopped {
+					return errors.New("Kafka producer closed before http server")
+				}
 				return nil
 			},
 		}
@@ -220,10 +424,16 @@ func TestClose(t *testing.T) {
 		Convey("Closing the service results in all the dependencies being closed in the expected order", func() {
 
 			initMock := &mock.InitialiserMock{
-				DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer { return serverMock },
+				DoGetHTTPServerFunc:     func(bindAddr string, router http.Handler) service.HTTPServer { return serverMock },
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
 				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 					return hcMock, nil
 				},
+				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.MongoDB, error) { return mongoMock, nil },
+				DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+					return kafkaProducerMock, nil
+				},
 			}
 
 			svcErrors := make(chan error, 1)
@@ -235,6 +445,8 @@ func TestClose(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(len(hcMock.StopCalls()), ShouldEqual, 1)
 			So(len(serverMock.ShutdownCalls()), ShouldEqual, 1)
+			So(len(kafkaProducerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(mongoMock.CloseCalls()), ShouldEqual, 1)
 		})
 
 		Convey("If services fail to stop, the Close operation tries to close all dependencies and returns an error", func() {
@@ -247,10 +459,16 @@ func TestClose(t *testing.T) {
 			}
 
 			initMock := &mock.InitialiserMock{
-				DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer { return failingserverMock },
+				DoGetHTTPServerFunc:     func(bindAddr string, router http.Handler) service.HTTPServer { return failingserverMock },
+				DoGetIdentityClientFunc: funcDoGetIdentityClient,
+				DoGetTracerProviderFunc: funcDoGetTracerProvider,
 				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 					return hcMock, nil
 				},
+				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.MongoDB, error) { return mongoMock, nil },
+				DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Config) (service.KafkaProducer, error) {
+					return kafkaProducerMock, nil
+				},
 			}
 
 			svcErrors := make(chan error, 1)
@@ -259,9 +477,11 @@ func TestClose(t *testing.T) {
 			So(err, ShouldBeNil)
 
 			err = svc.Close(context.Background())
-			//So(err, ShouldNotBeNil)
+			So(err, ShouldNotBeNil)
 			So(len(hcMock.StopCalls()), ShouldEqual, 1)
 			So(len(failingserverMock.ShutdownCalls()), ShouldEqual, 1)
+			So(len(kafkaProducerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(mongoMock.CloseCalls()), ShouldEqual, 1)
 		})
 
 		Convey("If service times out while shutting down, the Close operation fails with the expected error", func() {
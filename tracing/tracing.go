@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ONSdigital/dp-content-api/config"
+)
+
+// TracerName is the name of the tracer that the spans of the service are recorded with
+const TracerName = "github.com/ONSdigital/dp-content-api"
+
+// The exporters that spans can be sent with
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// NewTracerProvider returns a provider that sends spans with the configured exporter. With no exporter, spans are
+// still created so that trace context is propagated, but they are discarded.
+func NewTracerProvider(ctx context.Context, cfg *config.Config) (*sdktrace.TracerProvider, error) {
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.OTServiceName))
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	switch cfg.OTExporter {
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(cfg.OTExporterOTLPEndpoint), otlptracehttp.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterNone, "":
+		opts = append(opts, sdktrace.WithSyncer(discardExporter{}))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q, must be one of: otlp, stdout, none", cfg.OTExporter)
+	}
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// discardExporter drops every span. The provider is given it when no exporter is configured, as a provider
+// without any span processor fails to shut down.
+type discardExporter struct{}
+
+func (discardExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return nil
+}
+
+func (discardExporter) Shutdown(ctx context.Context) error { return nil }
+
+// SetGlobal makes the provider the one that every span is recorded with, and propagates W3C trace context and
+// baggage in the headers of incoming and outgoing requests
+func SetGlobal(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Start starts a span as a child of any span in the context, returning the context holding the new span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, recording the error if the operation it spans failed
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-content-api/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the configuration of the service", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+
+		for _, exporter := range []string{ExporterOTLP, ExporterStdout, ExporterNone} {
+			Convey("Then a provider is returned for the "+exporter+" exporter, which can be shut down", func() {
+				cfg.OTExporter = exporter
+				provider, err := NewTracerProvider(ctx, cfg)
+				So(err, ShouldBeNil)
+				So(provider, ShouldNotBeNil)
+				So(provider.Shutdown(ctx), ShouldBeNil)
+			})
+		}
+
+		Convey("Then an exporter that is not supported is refused", func() {
+			cfg.OTExporter = "zipkin"
+			_, err := NewTracerProvider(ctx, cfg)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSpans(t *testing.T) {
+	Convey("Given spans are recorded in memory", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		SetGlobal(provider)
+
+		Convey("When a span is started within another and both end", func() {
+			ctx, parent := Start(context.Background(), "parent")
+			_, child := Start(ctx, "child", attribute.String("content.uri", "/aboutus"))
+			End(child, errors.New("store failure"))
+			End(parent, nil)
+
+			spans := recorder.Ended()
+			So(spans, ShouldHaveLength, 2)
+
+			Convey("Then the child span is in the same trace with its attributes and the error", func() {
+				So(spans[0].Name(), ShouldEqual, "child")
+				So(spans[0].Parent().SpanID(), ShouldEqual, spans[1].SpanContext().SpanID())
+				So(spans[0].Attributes(), ShouldContain, attribute.String("content.uri", "/aboutus"))
+				So(spans[0].Status().Code, ShouldEqual, codes.Error)
+				So(spans[0].Status().Description, ShouldEqual, "store failure")
+				So(spans[0].Events(), ShouldHaveLength, 1)
+			})
+
+			Convey("Then the span that succeeded has no error", func() {
+				So(spans[1].Name(), ShouldEqual, "parent")
+				So(spans[1].Status().Code, ShouldEqual, codes.Unset)
+			})
+		})
+	})
+}